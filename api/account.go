@@ -208,6 +208,12 @@ func (s *Server) accountDownloadExport(c *gin.Context) {
 		return
 	}
 
+	// the file is not ready yet or removed after its expiry
+	if a.FileKey == "" {
+		abortWithEncoding(c, http.StatusNotFound, errorNoArchiveFound)
+		return
+	}
+
 	sess, err := session.NewSession(s.awsConf)
 	if err != nil {
 		log.Debug(err)
@@ -236,6 +242,12 @@ func (s *Server) accountDelete(c *gin.Context) {
 		return
 	}
 
+	if err := s.revokeAccountTokens(c, account.AccountNumber); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+		return
+	}
+
 	job, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
 		Name: "delete_user_data",
 		Args: []tasks.Arg{
@@ -269,6 +281,12 @@ func (s *Server) adminAccountDelete(c *gin.Context) {
 
 	result := make(map[string]string)
 	for _, accountNumber := range params.AccountNumbers {
+		if err := s.revokeAccountTokens(c, accountNumber); err != nil {
+			log.Debug(err)
+			abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+			return
+		}
+
 		job, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
 			Name: "delete_user_data",
 			Args: []tasks.Arg{
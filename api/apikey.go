@@ -0,0 +1,279 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// Scopes of api keys
+const (
+	scopeArchivesSubmit  = "archives:submit"
+	scopeArchivesReparse = "archives:reparse"
+	scopeArchivesRemove  = "archives:remove"
+	scopeAccountsDelete  = "accounts:delete"
+	scopeJobsRead        = "jobs:read"
+	scopeJobsRequeue     = "jobs:requeue"
+	scopeMetricsRead     = "metrics:read"
+	scopeAPIKeysManage   = "apikeys:manage"
+	scopeAuditRead       = "audit:read"
+)
+
+// APIKeyScopes are all scopes an api key can have
+var APIKeyScopes = []string{
+	scopeArchivesSubmit,
+	scopeArchivesReparse,
+	scopeArchivesRemove,
+	scopeAccountsDelete,
+	scopeJobsRead,
+	scopeJobsRequeue,
+	scopeMetricsRead,
+	scopeAPIKeysManage,
+	scopeAuditRead,
+}
+
+// maxAuditBodySize is the size of the largest request body written to audit logs
+const maxAuditBodySize = 64 * 1024
+
+// hashSecret returns the hash of an api key or a refresh token to store.
+// They are random, so they are hashed without salt.
+func hashSecret(secret string) string {
+	digest := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(digest[:])
+}
+
+func validateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		known := false
+		for _, s := range APIKeyScopes {
+			if s == scope {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown scope: %s", scope)
+		}
+	}
+	return nil
+}
+
+// CreateAPIKey creates a random api key with scopes. Only the hash of the key is stored,
+// so the returned key can not be shown again.
+func CreateAPIKey(ctx context.Context, s store.Store, name string, scopes []string, expireAt *time.Time) (string, *store.APIKey, error) {
+	if name == "" {
+		return "", nil, fmt.Errorf("empty api key name")
+	}
+
+	if err := validateScopes(scopes); err != nil {
+		return "", nil, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	key := hex.EncodeToString(b)
+
+	apiKey, err := s.AddAPIKey(ctx, name, hashSecret(key), scopes, expireAt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return key, apiKey, nil
+}
+
+// apikeyAuthentication is a middleware to authorize admins by the api key in the Api-Token header
+func (s *Server) apikeyAuthentication() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiToken := c.GetHeader("Api-Token")
+		if apiToken == "" {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		key, err := s.store.UseAPIKey(c, hashSecret(apiToken))
+		if shouldInterupt(err, c) {
+			return
+		}
+
+		if key == nil || (key.ExpireAt != nil && !key.ExpireAt.After(time.Now())) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		c.Set("apikey", key)
+		c.Next()
+	}
+}
+
+// requireScope is a middleware to allow api keys with a scope only
+func (s *Server) requireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.MustGet("apikey").(*store.APIKey)
+		if !key.HasScope(scope) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// auditLog is a middleware to write the request of an api key to audit logs after it is handled,
+// including the requests denied for lack of scopes
+func (s *Server) auditLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		params := make(map[string]interface{})
+
+		if c.Request.Body != nil {
+			body, err := ioutil.ReadAll(c.Request.Body)
+			if err != nil {
+				abortWithEncoding(c, http.StatusBadRequest, errorCannotParseRequest)
+				return
+			}
+			c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			switch {
+			case len(body) == 0:
+			case len(body) > maxAuditBodySize:
+				params["body_size"] = len(body)
+			case json.Valid(body):
+				params["body"] = json.RawMessage(body)
+			default:
+				params["body"] = string(body)
+			}
+		}
+
+		if query := c.Request.URL.Query(); len(query) > 0 {
+			params["query"] = query
+		}
+		if len(c.Params) > 0 {
+			path := make(map[string]string, len(c.Params))
+			for _, p := range c.Params {
+				path[p.Key] = p.Value
+			}
+			params["path"] = path
+		}
+
+		c.Next()
+
+		key := c.MustGet("apikey").(*store.APIKey)
+		if err := s.store.AddAuditLog(context.Background(), &store.AuditLog{
+			Actor:      key.Name,
+			Action:     c.Request.Method + " " + c.FullPath(),
+			Params:     params,
+			Status:     c.Writer.Status(),
+			RemoteAddr: c.ClientIP(),
+		}); err != nil {
+			log.WithError(err).WithField("actor", key.Name).Error("failed to write audit log")
+		}
+	}
+}
+
+// adminListAPIKeys lists api keys without their hashes
+func (s *Server) adminListAPIKeys(c *gin.Context) {
+	keys, err := s.store.GetAPIKeys(c)
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": keys})
+}
+
+// adminCreateAPIKey creates an api key and responds the key, which is shown only once
+func (s *Server) adminCreateAPIKey(c *gin.Context) {
+	var params struct {
+		Name     string     `json:"name" binding:"required"`
+		Scopes   []string   `json:"scopes"`
+		ExpireAt *time.Time `json:"expire_at"`
+	}
+
+	if err := c.BindJSON(&params); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if err := validateScopes(params.Scopes); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	keys, err := s.store.GetAPIKeys(c)
+	if shouldInterupt(err, c) {
+		return
+	}
+	for _, k := range keys {
+		if k.Name == params.Name {
+			abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+			return
+		}
+	}
+
+	key, apiKey, err := CreateAPIKey(c, s.store, params.Name, params.Scopes, params.ExpireAt)
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"result": gin.H{
+		"key":     key,
+		"api_key": apiKey,
+	}})
+}
+
+// adminDeleteAPIKey deletes an api key by its name
+func (s *Server) adminDeleteAPIKey(c *gin.Context) {
+	if err := s.store.DeleteAPIKey(c, c.Param("name")); shouldInterupt(err, c) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "OK"})
+}
+
+// adminListAuditLogs lists audit logs newest first, optionally of an actor and older than a log
+func (s *Server) adminListAuditLogs(c *gin.Context) {
+	params := &store.AuditLogQueryParam{Limit: 100}
+
+	if actor := c.Query("actor"); actor != "" {
+		params.Actor = &actor
+	}
+
+	if before := c.Query("before"); before != "" {
+		id, err := strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+			return
+		}
+		params.Before = &id
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.ParseUint(limit, 10, 64)
+		if err != nil || n == 0 || n > 1000 {
+			abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+			return
+		}
+		params.Limit = n
+	}
+
+	logs, err := s.store.GetAuditLogs(c, params)
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": logs})
+}
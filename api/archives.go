@@ -13,6 +13,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/bitmark-inc/spring-app-api/archives/facebook"
+	"github.com/bitmark-inc/spring-app-api/pipeline"
 	"github.com/bitmark-inc/spring-app-api/s3util"
 	"github.com/bitmark-inc/spring-app-api/store"
 )
@@ -95,41 +96,60 @@ func (s *Server) uploadArchiveByURL(c *gin.Context) {
 		return
 	}
 
-	job, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
-		Name: "download_archive",
-		Args: []tasks.Arg{
-			{
-				Type:  "string",
-				Value: params.FileURL,
-			},
-			{
-				Type:  "string",
-				Value: archiveType,
-			},
-			{
-				Type:  "string",
-				Value: params.RawCookie,
-			},
-			{
-				Type:  "string",
-				Value: account.AccountNumber,
-			},
-			{
-				Type:  "int64",
-				Value: archiveRecord.ID,
-			},
-		},
+	workflowID, err := s.startArchiveWorkflow(c, pipeline.DownloadArchive, pipeline.ArchiveArgs{
+		AccountNumber: account.AccountNumber,
+		ArchiveID:     archiveRecord.ID,
+		ArchiveType:   archiveType,
+		FileURL:       params.FileURL,
+		RawCookie:     params.RawCookie,
 	})
 	if err != nil {
 		log.Debug(err)
 		abortWithEncoding(c, http.StatusBadRequest, errorInternalServer)
 		return
 	}
-	log.Info("Enqueued job with id:", job.Signature.UUID)
+	log.Info("Started workflow with id:", workflowID)
 
 	c.JSON(http.StatusAccepted, gin.H{"result": "ok"})
 }
 
+// archiveStageSummary summarizes the stages of processing an archive
+type archiveStageSummary struct {
+	Total        int        `json:"total"`
+	Finished     int        `json:"finished"`
+	Failed       int        `json:"failed"`
+	CurrentStage string     `json:"current_stage,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// summarizeArchiveStages summarizes stages ordered by their starting time.
+// The current stage is the last started one.
+func summarizeArchiveStages(stages []store.FBArchiveStage) archiveStageSummary {
+	var summary archiveStageSummary
+	for i, stage := range stages {
+		summary.Total++
+		switch stage.Status {
+		case store.FBArchiveStageStatusFinished:
+			summary.Finished++
+		case store.FBArchiveStageStatusFailed:
+			summary.Failed++
+			summary.LastError = stage.Error
+		}
+
+		updatedAt := &stages[i].StartedAt
+		if stage.FinishedAt != nil {
+			updatedAt = stage.FinishedAt
+		}
+		if summary.UpdatedAt == nil || updatedAt.After(*summary.UpdatedAt) {
+			summary.UpdatedAt = updatedAt
+		}
+
+		summary.CurrentStage = stage.Name
+	}
+	return summary
+}
+
 func (s *Server) getAllArchives(c *gin.Context) {
 	account := c.MustGet("account").(*store.Account)
 
@@ -141,8 +161,74 @@ func (s *Server) getAllArchives(c *gin.Context) {
 		return
 	}
 
+	archiveIDs := make([]int64, 0, len(archives))
+	for _, a := range archives {
+		archiveIDs = append(archiveIDs, a.ID)
+	}
+
+	stages, err := s.store.GetFBArchiveStages(c, archiveIDs)
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	archiveStages := make(map[int64][]store.FBArchiveStage)
+	for _, stage := range stages {
+		archiveStages[stage.ArchiveID] = append(archiveStages[stage.ArchiveID], stage)
+	}
+
+	type archiveWithStages struct {
+		store.FBArchive
+		Stages archiveStageSummary `json:"stages"`
+	}
+
+	result := make([]archiveWithStages, 0, len(archives))
+	for _, a := range archives {
+		result = append(result, archiveWithStages{
+			FBArchive: a,
+			Stages:    summarizeArchiveStages(archiveStages[a.ID]),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": result,
+	})
+}
+
+// getArchive returns an archive of the account with the stages of processing it
+func (s *Server) getArchive(c *gin.Context) {
+	account := c.MustGet("account").(*store.Account)
+
+	archiveID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	archives, err := s.store.GetFBArchives(c, &store.FBArchiveQueryParam{
+		ID:            &archiveID,
+		AccountNumber: &account.AccountNumber,
+	})
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	if len(archives) == 0 {
+		abortWithEncoding(c, http.StatusNotFound, errorNoArchiveFound)
+		return
+	}
+
+	stages, err := s.store.GetFBArchiveStages(c, []int64{archiveID})
+	if shouldInterupt(err, c) {
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"result": archives,
+		"result": gin.H{
+			"archive": archives[0],
+			"stages":  stages,
+			"summary": summarizeArchiveStages(stages),
+		},
 	})
 }
 
@@ -188,47 +274,19 @@ func (s *Server) adminAckArchiveUploaded(c *gin.Context) {
 		return
 	}
 
-	// FIXME: this cause additional download the archive file
-	if _, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
-		Name: "generate_hash_content",
-		Args: []tasks.Arg{
-			{
-				Type:  "string",
-				Value: params.FileKey,
-			},
-			{
-				Type:  "int64",
-				Value: archiveID,
-			},
-		},
-	}); err != nil {
-		panic(err)
-	}
-
-	job, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
-		Name: "parse_archive",
-		Args: []tasks.Arg{
-			{
-				Type:  "string",
-				Value: keys[1],
-			},
-			{
-				Type:  "string",
-				Value: keys[0],
-			},
-			{
-				Type:  "int64",
-				Value: archiveID,
-			},
-		},
+	// FIXME: generating the hash content causes additional download the archive file
+	workflowID, err := s.startArchiveWorkflow(c, pipeline.ImportArchive, pipeline.ArchiveArgs{
+		AccountNumber: keys[0],
+		ArchiveID:     archiveID,
+		ArchiveType:   keys[1],
+		S3Key:         params.FileKey,
 	})
-
 	if err != nil {
 		log.Debug(err)
 		abortWithEncoding(c, http.StatusBadRequest, errorInternalServer)
 		return
 	}
-	log.Info("Enqueued job with id:", job.Signature.UUID)
+	log.Info("Started workflow with id:", workflowID)
 
 	c.JSON(http.StatusOK, gin.H{"result": ""})
 }
@@ -309,46 +367,74 @@ func (s *Server) adminForceParseArchive(c *gin.Context) {
 			continue
 		}
 
-		job, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
-			Name: "analyze_posts",
-			Args: []tasks.Arg{
-				{
-					Type:  "string",
-					Value: accountNumber,
-				},
-				{
-					Type:  "int64",
-					Value: archives[0].ID,
-				},
-			},
+		workflowID, err := s.startArchiveWorkflow(c, pipeline.AnalyzeArchive, pipeline.ArchiveArgs{
+			AccountNumber: accountNumber,
+			ArchiveID:     archives[0].ID,
 		})
 		if err != nil {
 			log.Debug(err)
 			abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
 			return
 		}
+		log.Info("Started workflow with id:", workflowID)
+		result[workflowID] = accountNumber
+	}
+
+	c.JSON(http.StatusAccepted, result)
+}
+
+func (s *Server) adminGenerateHashContent(c *gin.Context) {
+	var params struct {
+		Ids []int64 `json:"ids"`
+	}
+
+	if err := c.BindJSON(&params); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	result := make(map[string]store.FBArchive)
+	for _, id := range params.Ids {
+		archives, err := s.store.GetFBArchives(c, &store.FBArchiveQueryParam{
+			ID: &id,
+		})
+		if len(archives) != 1 {
+			continue
+		}
+
+		archive := archives[0]
+		if archive.S3Key == "" {
+			continue
+		}
 
-		if _, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
-			Name: "extract_time_metadata",
+		job, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
+			Name: "generate_hash_content",
 			Args: []tasks.Arg{
 				{
 					Type:  "string",
-					Value: accountNumber,
+					Value: archive.S3Key,
+				},
+				{
+					Type:  "int64",
+					Value: archives[0].ID,
 				},
 			},
-		}); err != nil {
+		})
+		if err != nil {
 			log.Debug(err)
 			abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
 			return
 		}
 		log.Info("Enqueued job with id:", job.Signature.UUID)
-		result[job.Signature.UUID] = accountNumber
+		result[job.Signature.UUID] = archive
 	}
 
 	c.JSON(http.StatusAccepted, result)
 }
 
-func (s *Server) adminGenerateHashContent(c *gin.Context) {
+// adminRemoveArchiveData removes all of the data imported from archives and recomputes the stats of their owners
+func (s *Server) adminRemoveArchiveData(c *gin.Context) {
 	var params struct {
 		Ids []int64 `json:"ids"`
 	}
@@ -364,25 +450,26 @@ func (s *Server) adminGenerateHashContent(c *gin.Context) {
 		archives, err := s.store.GetFBArchives(c, &store.FBArchiveQueryParam{
 			ID: &id,
 		})
+		if err != nil {
+			log.Debug(err)
+			abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+			return
+		}
 		if len(archives) != 1 {
 			continue
 		}
 
 		archive := archives[0]
-		if archive.S3Key == "" {
-			continue
-		}
-
 		job, err := s.backgroundEnqueuer.SendTask(&tasks.Signature{
-			Name: "generate_hash_content",
+			Name: "remove_archive_data",
 			Args: []tasks.Arg{
 				{
 					Type:  "string",
-					Value: archive.S3Key,
+					Value: archive.AccountNumber,
 				},
 				{
 					Type:  "int64",
-					Value: archives[0].ID,
+					Value: archive.ID,
 				},
 			},
 		})
@@ -397,3 +484,21 @@ func (s *Server) adminGenerateHashContent(c *gin.Context) {
 
 	c.JSON(http.StatusAccepted, result)
 }
+
+// startArchiveWorkflow starts a workflow of an archive and keeps the workflow id in the archive
+func (s *Server) startArchiveWorkflow(c *gin.Context, w pipeline.Workflow, args pipeline.ArchiveArgs) (string, error) {
+	workflowID, err := s.workflows.Start(w, args)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.store.UpdateFBArchiveStatus(c, &store.FBArchiveQueryParam{
+		ID: &args.ArchiveID,
+	}, &store.FBArchiveQueryParam{
+		WorkflowID: &workflowID,
+	}); err != nil {
+		return "", err
+	}
+
+	return workflowID, nil
+}
@@ -2,10 +2,11 @@ package api
 
 import (
 	"crypto/md5"
+	"crypto/rand"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
-	"fmt"
+	"io"
 	"math"
 	"net/http"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/bitmark-inc/bitmark-sdk-go/account"
+	"github.com/bitmark-inc/spring-app-api/keyring"
 	"github.com/bitmark-inc/spring-app-api/protomodel"
 	"github.com/bitmark-inc/spring-app-api/store"
 	jwt "github.com/dgrijalva/jwt-go"
@@ -65,34 +67,199 @@ func (s *Server) requestJWT(c *gin.Context) {
 		return
 	}
 
-	exp := now.Add(time.Duration(viper.GetInt("jwt.expire")) * time.Hour)
+	acct, err := s.store.QueryAccount(c, &store.AccountQueryParam{
+		AccountNumber: &req.Requester,
+	})
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	if acct != nil && acct.Deleting {
+		abortWithEncoding(c, http.StatusBadRequest, errorAccountDeleting)
+		return
+	}
+
+	s.respondTokens(c, req.Requester, acct != nil)
+}
+
+// refreshJWT issues a new access token with a refresh token. A refresh token is used once,
+// and a new one is issued along with the access token.
+func (s *Server) refreshJWT(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	tokenHash := hashSecret(req.RefreshToken)
+	tokens, err := s.store.DeleteTokens(c, &store.TokenQueryParam{
+		Token: &tokenHash,
+	})
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	if len(tokens) == 0 || tokens[0].ExpireAt.Before(time.Now()) {
+		abortWithEncoding(c, http.StatusUnauthorized, errorInvalidRefreshToken)
+		return
+	}
+
+	accountNumber := tokens[0].AccountNumber
+	acct, err := s.store.QueryAccount(c, &store.AccountQueryParam{
+		AccountNumber: &accountNumber,
+	})
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	if acct == nil {
+		abortWithEncoding(c, http.StatusUnauthorized, errorAccountNotFound)
+		return
+	}
+
+	if acct.Deleting {
+		abortWithEncoding(c, http.StatusBadRequest, errorAccountDeleting)
+		return
+	}
+
+	s.respondTokens(c, accountNumber, true)
+}
+
+// revokeJWT revokes the access token of the request and the refresh token in the body if any.
+// All tokens of the requester are revoked if all is true.
+func (s *Server) revokeJWT(c *gin.Context) {
+	accountNumber := c.GetString("requester")
+
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+		All          bool   `json:"all"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if req.All {
+		if shouldInterupt(s.revokeAccountTokens(c, accountNumber), c) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"result": "OK"})
+		return
+	}
+
+	if err := s.store.RevokeToken(c, accountNumber, c.GetString("token_id"), c.GetTime("token_expire_at")); shouldInterupt(err, c) {
+		return
+	}
+
+	if req.RefreshToken != "" {
+		tokenHash := hashSecret(req.RefreshToken)
+		if _, err := s.store.DeleteTokens(c, &store.TokenQueryParam{
+			Token:         &tokenHash,
+			AccountNumber: &accountNumber,
+		}); shouldInterupt(err, c) {
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "OK"})
+}
+
+// revokeAccountTokens deletes all refresh tokens of an account and revokes
+// all access tokens of the account issued before now
+func (s *Server) revokeAccountTokens(c *gin.Context, accountNumber string) error {
+	if _, err := s.store.DeleteTokens(c, &store.TokenQueryParam{
+		AccountNumber: &accountNumber,
+	}); err != nil {
+		return err
+	}
+
+	// access tokens issued until now are all expired after jwt.expire
+	now := time.Now()
+	return s.store.RevokeAccountTokens(c, accountNumber, now, now.Add(accessTokenExpiry()))
+}
 
-	jwtPubKeyByte := x509.MarshalPKCS1PublicKey(&s.jwtPrivateKey.PublicKey)
-	pubkeyMd5sum := md5.Sum(jwtPubKeyByte)
-	clientID := base64.StdEncoding.EncodeToString(pubkeyMd5sum[:])
+// respondTokens responds a new access token of an account, and a refresh token
+// if the account is registered, since refresh tokens are stored along with their accounts
+func (s *Server) respondTokens(c *gin.Context, accountNumber string, withRefreshToken bool) {
+	now := time.Now()
+	exp := now.Add(accessTokenExpiry())
+
+	key, err := s.jwtKeys.SigningKey()
+	if err != nil {
+		c.Error(err)
+		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+		return
+	}
 
-	// Create a new token object, specifying signing method and the claims
-	// you would like it to contain.
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{
-		Issuer:    clientID,
-		Subject:   req.Requester,
+	tokenString, err := key.Sign(jwt.StandardClaims{
+		Issuer:    s.jwtIssuer,
+		Subject:   accountNumber,
 		ExpiresAt: exp.Unix(),
 		IssuedAt:  now.Unix(),
 		Id:        uuid.NewV4().String(),
 		Audience:  "write",
 	})
-
-	tokenString, err := token.SignedString(s.jwtPrivateKey)
 	if err != nil {
 		c.Error(err)
 		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	result := gin.H{
 		"jwt_token": tokenString,
-		"expire_in": time.Hour.Seconds(),
-	})
+		"expire_in": exp.Sub(now).Seconds(),
+	}
+
+	if withRefreshToken {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); shouldInterupt(err, c) {
+			return
+		}
+
+		// only the hash of a refresh token is stored
+		refreshToken := hex.EncodeToString(b)
+		refreshExp := now.Add(refreshTokenExpiry())
+		if _, err := s.store.AddToken(c, hashSecret(refreshToken), accountNumber, map[string]interface{}{
+			"client_type": strings.ToLower(c.GetHeader("Client-Type")),
+		}, refreshExp); shouldInterupt(err, c) {
+			return
+		}
+
+		result["refresh_token"] = refreshToken
+		result["refresh_expire_in"] = refreshExp.Sub(now).Seconds()
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// jwtIssuer returns the issuer of access tokens, which is jwt.issuer in the config
+// or the md5 sum of the public key of the first configured key if it is not set,
+// so the issuer stays the same when the signing key is rotated
+func jwtIssuer(key *keyring.Key) string {
+	if issuer := viper.GetString("jwt.issuer"); issuer != "" {
+		return issuer
+	}
+
+	pubkeyMd5sum := md5.Sum(x509.MarshalPKCS1PublicKey(&key.PrivateKey.PublicKey))
+	return base64.StdEncoding.EncodeToString(pubkeyMd5sum[:])
+}
+
+// accessTokenExpiry returns the lifetime of access tokens
+func accessTokenExpiry() time.Duration {
+	return time.Duration(viper.GetInt("jwt.expire")) * time.Hour
+}
+
+// refreshTokenExpiry returns the lifetime of refresh tokens, 30 days if jwt.refresh_expire is not set
+func refreshTokenExpiry() time.Duration {
+	if hours := viper.GetInt("jwt.refresh_expire"); hours > 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return 30 * 24 * time.Hour
 }
 
 // authMiddleware is a middleware to authorize users from using our APIs
@@ -104,13 +271,7 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 		claims := &jwt.StandardClaims{}
 		token, err := jwtrequest.ParseFromRequest(c.Request,
 			jwtrequest.AuthorizationHeaderExtractor,
-			func(token *jwt.Token) (interface{}, error) {
-				if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-					return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-				}
-
-				return &s.jwtPrivateKey.PublicKey, nil
-			},
+			s.jwtKeys.Keyfunc,
 			jwtrequest.WithClaims(claims),
 		)
 
@@ -125,7 +286,19 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		revoked, err := s.store.IsTokenRevoked(c, claims.Subject, claims.Id, time.Unix(claims.IssuedAt, 0))
+		if shouldInterupt(err, c) {
+			return
+		}
+
+		if revoked {
+			abortWithEncoding(c, http.StatusUnauthorized, errorAuthorizationRevoked)
+			return
+		}
+
 		c.Set("requester", claims.Subject)
+		c.Set("token_id", claims.Id)
+		c.Set("token_expire_at", time.Unix(claims.ExpiresAt, 0))
 		c.Next()
 	}
 }
@@ -142,17 +315,6 @@ func (s *Server) fakeCredential() gin.HandlerFunc {
 	}
 }
 
-func (s *Server) apikeyAuthentication(key string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		apiToken := c.GetHeader("Api-Token")
-		if apiToken == "" || apiToken != key {
-			c.AbortWithStatus(http.StatusForbidden)
-			return
-		}
-		c.Next()
-	}
-}
-
 func (s *Server) recognizeAccountMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requester := c.GetString("requester")
@@ -200,3 +362,9 @@ func (s *Server) clientVersionGateway() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// jwks responds the public keys verifying access tokens
+func (s *Server) jwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, s.jwtKeys.JWKS())
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/bitmark-inc/spring-app-api/protomodel"
+	"github.com/bitmark-inc/spring-app-api/store"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+)
+
+func (s *Server) getAllComments(c *gin.Context) {
+	accountNumber := c.GetString("requester")
+	var params struct {
+		StartedAt int64  `form:"started_at"`
+		EndedAt   int64  `form:"ended_at"`
+		Limit     int64  `form:"limit"`
+		Cursor    string `form:"cursor"`
+	}
+
+	if err := c.BindQuery(&params); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if params.StartedAt >= params.EndedAt {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if params.Limit > 1000 {
+		params.Limit = 1000
+	}
+
+	if params.Limit < 1 {
+		params.Limit = 100
+	}
+
+	cursor, err := store.ParseCursor(params.Cursor)
+	if err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	data, nextCursor, err := s.fbDataStore.GetFBStatPage(c, accountNumber+"/comment", params.StartedAt, params.EndedAt, params.Limit, cursor)
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	comments := make([]*protomodel.Comment, 0)
+	for _, d := range data {
+		var comment protomodel.Comment
+		err := proto.Unmarshal(d, &comment)
+		if shouldInterupt(err, c) {
+			return
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	responseWithEncoding(c, http.StatusOK, &protomodel.CommentsResponse{
+		Result:     comments,
+		NextCursor: nextCursor.String(),
+	})
+}
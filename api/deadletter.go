@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// adminListDeadLetterJobs lists background jobs failed after all of their retries
+func (s *Server) adminListDeadLetterJobs(c *gin.Context) {
+	jobs, err := s.deadLetters.List()
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": jobs})
+}
+
+// adminRequeueDeadLetterJobs sends dead-letter jobs to the background again by their task uuids
+func (s *Server) adminRequeueDeadLetterJobs(c *gin.Context) {
+	var params struct {
+		Ids []string `json:"ids"`
+	}
+
+	if err := c.BindJSON(&params); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	result := make(map[string]string)
+	for _, id := range params.Ids {
+		job, err := s.deadLetters.Get(id)
+		if shouldInterupt(err, c) {
+			return
+		}
+		if job == nil {
+			continue
+		}
+
+		if _, err := s.backgroundEnqueuer.SendTask(job.Requeue()); shouldInterupt(err, c) {
+			return
+		}
+
+		if err := s.deadLetters.Remove(id); shouldInterupt(err, c) {
+			return
+		}
+		log.Info("Requeued job with id:", id)
+		result[id] = job.Signature.Name
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"result": result})
+}
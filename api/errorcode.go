@@ -16,11 +16,14 @@ var (
 		1006: "account not found",
 		1007: "API for this client version has been discontinued",
 		1008: "the account is under deletion",
+		1009: "invalid refresh token",
+		1010: "authorization has been revoked",
 
 		2000: "file source is not supported",
 		2001: "invalid archive file",
 		2002: "multiple exporting is not allowed",
 		2003: "no archive found",
+		2004: "friend not found",
 	}
 
 	errorInternalServer             = errorJSON(999)
@@ -33,11 +36,14 @@ var (
 	errorAccountNotFound            = errorJSON(1006)
 	errorUnsupportedClientVersion   = errorJSON(1007)
 	errorAccountDeleting            = errorJSON(1008)
+	errorInvalidRefreshToken        = errorJSON(1009)
+	errorAuthorizationRevoked       = errorJSON(1010)
 
 	errorFileSourceUnsupported         = errorJSON(2000)
 	errorInvalidArchiveFile            = errorJSON(2001)
 	errorMultipleExportingIsNotAllowed = errorJSON(2002)
 	errorNoArchiveFound                = errorJSON(2003)
+	errorFriendNotFound                = errorJSON(2004)
 )
 
 // errorJSON converts an error code to a standardized error object
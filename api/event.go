@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/store"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
@@ -12,9 +13,10 @@ import (
 func (s *Server) getAllEvents(c *gin.Context) {
 	accountNumber := c.GetString("requester")
 	var params struct {
-		StartedAt int64 `form:"started_at"`
-		EndedAt   int64 `form:"ended_at"`
-		Limit     int64 `form:"limit"`
+		StartedAt int64  `form:"started_at"`
+		EndedAt   int64  `form:"ended_at"`
+		Limit     int64  `form:"limit"`
+		Cursor    string `form:"cursor"`
 	}
 
 	if err := c.BindQuery(&params); err != nil {
@@ -40,16 +42,37 @@ func (s *Server) getAllEvents(c *gin.Context) {
 		params.Limit = 100
 	}
 
+	cursor, err := parseUUIDCursor(params.Cursor)
+	if err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
 	var events []facebook.EventORM
 
-	if err := s.ormDB.
+	query := s.ormDB.
 		Where("data_owner_id = ?", accountNumber).
-		Where("start_timestamp >= ? AND start_timestamp < ?", params.StartedAt, params.EndedAt).
-		Order("start_timestamp desc").Limit(params.Limit).
+		Where("start_timestamp >= ? AND start_timestamp < ?", params.StartedAt, params.EndedAt)
+
+	if cursor != nil {
+		query = query.Where("(start_timestamp, id) < (?, ?)", cursor.Timestamp, cursor.ID)
+	}
+
+	// query one more item to know whether there is a next page
+	if err := query.
+		Order("start_timestamp desc, id desc").Limit(params.Limit + 1).
 		Find(&events).Error; err != nil {
 		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"result": events})
+	var nextCursor *store.Cursor
+	if int64(len(events)) > params.Limit {
+		events = events[:params.Limit]
+		last := events[len(events)-1]
+		nextCursor = &store.Cursor{Timestamp: last.StartTimestamp, ID: last.ID.String()}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": events, "next_cursor": nextCursor.String()})
 }
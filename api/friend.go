@@ -0,0 +1,219 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/protomodel"
+	"github.com/bitmark-inc/spring-app-api/store"
+	"github.com/bitmark-inc/spring-app-api/timeutil"
+)
+
+// friendSummaryQuery selects friends with the number of posts they are tagged in
+// and the time of the first and the last of those posts
+const friendSummaryQuery = `SELECT facebook_friend.id, facebook_friend.friend_name AS name,
+	facebook_friend.timestamp AS friended_at,
+	COUNT(facebook_post.id) AS tag_count,
+	COALESCE(MIN(facebook_post.timestamp), 0) AS first_interaction_at,
+	COALESCE(MAX(facebook_post.timestamp), 0) AS last_interaction_at
+	FROM facebook_friend
+	LEFT OUTER JOIN facebook_tag ON facebook_tag.friend_id = facebook_friend.id
+	LEFT OUTER JOIN facebook_post ON facebook_post.id = facebook_tag.post_id`
+
+type friendSummary struct {
+	ID                 string
+	Name               string
+	FriendedAt         int64
+	TagCount           int64
+	FirstInteractionAt int64
+	LastInteractionAt  int64
+}
+
+func (f friendSummary) protomodel() *protomodel.Friend {
+	return &protomodel.Friend{
+		Id:                 f.ID,
+		Name:               f.Name,
+		FriendedAt:         f.FriendedAt,
+		TagCount:           f.TagCount,
+		FirstInteractionAt: f.FirstInteractionAt,
+		LastInteractionAt:  f.LastInteractionAt,
+	}
+}
+
+func (s *Server) getAllFriends(c *gin.Context) {
+	accountNumber := c.GetString("requester")
+
+	var friends []friendSummary
+	if err := s.ormDB.Raw(friendSummaryQuery+`
+		WHERE facebook_friend.data_owner_id = ?
+		GROUP BY facebook_friend.id
+		ORDER BY tag_count DESC, facebook_friend.friend_name`, accountNumber).
+		Scan(&friends).Error; err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+		return
+	}
+
+	results := make([]*protomodel.Friend, 0)
+	for _, f := range friends {
+		results = append(results, f.protomodel())
+	}
+
+	responseWithEncoding(c, http.StatusOK, &protomodel.FriendsResponse{
+		Result: results,
+	})
+}
+
+func (s *Server) getFriend(c *gin.Context) {
+	accountNumber := c.GetString("requester")
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	var params struct {
+		Limit  int64  `form:"limit"`
+		Cursor string `form:"cursor"`
+	}
+
+	if err := c.BindQuery(&params); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if params.Limit > 1000 {
+		params.Limit = 1000
+	}
+
+	if params.Limit < 1 {
+		params.Limit = 100
+	}
+
+	cursor, err := parseUUIDCursor(params.Cursor)
+	if err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	var friend friendSummary
+	if err := s.ormDB.Raw(friendSummaryQuery+`
+		WHERE facebook_friend.data_owner_id = ? AND facebook_friend.id = ?
+		GROUP BY facebook_friend.id`, accountNumber, friendID).
+		Scan(&friend).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			abortWithEncoding(c, http.StatusNotFound, errorFriendNotFound)
+			return
+		}
+
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+		return
+	}
+
+	// count the tagged posts by week and year
+	var timestamps []int64
+	if err := s.ormDB.Table("facebook_tag").
+		Joins("INNER JOIN facebook_post ON facebook_post.id = facebook_tag.post_id").
+		Where("facebook_tag.data_owner_id = ? AND facebook_tag.friend_id = ?", accountNumber, friendID).
+		Pluck("facebook_post.timestamp", &timestamps).Error; err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+		return
+	}
+
+	weeklyCounts := make(map[int64]int64)
+	yearlyCounts := make(map[int64]int64)
+	for _, t := range timestamps {
+		weeklyCounts[timeutil.AbsWeek(t)]++
+		yearlyCounts[timeutil.AbsYear(t)]++
+	}
+
+	// query one more tagged post to know whether there is a next page
+	var taggedPosts []struct {
+		ID        string
+		Timestamp int64
+	}
+
+	query := s.ormDB.Table("facebook_tag").
+		Joins("INNER JOIN facebook_post ON facebook_post.id = facebook_tag.post_id").
+		Select("facebook_post.id, facebook_post.timestamp").
+		Where("facebook_tag.data_owner_id = ? AND facebook_tag.friend_id = ?", accountNumber, friendID)
+
+	if cursor != nil {
+		query = query.Where("(facebook_post.timestamp, facebook_post.id) < (?, ?)", cursor.Timestamp, cursor.ID)
+	}
+
+	if err := query.
+		Order("facebook_post.timestamp desc, facebook_post.id desc").
+		Limit(params.Limit + 1).Scan(&taggedPosts).Error; err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+		return
+	}
+
+	var nextCursor *store.Cursor
+	if int64(len(taggedPosts)) > params.Limit {
+		taggedPosts = taggedPosts[:params.Limit]
+		last := taggedPosts[len(taggedPosts)-1]
+		nextCursor = &store.Cursor{Timestamp: last.Timestamp, ID: last.ID}
+	}
+
+	posts := make([]*protomodel.Post, 0)
+	for _, p := range taggedPosts {
+		data, err := s.fbDataStore.GetExactFBStat(c, accountNumber+"/post", p.Timestamp)
+		if shouldInterupt(err, c) {
+			return
+		}
+
+		// posts with a duplicated timestamp are not saved as stats
+		if data == nil {
+			continue
+		}
+
+		var post protomodel.Post
+		err = proto.Unmarshal(data, &post)
+		if shouldInterupt(err, c) {
+			return
+		}
+
+		posts = append(posts, &post)
+	}
+
+	responseWithEncoding(c, http.StatusOK, &protomodel.FriendDetailResponse{
+		Result: &protomodel.FriendDetail{
+			Friend:     friend.protomodel(),
+			WeeklyTags: periodCounts(weeklyCounts),
+			YearlyTags: periodCounts(yearlyCounts),
+			Posts:      posts,
+		},
+		NextCursor: nextCursor.String(),
+	})
+}
+
+// periodCounts converts counts keyed by the start of periods to a list in time order
+func periodCounts(counts map[int64]int64) []*protomodel.PeriodCount {
+	results := make([]*protomodel.PeriodCount, 0, len(counts))
+	for period, count := range counts {
+		results = append(results, &protomodel.PeriodCount{
+			PeriodStartedAt: period,
+			Count:           count,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].PeriodStartedAt < results[j].PeriodStartedAt
+	})
+
+	return results
+}
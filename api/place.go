@@ -0,0 +1,361 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/external/geoservice"
+	"github.com/bitmark-inc/spring-app-api/protomodel"
+)
+
+const (
+	// maxPlaceZoom is the deepest zoom level of clustering, where a cell is about 40 meters wide
+	maxPlaceZoom = 20
+
+	// maxNamedPlaceClusters limits the reverse geocoding requests of a single clustering
+	maxNamedPlaceClusters = 50
+
+	// placeGeocodeConcurrency limits the concurrent reverse geocoding requests of a single clustering
+	placeGeocodeConcurrency = 4
+
+	// placeGeocodeTimeout is the timeout of a reverse geocoding request. A cluster is left
+	// with the name of one of its places when its area name is not resolved in time.
+	placeGeocodeTimeout = 3 * time.Second
+
+	// placeNameCacheTTL and placeNameCacheSize bound the cache of area names
+	placeNameCacheTTL  = 24 * time.Hour
+	placeNameCacheSize = 10000
+)
+
+// placeNameCache caches the area names of coordinates rounded by the precision of each naming level,
+// so zooming and panning a map do not resolve the same areas again
+type placeNameCache struct {
+	sync.Mutex
+	names map[string]placeNameEntry
+}
+
+type placeNameEntry struct {
+	name     string
+	expireAt time.Time
+}
+
+func newPlaceNameCache() *placeNameCache {
+	return &placeNameCache{names: make(map[string]placeNameEntry)}
+}
+
+func (p *placeNameCache) get(key string) (string, bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	e, ok := p.names[key]
+	if !ok || time.Now().After(e.expireAt) {
+		return "", false
+	}
+	return e.name, true
+}
+
+func (p *placeNameCache) set(key, name string) {
+	p.Lock()
+	defer p.Unlock()
+
+	// drop expired names first, and everything if the cache is still full
+	if len(p.names) >= placeNameCacheSize {
+		now := time.Now()
+		for k, e := range p.names {
+			if now.After(e.expireAt) {
+				delete(p.names, k)
+			}
+		}
+		if len(p.names) >= placeNameCacheSize {
+			p.names = make(map[string]placeNameEntry)
+		}
+	}
+
+	p.names[key] = placeNameEntry{name: name, expireAt: time.Now().Add(placeNameCacheTTL)}
+}
+
+// placeNameKey returns the cache key of the area name of a coordinate at a zoom level.
+// Countries are named within a degree, states within a tenth and local areas within a hundredth.
+func placeNameKey(lat, long float64, zoom int) string {
+	level, precision := placeNameLevel(zoom)
+	return fmt.Sprintf("%d:%.*f,%.*f", level, precision, lat, precision, long)
+}
+
+// placeNameLevel returns the naming level of a zoom level and the decimal places of its coordinates
+func placeNameLevel(zoom int) (int, int) {
+	switch {
+	case zoom <= 5:
+		return 0, 0
+	case zoom <= 9:
+		return 1, 1
+	default:
+		return 2, 2
+	}
+}
+
+// resolvePlaceNames resolves the area names of coordinates concurrently with the cache.
+// Coordinates without names are missing from the result.
+func (s *Server) resolvePlaceNames(ctx context.Context, coordinates [][2]float64, zoom int) map[int]string {
+	var mu sync.Mutex
+	names := make(map[int]string)
+
+	sem := make(chan struct{}, placeGeocodeConcurrency)
+	var wg sync.WaitGroup
+	for i, coordinate := range coordinates {
+		key := placeNameKey(coordinate[0], coordinate[1], zoom)
+		if name, ok := s.placeNames.get(key); ok {
+			mu.Lock()
+			names[i] = name
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, lat, long float64, key string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			callCtx, cancel := context.WithTimeout(ctx, placeGeocodeTimeout)
+			defer cancel()
+
+			info, err := s.geoServiceClient.ReverseGeocode(callCtx, lat, long)
+			if err != nil {
+				log.WithField("prefix", "places").Warn("cannot reverse geocode cluster: ", err)
+				return
+			}
+
+			// areas without names are resolved again next time
+			name := placeClusterName(info, zoom)
+			if name == "" {
+				return
+			}
+
+			s.placeNames.set(key, name)
+			mu.Lock()
+			names[i] = name
+			mu.Unlock()
+		}(i, coordinate[0], coordinate[1], key)
+	}
+	wg.Wait()
+
+	return names
+}
+
+func (s *Server) getAllPlaces(c *gin.Context) {
+	accountNumber := c.GetString("requester")
+
+	var params struct {
+		StartedAt int64    `form:"started_at"`
+		EndedAt   int64    `form:"ended_at"`
+		MinLat    *float64 `form:"min_lat"`
+		MinLong   *float64 `form:"min_long"`
+		MaxLat    *float64 `form:"max_lat"`
+		MaxLong   *float64 `form:"max_long"`
+		Zoom      *int     `form:"zoom"`
+	}
+
+	if err := c.BindQuery(&params); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if params.EndedAt == 0 {
+		params.EndedAt = time.Now().Unix()
+	}
+
+	if params.StartedAt >= params.EndedAt {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	query := s.ormDB.Table("facebook_place").
+		Joins("INNER JOIN facebook_post ON facebook_post.id = facebook_place.post_id").
+		Where("facebook_place.data_owner_id = ?", accountNumber).
+		Where("facebook_post.timestamp >= ?", params.StartedAt).
+		Where("facebook_post.timestamp <= ?", params.EndedAt)
+
+	// list all places unless a map area is given
+	if params.MinLat == nil && params.MinLong == nil && params.MaxLat == nil && params.MaxLong == nil && params.Zoom == nil {
+		var places []struct {
+			Name           string
+			Address        string
+			Latitude       float64
+			Longitude      float64
+			VisitCount     int64
+			FirstVisitedAt int64
+			LastVisitedAt  int64
+		}
+
+		if err := query.
+			Select(`facebook_place.name, facebook_place.address, facebook_place.latitude, facebook_place.longitude,
+				COUNT(*) AS visit_count,
+				MIN(facebook_post.timestamp) AS first_visited_at,
+				MAX(facebook_post.timestamp) AS last_visited_at`).
+			Group("facebook_place.name, facebook_place.address, facebook_place.latitude, facebook_place.longitude").
+			Order("visit_count desc, last_visited_at desc").
+			Scan(&places).Error; err != nil {
+			log.Debug(err)
+			abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+			return
+		}
+
+		results := make([]*protomodel.Place, 0)
+		for _, p := range places {
+			results = append(results, &protomodel.Place{
+				Location: &protomodel.Location{
+					Name:    p.Name,
+					Address: p.Address,
+					Coordinate: &protomodel.Coordinate{
+						Latitude:  p.Latitude,
+						Longitude: p.Longitude,
+					},
+					CreatedAt: p.FirstVisitedAt,
+				},
+				VisitCount:     p.VisitCount,
+				FirstVisitedAt: p.FirstVisitedAt,
+				LastVisitedAt:  p.LastVisitedAt,
+			})
+		}
+
+		responseWithEncoding(c, http.StatusOK, &protomodel.PlacesResponse{
+			Result: results,
+		})
+		return
+	}
+
+	// a map area needs all of the bounds and the zoom level
+	if params.MinLat == nil || params.MinLong == nil || params.MaxLat == nil || params.MaxLong == nil || params.Zoom == nil {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if *params.MinLat < -90 || *params.MaxLat > 90 || *params.MinLat > *params.MaxLat ||
+		*params.MinLong < -180 || *params.MaxLong > 180 ||
+		*params.Zoom < 0 || *params.Zoom > maxPlaceZoom {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	query = query.
+		Where("NOT (facebook_place.latitude = 0 AND facebook_place.longitude = 0)").
+		Where("facebook_place.latitude BETWEEN ? AND ?", *params.MinLat, *params.MaxLat)
+
+	// the area crosses the antimeridian when min long is greater than max long
+	if *params.MinLong <= *params.MaxLong {
+		query = query.Where("facebook_place.longitude BETWEEN ? AND ?", *params.MinLong, *params.MaxLong)
+	} else {
+		query = query.Where("(facebook_place.longitude >= ? OR facebook_place.longitude <= ?)", *params.MinLong, *params.MaxLong)
+	}
+
+	// places are clustered by square cells of a grid which halves its cell size on each zoom level
+	cellSize := 360 / math.Pow(2, float64(*params.Zoom))
+
+	var clusters []struct {
+		Name           string
+		Latitude       float64
+		Longitude      float64
+		MinLatitude    float64
+		MinLongitude   float64
+		MaxLatitude    float64
+		MaxLongitude   float64
+		PlaceCount     int64
+		VisitCount     int64
+		FirstVisitedAt int64
+		LastVisitedAt  int64
+	}
+
+	if err := query.
+		Select(`MIN(facebook_place.name) AS name,
+			AVG(facebook_place.latitude) AS latitude, AVG(facebook_place.longitude) AS longitude,
+			MIN(facebook_place.latitude) AS min_latitude, MIN(facebook_place.longitude) AS min_longitude,
+			MAX(facebook_place.latitude) AS max_latitude, MAX(facebook_place.longitude) AS max_longitude,
+			COUNT(DISTINCT (facebook_place.name, facebook_place.latitude, facebook_place.longitude)) AS place_count,
+			COUNT(*) AS visit_count,
+			MIN(facebook_post.timestamp) AS first_visited_at,
+			MAX(facebook_post.timestamp) AS last_visited_at`).
+		Group(fmt.Sprintf("FLOOR(facebook_place.latitude / %[1]g), FLOOR(facebook_place.longitude / %[1]g)", cellSize)).
+		Order("visit_count desc").
+		Scan(&clusters).Error; err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+		return
+	}
+
+	// name the clusters of many places by the area around them
+	coordinates := make([][2]float64, 0)
+	clusterIndexes := make([]int, 0)
+	for i, cl := range clusters {
+		if cl.PlaceCount > 1 && len(coordinates) < maxNamedPlaceClusters {
+			coordinates = append(coordinates, [2]float64{cl.Latitude, cl.Longitude})
+			clusterIndexes = append(clusterIndexes, i)
+		}
+	}
+	areaNames := make(map[int]string)
+	for i, name := range s.resolvePlaceNames(c, coordinates, *params.Zoom) {
+		areaNames[clusterIndexes[i]] = name
+	}
+
+	results := make([]*protomodel.PlaceCluster, 0)
+	for i, cl := range clusters {
+		name := cl.Name
+		if areaName, ok := areaNames[i]; ok {
+			name = areaName
+		}
+
+		results = append(results, &protomodel.PlaceCluster{
+			Name: name,
+			Coordinate: &protomodel.Coordinate{
+				Latitude:  cl.Latitude,
+				Longitude: cl.Longitude,
+			},
+			SouthWest: &protomodel.Coordinate{
+				Latitude:  cl.MinLatitude,
+				Longitude: cl.MinLongitude,
+			},
+			NorthEast: &protomodel.Coordinate{
+				Latitude:  cl.MaxLatitude,
+				Longitude: cl.MaxLongitude,
+			},
+			PlaceCount:     cl.PlaceCount,
+			VisitCount:     cl.VisitCount,
+			FirstVisitedAt: cl.FirstVisitedAt,
+			LastVisitedAt:  cl.LastVisitedAt,
+		})
+	}
+
+	responseWithEncoding(c, http.StatusOK, &protomodel.PlaceClustersResponse{
+		Result: results,
+	})
+}
+
+// placeClusterName picks an area name matching how far the map is zoomed in
+func placeClusterName(info *geoservice.GeoCodeInfo, zoom int) string {
+	var names []string
+	switch {
+	case zoom <= 5:
+		names = []string{info.Address.Country}
+	case zoom <= 9:
+		names = []string{info.Address.State, info.Address.Country}
+	default:
+		names = []string{info.Address.Suburb, info.Address.CityDistrict, info.Address.Hamlet, info.Address.State, info.Address.Country}
+	}
+
+	for _, n := range names {
+		if n != "" {
+			return n
+		}
+	}
+
+	return info.DisplayName
+}
@@ -19,9 +19,10 @@ import (
 func (s *Server) getAllPosts(c *gin.Context) {
 	accountNumber := c.GetString("requester")
 	var params struct {
-		StartedAt int64 `form:"started_at"`
-		EndedAt   int64 `form:"ended_at"`
-		Limit     int64 `form:"limit"`
+		StartedAt int64  `form:"started_at"`
+		EndedAt   int64  `form:"ended_at"`
+		Limit     int64  `form:"limit"`
+		Cursor    string `form:"cursor"`
 	}
 
 	if err := c.BindQuery(&params); err != nil {
@@ -43,7 +44,14 @@ func (s *Server) getAllPosts(c *gin.Context) {
 		params.Limit = 100
 	}
 
-	data, err := s.fbDataStore.GetFBStat(c, accountNumber+"/post", params.StartedAt, params.EndedAt, params.Limit)
+	cursor, err := store.ParseCursor(params.Cursor)
+	if err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	data, nextCursor, err := s.fbDataStore.GetFBStatPage(c, accountNumber+"/post", params.StartedAt, params.EndedAt, params.Limit, cursor)
 	if shouldInterupt(err, c) {
 		return
 	}
@@ -60,7 +68,8 @@ func (s *Server) getAllPosts(c *gin.Context) {
 	}
 
 	responseWithEncoding(c, http.StatusOK, &protomodel.PostsResponse{
-		Result: posts,
+		Result:     posts,
+		NextCursor: nextCursor.String(),
 	})
 }
 
@@ -107,6 +116,21 @@ func (s *Server) getPostStats(c *gin.Context) {
 		results = append(results, &reactionStat)
 	}
 
+	// For comment
+	commentStatData, err := s.fbDataStore.GetExactFBStat(c, fmt.Sprintf("%s/comment-%s-stat", accountNumber, period), startedAt)
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	if commentStatData != nil {
+		var commentStat protomodel.Usage
+		err := proto.Unmarshal(commentStatData, &commentStat)
+		if shouldInterupt(err, c) {
+			return
+		}
+		results = append(results, &commentStat)
+	}
+
 	// For sentiment
 	sentimentStatData, err := s.fbDataStore.GetExactFBStat(c, fmt.Sprintf("%s/sentiment-%s-stat", accountNumber, period), startedAt)
 	if shouldInterupt(err, c) {
@@ -131,9 +155,10 @@ func (s *Server) getAllPostMedia(c *gin.Context) {
 	accountNumber := c.GetString("requester")
 
 	var params struct {
-		StartedAt int64 `form:"started_at"`
-		EndedAt   int64 `form:"ended_at"`
-		Limit     int64 `form:"limit"`
+		StartedAt int64  `form:"started_at"`
+		EndedAt   int64  `form:"ended_at"`
+		Limit     int64  `form:"limit"`
+		Cursor    string `form:"cursor"`
 	}
 
 	if err := c.BindQuery(&params); err != nil {
@@ -155,6 +180,13 @@ func (s *Server) getAllPostMedia(c *gin.Context) {
 		params.Limit = 100
 	}
 
+	cursor, err := parseUUIDCursor(params.Cursor)
+	if err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
 	results := make([]*struct {
 		Id                string `json:"id"`
 		MediaURI          string `json:"uri"`
@@ -164,18 +196,32 @@ func (s *Server) getAllPostMedia(c *gin.Context) {
 		SourceURI         string `json:"source"`
 	}, 0)
 
-	if err := s.ormDB.Table("facebook_postmedia").
+	query := s.ormDB.Table("facebook_postmedia").
 		Joins("LEFT OUTER JOIN facebook_post ON facebook_postmedia.post_id = facebook_post.id").
 		Select("facebook_postmedia.id, facebook_postmedia.media_uri, facebook_postmedia.thumbnail_uri, facebook_postmedia.filename_extension, facebook_post.timestamp").
 		Where("facebook_postmedia.data_owner_id = ?", accountNumber).
 		Where("facebook_post.timestamp > ?", params.StartedAt).
-		Where("facebook_post.timestamp < ?", params.EndedAt).
-		Order("facebook_post.timestamp desc").
-		Limit(params.Limit).Scan(&results).Error; err != nil {
+		Where("facebook_post.timestamp < ?", params.EndedAt)
+
+	if cursor != nil {
+		query = query.Where("(facebook_post.timestamp, facebook_postmedia.id) < (?, ?)", cursor.Timestamp, cursor.ID)
+	}
+
+	// query one more item to know whether there is a next page
+	if err := query.
+		Order("facebook_post.timestamp desc, facebook_postmedia.id desc").
+		Limit(params.Limit + 1).Scan(&results).Error; err != nil {
 		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
 		return
 	}
 
+	var nextCursor *store.Cursor
+	if int64(len(results)) > params.Limit {
+		results = results[:params.Limit]
+		last := results[len(results)-1]
+		nextCursor = &store.Cursor{Timestamp: last.Timestamp, ID: last.Id}
+	}
+
 	sess := session.New(s.awsConf)
 
 	for _, r := range results {
@@ -198,7 +244,7 @@ func (s *Server) getAllPostMedia(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{"result": results})
+	c.JSON(http.StatusOK, gin.H{"result": results, "next_cursor": nextCursor.String()})
 }
 
 func (s *Server) getPostMediaURI(c *gin.Context) {
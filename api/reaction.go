@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/bitmark-inc/spring-app-api/protomodel"
+	"github.com/bitmark-inc/spring-app-api/store"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/protobuf/proto"
 	log "github.com/sirupsen/logrus"
@@ -12,9 +13,10 @@ import (
 func (s *Server) getAllReactions(c *gin.Context) {
 	accountNumber := c.GetString("requester")
 	var params struct {
-		StartedAt int64 `form:"started_at"`
-		EndedAt   int64 `form:"ended_at"`
-		Limit     int64 `form:"limit"`
+		StartedAt int64  `form:"started_at"`
+		EndedAt   int64  `form:"ended_at"`
+		Limit     int64  `form:"limit"`
+		Cursor    string `form:"cursor"`
 	}
 
 	if err := c.BindQuery(&params); err != nil {
@@ -36,7 +38,14 @@ func (s *Server) getAllReactions(c *gin.Context) {
 		params.Limit = 100
 	}
 
-	data, err := s.fbDataStore.GetFBStat(c, accountNumber+"/reaction", params.StartedAt, params.EndedAt, params.Limit)
+	cursor, err := store.ParseCursor(params.Cursor)
+	if err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	data, nextCursor, err := s.fbDataStore.GetFBStatPage(c, accountNumber+"/reaction", params.StartedAt, params.EndedAt, params.Limit, cursor)
 	if shouldInterupt(err, c) {
 		return
 	}
@@ -53,6 +62,7 @@ func (s *Server) getAllReactions(c *gin.Context) {
 	}
 
 	responseWithEncoding(c, http.StatusOK, &protomodel.ReactionsResponse{
-		Result: reactions,
+		Result:     reactions,
+		NextCursor: nextCursor.String(),
 	})
 }
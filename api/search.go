@@ -0,0 +1,174 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/protomodel"
+)
+
+// searchFragmentDelimiter separates the highlighted fragments returned by ts_headline
+const searchFragmentDelimiter = "<|>"
+
+// searchHeadlineOptions highlights the matches of a snippet and separates its fragments
+var searchHeadlineOptions = fmt.Sprintf(`StartSel=<em>, StopSel=</em>, MaxFragments=3, FragmentDelimiter="%s"`, searchFragmentDelimiter)
+
+// searchSources are the searchable types and the text column of each.
+// The expressions must match the full-text indexes created by schema/facebook/migrate.
+var searchSources = map[string]struct {
+	Table  string
+	Column string
+}{
+	"post":     {"facebook_post", "post"},
+	"comment":  {"facebook_comment", "comment"},
+	"reaction": {"facebook_reaction", "title"},
+}
+
+func (s *Server) search(c *gin.Context) {
+	accountNumber := c.GetString("requester")
+
+	var params struct {
+		Query     string   `form:"q"`
+		Types     []string `form:"types"`
+		StartedAt int64    `form:"started_at"`
+		EndedAt   int64    `form:"ended_at"`
+		Limit     int64    `form:"limit"`
+	}
+
+	if err := c.BindQuery(&params); err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if params.EndedAt == 0 {
+		params.EndedAt = time.Now().Unix()
+	}
+
+	if params.StartedAt >= params.EndedAt {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	if params.Limit < 1 {
+		params.Limit = 20
+	}
+
+	// types can be given either as repeated or comma separated values
+	types := make([]string, 0)
+	for _, t := range params.Types {
+		for _, v := range strings.Split(t, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				types = append(types, v)
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		types = []string{"post", "comment", "reaction"}
+	}
+
+	query, values, err := searchQuery(accountNumber, params.Query, types, params.StartedAt, params.EndedAt, params.Limit)
+	if err != nil {
+		abortWithEncoding(c, http.StatusBadRequest, errorInvalidParameters)
+		return
+	}
+
+	var hits []struct {
+		Type      string
+		ID        string
+		Timestamp int64
+		Rank      float64
+		Snippet   string
+	}
+
+	if err := s.ormDB.Raw(query, values...).
+		Scan(&hits).Error; err != nil {
+		log.Debug(err)
+		abortWithEncoding(c, http.StatusInternalServerError, errorInternalServer)
+		return
+	}
+
+	results := make([]*protomodel.SearchHit, 0)
+	for _, h := range hits {
+		results = append(results, &protomodel.SearchHit{
+			Type:      h.Type,
+			Id:        h.ID,
+			Timestamp: h.Timestamp,
+			Rank:      h.Rank,
+			Snippets:  strings.Split(h.Snippet, searchFragmentDelimiter),
+		})
+	}
+
+	responseWithEncoding(c, http.StatusOK, &protomodel.SearchResponse{
+		Result: results,
+	})
+}
+
+// escapeHTMLExpr returns the sql expression of a text expression with the html special characters escaped,
+// so the highlight markers are the only markup of a snippet
+func escapeHTMLExpr(expr string) string {
+	// ampersands are escaped first, so the other escaped characters are not escaped again
+	for _, r := range [][2]string{{"'&'", "'&amp;'"}, {"'<'", "'&lt;'"}, {"'>'", "'&gt;'"}, {`'"'`, "'&quot;'"}, {"''''", "'&#39;'"}} {
+		expr = fmt.Sprintf("replace(%s, %s, %s)", expr, r[0], r[1])
+	}
+	return expr
+}
+
+// searchQuery builds the query of the hits of a search ordered by their rank.
+// Hits of all types are ranked and limited first, so the snippets, which are
+// expensive to compute, are highlighted only for the returned hits.
+func searchQuery(accountNumber, query string, types []string, startedAt, endedAt, limit int64) (string, []interface{}, error) {
+	rankQueries := make([]string, 0)
+	snippetQueries := make([]string, 0)
+	values := []interface{}{query}
+	snippetValues := make([]interface{}, 0)
+	seen := make(map[string]bool)
+	for _, t := range types {
+		source, ok := searchSources[t]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown search type %s", t)
+		}
+
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+
+		rankQueries = append(rankQueries, fmt.Sprintf(`SELECT '%[1]s' AS type, id, timestamp,
+			ts_rank(to_tsvector('simple', %[3]s), q.query) AS rank
+			FROM %[2]s, q
+			WHERE data_owner_id = ? AND timestamp >= ? AND timestamp <= ?
+			AND to_tsvector('simple', %[3]s) @@ q.query`, t, source.Table, source.Column))
+		values = append(values, accountNumber, startedAt, endedAt)
+
+		snippetQueries = append(snippetQueries, fmt.Sprintf(`SELECT hits.type, hits.id, hits.timestamp, hits.rank,
+			ts_headline('simple', %[3]s, q.query, ?) AS snippet
+			FROM hits JOIN %[2]s AS source ON source.id = hits.id, q
+			WHERE hits.type = '%[1]s'`, t, source.Table, escapeHTMLExpr("source."+source.Column)))
+		snippetValues = append(snippetValues, searchHeadlineOptions)
+	}
+	values = append(values, limit)
+	values = append(values, snippetValues...)
+
+	return `WITH q AS (SELECT plainto_tsquery('simple', ?) AS query),
+		hits AS (SELECT type, id, timestamp, rank FROM (` + strings.Join(rankQueries, " UNION ALL ") + `) AS ranked
+			ORDER BY rank DESC, timestamp DESC LIMIT ?)
+		SELECT type, id, timestamp, rank, snippet FROM (` + strings.Join(snippetQueries, " UNION ALL ") + `) AS snippets
+		ORDER BY rank DESC, timestamp DESC`, values, nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeHTMLExpr(t *testing.T) {
+	assert.Equal(t,
+		`replace(replace(replace(replace(replace(source.post, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`,
+		escapeHTMLExpr("source.post"))
+}
+
+func TestSearchQuery(t *testing.T) {
+	query, values, err := searchQuery("account", "hello", []string{"post", "comment", "post"}, 100, 200, 20)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// every placeholder has a value, and duplicated types are searched once
+	assert.Equal(t, strings.Count(query, "?"), len(values))
+	assert.Equal(t, []interface{}{
+		"hello",
+		"account", int64(100), int64(200),
+		"account", int64(100), int64(200),
+		int64(20),
+		searchHeadlineOptions, searchHeadlineOptions,
+	}, values)
+
+	// hits are limited before the snippets are highlighted
+	limit := strings.Index(query, "LIMIT ?")
+	headline := strings.Index(query, "ts_headline")
+	assert.True(t, limit >= 0 && headline > limit, "ts_headline is computed after the limit")
+	assert.Equal(t, 2, strings.Count(query, "ts_headline"))
+	assert.Equal(t, 2, strings.Count(query, "ts_rank"))
+
+	// snippets are highlighted on the escaped text
+	assert.Contains(t, query, "ts_headline('simple', "+escapeHTMLExpr("source.post")+", q.query, ?)")
+	assert.Contains(t, query, "ts_headline('simple', "+escapeHTMLExpr("source.comment")+", q.query, ?)")
+	assert.NotContains(t, query, "facebook_reaction")
+
+	_, _, err = searchQuery("account", "hello", []string{"post", "friend"}, 100, 200, 20)
+	assert.Error(t, err)
+}
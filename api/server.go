@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"crypto/rsa"
 	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
@@ -10,19 +9,24 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/RichardKnop/machinery/v1"
 	"github.com/aws/aws-sdk-go/aws"
 	sentrygin "github.com/getsentry/sentry-go/gin"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/spf13/viper"
 
 	"github.com/bitmark-inc/bitmark-sdk-go/account"
+	"github.com/bitmark-inc/spring-app-api/deadletter"
+	"github.com/bitmark-inc/spring-app-api/events"
 	"github.com/bitmark-inc/spring-app-api/external/fbarchive"
+	"github.com/bitmark-inc/spring-app-api/external/geoservice"
 	"github.com/bitmark-inc/spring-app-api/external/onesignal"
+	"github.com/bitmark-inc/spring-app-api/keyring"
 	"github.com/bitmark-inc/spring-app-api/logmodule"
+	"github.com/bitmark-inc/spring-app-api/pipeline"
 	"github.com/bitmark-inc/spring-app-api/store"
 )
 
@@ -35,8 +39,9 @@ type Server struct {
 	store       store.Store
 	fbDataStore store.FBDataStore
 
-	// JWT private key
-	jwtPrivateKey *rsa.PrivateKey
+	// JWT signing keys
+	jwtKeys   *keyring.Keyring
+	jwtIssuer string
 
 	// AWS Config
 	awsConf *aws.Config
@@ -44,8 +49,9 @@ type Server struct {
 	ormDB *gorm.DB
 
 	// External services
-	oneSignalClient *onesignal.OneSignalClient
-	bitSocialClient *fbarchive.Client
+	oneSignalClient  *onesignal.OneSignalClient
+	bitSocialClient  *fbarchive.Client
+	geoServiceClient *geoservice.Client
 
 	// account
 	bitmarkAccount *account.AccountV2
@@ -54,21 +60,36 @@ type Server struct {
 	httpClient *http.Client
 
 	// job pool enqueuer
-	backgroundEnqueuer *machinery.Server
+	backgroundEnqueuer pipeline.Sender
+
+	// broker of job events for clients
+	events *events.Broker
+
+	// jobs failed after all of their retries
+	deadLetters *deadletter.Queue
+
+	// runner of archive workflows
+	workflows *pipeline.Runner
 
 	// country continent list
 	countryContinentMap map[string]string
 	areaFBIncomeMap     *areaFBIncomeMap
+
+	// area names of place clusters
+	placeNames *placeNameCache
 }
 
 // NewServer new instance of server
 func NewServer(store store.Store,
 	fbDataStore store.FBDataStore,
 	ormDB *gorm.DB,
-	jwtKey *rsa.PrivateKey,
+	jwtKeys *keyring.Keyring,
 	awsConf *aws.Config,
 	bitmarkAccount *account.AccountV2,
-	backgroundEnqueuer *machinery.Server) *Server {
+	backgroundEnqueuer pipeline.Sender,
+	eventBroker *events.Broker,
+	deadLetters *deadletter.Queue,
+	workflows *pipeline.Runner) *Server {
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 	}
@@ -81,13 +102,19 @@ func NewServer(store store.Store,
 		store:              store,
 		fbDataStore:        fbDataStore,
 		ormDB:              ormDB,
-		jwtPrivateKey:      jwtKey,
+		jwtKeys:            jwtKeys,
+		jwtIssuer:          jwtIssuer(jwtKeys.FirstKey()),
 		awsConf:            awsConf,
 		httpClient:         httpClient,
 		bitmarkAccount:     bitmarkAccount,
 		oneSignalClient:    onesignal.NewClient(httpClient),
 		bitSocialClient:    fbarchive.NewClient(httpClient),
+		geoServiceClient:   geoservice.NewClient(httpClient),
 		backgroundEnqueuer: backgroundEnqueuer,
+		events:             eventBroker,
+		deadLetters:        deadLetters,
+		workflows:          workflows,
+		placeNames:         newPlaceNameCache(),
 	}
 }
 
@@ -122,6 +149,8 @@ func (s *Server) setupRouter() *gin.Engine {
 		Timeout:         10 * time.Second,
 	}))
 
+	r.GET("/.well-known/jwks.json", s.jwks)
+
 	webhookRoute := r.Group("/webhook")
 	webhookRoute.Use(logmodule.Ginrus("Webhook"))
 	{
@@ -133,6 +162,8 @@ func (s *Server) setupRouter() *gin.Engine {
 	apiRoute.Use(s.clientVersionGateway())
 
 	apiRoute.POST("/auth", s.requestJWT)
+	apiRoute.POST("/auth/refresh", s.refreshJWT)
+	apiRoute.POST("/auth/revoke", s.authMiddleware(), s.revokeJWT)
 
 	accountRoute := apiRoute.Group("/accounts")
 	accountRoute.Use(s.authMiddleware())
@@ -159,6 +190,14 @@ func (s *Server) setupRouter() *gin.Engine {
 		archivesRoute.POST("", s.uploadArchive)
 		archivesRoute.POST("url", s.uploadArchiveByURL)
 		archivesRoute.GET("", s.getAllArchives)
+		archivesRoute.GET(":id", s.getArchive)
+	}
+
+	streamRoute := apiRoute.Group("/stream")
+	streamRoute.Use(s.authMiddleware())
+	streamRoute.Use(s.recognizeAccountMiddleware())
+	{
+		streamRoute.GET("", s.streamJobEvents)
 	}
 
 	postRoute := apiRoute.Group("/posts")
@@ -189,6 +228,13 @@ func (s *Server) setupRouter() *gin.Engine {
 		reactionRoute.GET("", s.getAllReactions)
 	}
 
+	commentRoute := apiRoute.Group("/comments")
+	commentRoute.Use(s.authMiddleware())
+	commentRoute.Use(s.fakeCredential())
+	{
+		commentRoute.GET("", s.getAllComments)
+	}
+
 	eventRoute := apiRoute.Group("/events")
 	eventRoute.Use(s.authMiddleware())
 	eventRoute.Use(s.fakeCredential())
@@ -196,6 +242,28 @@ func (s *Server) setupRouter() *gin.Engine {
 		eventRoute.GET("", s.getAllEvents)
 	}
 
+	friendRoute := apiRoute.Group("/friends")
+	friendRoute.Use(s.authMiddleware())
+	friendRoute.Use(s.fakeCredential())
+	{
+		friendRoute.GET("", s.getAllFriends)
+		friendRoute.GET("/:id", s.getFriend)
+	}
+
+	placeRoute := apiRoute.Group("/places")
+	placeRoute.Use(s.authMiddleware())
+	placeRoute.Use(s.fakeCredential())
+	{
+		placeRoute.GET("", s.getAllPlaces)
+	}
+
+	searchRoute := apiRoute.Group("/search")
+	searchRoute.Use(s.authMiddleware())
+	searchRoute.Use(s.fakeCredential())
+	{
+		searchRoute.GET("", s.search)
+	}
+
 	usageRoute := apiRoute.Group("/usage")
 	usageRoute.Use(s.authMiddleware())
 	usageRoute.Use(s.fakeCredential())
@@ -227,13 +295,22 @@ func (s *Server) setupRouter() *gin.Engine {
 
 	secretRoute := r.Group("/secret")
 	secretRoute.Use(logmodule.Ginrus("Secret"))
-	secretRoute.Use(s.apikeyAuthentication(viper.GetString("server.apikey.admin")))
+	secretRoute.Use(s.apikeyAuthentication())
+	secretRoute.Use(s.auditLog())
 	{
-		secretRoute.POST("/ack-archive-uploaded", s.adminAckArchiveUploaded)
-		secretRoute.POST("/submit-archives", s.adminSubmitArchives)
-		secretRoute.POST("/parse-archives", s.adminForceParseArchive)
-		secretRoute.POST("/generate-hash-content", s.adminGenerateHashContent)
-		secretRoute.POST("/delete-accounts", s.adminAccountDelete)
+		secretRoute.POST("/ack-archive-uploaded", s.requireScope(scopeArchivesSubmit), s.adminAckArchiveUploaded)
+		secretRoute.POST("/submit-archives", s.requireScope(scopeArchivesSubmit), s.adminSubmitArchives)
+		secretRoute.POST("/parse-archives", s.requireScope(scopeArchivesReparse), s.adminForceParseArchive)
+		secretRoute.POST("/remove-archive-data", s.requireScope(scopeArchivesRemove), s.adminRemoveArchiveData)
+		secretRoute.POST("/generate-hash-content", s.requireScope(scopeArchivesReparse), s.adminGenerateHashContent)
+		secretRoute.POST("/delete-accounts", s.requireScope(scopeAccountsDelete), s.adminAccountDelete)
+		secretRoute.GET("/dead-letter-jobs", s.requireScope(scopeJobsRead), s.adminListDeadLetterJobs)
+		secretRoute.POST("/dead-letter-jobs/requeue", s.requireScope(scopeJobsRequeue), s.adminRequeueDeadLetterJobs)
+
+		secretRoute.GET("/api-keys", s.requireScope(scopeAPIKeysManage), s.adminListAPIKeys)
+		secretRoute.POST("/api-keys", s.requireScope(scopeAPIKeysManage), s.adminCreateAPIKey)
+		secretRoute.DELETE("/api-keys/:name", s.requireScope(scopeAPIKeysManage), s.adminDeleteAPIKey)
+		secretRoute.GET("/audit-logs", s.requireScope(scopeAuditRead), s.adminListAuditLogs)
 	}
 
 	metricRoute := r.Group("/metrics")
@@ -246,7 +323,8 @@ func (s *Server) setupRouter() *gin.Engine {
 		AllowAllOrigins:  true,
 		MaxAge:           12 * time.Hour,
 	}))
-	metricRoute.Use(s.apikeyAuthentication(viper.GetString("server.apikey.metric")))
+	metricRoute.Use(s.apikeyAuthentication())
+	metricRoute.Use(s.requireScope(scopeMetricsRead))
 	{
 		metricRoute.GET("/total-users", s.metricAccountCreation)
 	}
@@ -301,6 +379,20 @@ func shouldInterupt(err error, c *gin.Context) bool {
 	return true
 }
 
+// parseUUIDCursor parses the cursor of a list ordered by timestamp and uuid
+func parseUUIDCursor(s string) (*store.Cursor, error) {
+	cursor, err := store.ParseCursor(s)
+	if err != nil || cursor == nil {
+		return cursor, err
+	}
+
+	if _, err := uuid.Parse(cursor.ID); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
 func (s *Server) healthz(c *gin.Context) {
 	// Ping db
 	err := s.store.Ping(c)
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+const eventHeartbeatInterval = 30 * time.Second
+
+// streamJobEvents streams the state transitions of archives and export jobs of an account
+// as server-sent events. A heartbeat is sent periodically to keep the connection alive.
+func (s *Server) streamJobEvents(c *gin.Context) {
+	account := c.MustGet("account").(*store.Account)
+
+	events, err := s.events.Subscribe(c.Request.Context(), account.AccountNumber)
+	if shouldInterupt(err, c) {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("ready", gin.H{"timestamp": time.Now()})
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(e.Type, e)
+		case t := <-heartbeat.C:
+			c.SSEvent("heartbeat", gin.H{"timestamp": t})
+		case <-c.Request.Context().Done():
+			return false
+		}
+		return true
+	})
+}
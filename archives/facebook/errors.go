@@ -4,6 +4,9 @@ package facebook
 type ArchiveError struct {
 	CodeString string `json:"code"`
 	Message    string `json:"message"`
+
+	// permanent errors are not retried since they fail whenever the archive is processed
+	permanent bool
 }
 
 func (a *ArchiveError) Code() string {
@@ -13,6 +16,11 @@ func (a *ArchiveError) Error() string {
 	return a.Message
 }
 
+// Permanent tells whether processing the archive again fails as well
+func (a *ArchiveError) Permanent() bool {
+	return a.permanent
+}
+
 func NewArchiveError(code, message string) *ArchiveError {
 	return &ArchiveError{
 		CodeString: code,
@@ -24,7 +32,9 @@ var (
 	ErrFailToCreateArchive   = NewArchiveError("FAIL_TO_CREATE_ARCHIVE", "fail to create archive")
 	ErrFailToParseArchive    = NewArchiveError("FAIL_TO_PARSE_ARCHIVE", "fail to parse archive")
 	ErrFailToDownloadArchive = NewArchiveError("FAIL_TO_DOWNLOAD_ARCHIVE", "fail to download archive")
-	ErrInvalidArchive        = NewArchiveError("INVALID_ARCHIVE", "invalid archive")
+	ErrInvalidArchive        = &ArchiveError{CodeString: "INVALID_ARCHIVE", Message: "invalid archive", permanent: true}
 	ErrFailToExtractPost     = NewArchiveError("FAIL_TO_EXTRACT_POST", "fail to extract post")
 	ErrFailToExtractReaction = NewArchiveError("FAIL_TO_EXTRACT_REACTION", "fail to extract reaction")
+	ErrFailToExtractComment  = NewArchiveError("FAIL_TO_EXTRACT_COMMENT", "fail to extract comment")
+	ErrProcessingTimeout     = NewArchiveError("PROCESSING_TIMEOUT", "archive is not processed in time")
 )
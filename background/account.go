@@ -16,6 +16,39 @@ func (b *BackgroundContext) deleteUserData(ctx context.Context, accountNumber st
 	logEntity := log.WithField("prefix", "delete_user_data")
 
 	// Delete data on dynamodb
+	b.removeFBStats(ctx, accountNumber)
+
+	// Delete on s3
+	logEntity.Info("Remove s3 archive")
+	sess := session.New(b.awsConf)
+	svc := s3.New(sess)
+
+	iter := s3manager.NewDeleteListIterator(svc, &s3.ListObjectsInput{
+		Bucket: aws.String(viper.GetString("aws.s3.bucket")),
+		Prefix: aws.String(accountNumber + "/"),
+	})
+
+	if err := s3manager.NewBatchDeleteWithClient(svc).Delete(ctx, iter); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+
+	// Delete on postgres db
+	logEntity.Info("Remove postgres db")
+	if err := b.store.DeleteAccount(ctx, accountNumber); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+
+	logEntity.Info("Finish")
+
+	return nil
+}
+
+// removeFBStats removes the stats and the items of the stats of an account
+func (b *BackgroundContext) removeFBStats(ctx context.Context, accountNumber string) {
+	logEntity := log.WithField("prefix", "remove_fb_stats")
+
 	logEntity.Info("Remove post week stat")
 	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/post-week-stat"); err != nil {
 		logEntity.Error(err)
@@ -52,41 +85,39 @@ func (b *BackgroundContext) deleteUserData(ctx context.Context, accountNumber st
 		sentry.CaptureException(err)
 	}
 
-	logEntity.Info("Remove posts")
-	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/post"); err != nil {
+	logEntity.Info("Remove comment week stat")
+	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/comment-week-stat"); err != nil {
 		logEntity.Error(err)
 		sentry.CaptureException(err)
 	}
 
-	logEntity.Info("Remove reactions")
-	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/reaction"); err != nil {
+	logEntity.Info("Remove comment year stat")
+	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/comment-year-stat"); err != nil {
 		logEntity.Error(err)
 		sentry.CaptureException(err)
 	}
 
-	// Delete on s3
-	logEntity.Info("Remove s3 archive")
-	sess := session.New(b.awsConf)
-	svc := s3.New(sess)
-
-	iter := s3manager.NewDeleteListIterator(svc, &s3.ListObjectsInput{
-		Bucket: aws.String(viper.GetString("aws.s3.bucket")),
-		Prefix: aws.String(accountNumber + "/"),
-	})
-
-	if err := s3manager.NewBatchDeleteWithClient(svc).Delete(ctx, iter); err != nil {
+	logEntity.Info("Remove comment decade stat")
+	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/comment-decade-stat"); err != nil {
 		logEntity.Error(err)
 		sentry.CaptureException(err)
 	}
 
-	// Delete on postgres db
-	logEntity.Info("Remove postgres db")
-	if err := b.store.DeleteAccount(ctx, accountNumber); err != nil {
+	logEntity.Info("Remove posts")
+	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/post"); err != nil {
 		logEntity.Error(err)
 		sentry.CaptureException(err)
 	}
 
-	logEntity.Info("Finish")
+	logEntity.Info("Remove reactions")
+	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/reaction"); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
 
-	return nil
+	logEntity.Info("Remove comments")
+	if err := b.fbDataStore.RemoveFBStat(ctx, accountNumber+"/comment"); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
 }
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/getsentry/sentry-go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/schema/spring"
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// issueArchiveBitmark registers the content hash of an archive and issues a bitmark of it to the owner of the archive
+func (b *BackgroundContext) issueArchiveBitmark(ctx context.Context, accountNumber string, archiveID int64) error {
+	logEntity := log.WithField("prefix", jobIssueArchiveBitmark).WithField("archive_id", archiveID)
+
+	if b.registrar == nil {
+		logEntity.Warn("no global account to issue bitmarks")
+		return nil
+	}
+
+	archives, err := b.store.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		ID:            &archiveID,
+		AccountNumber: &accountNumber,
+	})
+	if err != nil {
+		logEntity.Error(err)
+		return err
+	}
+	if len(archives) == 0 {
+		return fmt.Errorf("archive %d not found", archiveID)
+	}
+
+	archive := archives[0]
+	if archive.BitmarkID != "" {
+		logEntity.WithField("bitmark_id", archive.BitmarkID).Info("the archive is issued already")
+		return nil
+	}
+
+	bitmarkID, err := b.registrar.Register("spring archive", map[string]string{
+		"type":       "archive",
+		"archive_id": strconv.FormatInt(archiveID, 10),
+		"created_at": archive.CreatedAt.UTC().Format("2006-01-02"),
+	}, archive.ContentHash, accountNumber)
+	if err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return err
+	}
+
+	if _, err := b.store.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+		ID: &archiveID,
+	}, &store.FBArchiveQueryParam{
+		BitmarkID: &bitmarkID,
+	}); err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	logEntity.WithField("bitmark_id", bitmarkID).Info("issued bitmark of the archive")
+	return nil
+}
+
+// issueExportBitmark registers the content hash of a data export and issues a bitmark of it to the owner of the export
+func (b *BackgroundContext) issueExportBitmark(ctx context.Context, accountNumber, exportID string) error {
+	logEntity := log.WithField("prefix", jobIssueExportBitmark).WithField("export_id", exportID)
+
+	if b.registrar == nil {
+		logEntity.Warn("no global account to issue bitmarks")
+		return nil
+	}
+
+	var export spring.ArchiveORM
+	if err := b.ormDB.Where("id = ? AND account_number = ?", exportID, accountNumber).First(&export).Error; err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	if export.BitmarkID != "" {
+		logEntity.WithField("bitmark_id", export.BitmarkID).Info("the export is issued already")
+		return nil
+	}
+
+	bitmarkID, err := b.registrar.Register("spring export", map[string]string{
+		"type":       "export",
+		"created_at": export.CreatedAt.UTC().Format("2006-01-02"),
+	}, export.ContentHash, accountNumber)
+	if err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return err
+	}
+
+	if err := b.ormDB.Model(&spring.ArchiveORM{}).Where("id = ?", exportID).
+		Update("bitmark_id", bitmarkID).Error; err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	logEntity.WithField("bitmark_id", bitmarkID).Info("issued bitmark of the export")
+	return nil
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+
+	fbArchive "github.com/bitmark-inc/spring-app-api/archives/facebook"
+	"github.com/bitmark-inc/spring-app-api/protomodel"
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/store"
+	"github.com/bitmark-inc/spring-app-api/timeutil"
+)
+
+func (b *BackgroundContext) extractComment(ctx context.Context, accountNumber string, archiveID int64) (err error) {
+	unlock, err := b.lockAccount(accountNumber, archiveID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	jobError := NewArchiveJobError(archiveID, fbArchive.ErrFailToExtractComment)
+	logEntry := log.WithField("prefix", "extract_comment")
+
+	var itemCount int64
+	finishStage := b.startStage(ctx, archiveID, store.FBArchiveStageAnalyzeComments)
+	defer func() { finishStage(itemCount, err) }()
+
+	saver := newStatSaver(b.fbDataStore)
+	scope, err := b.mergeScope(ctx, accountNumber, archiveID, "comment")
+	if err != nil {
+		logEntry.Error(err)
+		return jobError(err)
+	}
+	saver.scope = scope
+	counter := newCommentStatCounter(ctx, logEntry, saver, accountNumber)
+
+	var lastTimestamp int64
+
+	// Save to db & count
+	comments := make([]facebook.CommentORM, 0)
+	if err := b.ormDB.Preload("MediaItems").
+		Where(&facebook.CommentORM{DataOwnerID: accountNumber}).
+		Order("timestamp ASC").Find(&comments).Error; err != nil {
+		logEntry.Error(err)
+		return jobError(err)
+	}
+
+	items := make([]*protomodel.Comment, 0, len(comments))
+	for _, comment := range comments {
+		media := make([]*protomodel.MediaData, 0)
+		for _, m := range comment.MediaItems {
+			mediaType := "photo"
+			if m.FilenameExtension == ".mp4" {
+				mediaType = "video"
+			}
+			thumbnailURI := m.ThumbnailURI
+			if thumbnailURI == "" {
+				thumbnailURI = m.MediaURI
+			}
+			media = append(media, &protomodel.MediaData{
+				Type:      mediaType,
+				Source:    m.MediaURI,
+				Thumbnail: thumbnailURI,
+			})
+		}
+
+		items = append(items, &protomodel.Comment{
+			CommentId: comment.ID.String(),
+			Timestamp: comment.Timestamp,
+			Author:    comment.Author,
+			Comment:   comment.Comment,
+			Type:      commentType(comment),
+			Url:       comment.ExternalContextURL,
+			MediaData: media,
+			Source:    "facebook",
+		})
+	}
+
+	// Merge comments from other sources
+	instagramComments, err := b.instagramComments(accountNumber)
+	if err != nil {
+		logEntry.Error(err)
+		return jobError(err)
+	}
+	items = append(items, instagramComments...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Timestamp < items[j].Timestamp
+	})
+	itemCount = int64(len(items))
+
+	for _, comment := range items {
+		if lastTimestamp == comment.Timestamp {
+			continue
+		}
+		lastTimestamp = comment.Timestamp
+
+		commentData, _ := proto.Marshal(comment)
+		if err := saver.save(accountNumber+"/comment", comment.Timestamp, commentData); err != nil {
+			logEntry.Error(err)
+			sentry.CaptureException(err)
+			return jobError(err)
+		}
+
+		if err := counter.count(comment); err != nil {
+			logEntry.Error(err)
+			sentry.CaptureException(err)
+			return jobError(err)
+		}
+	}
+
+	if err := counter.flush(); err != nil {
+		logEntry.Error(err)
+		sentry.CaptureException(err)
+		return jobError(err)
+	}
+	if err := saver.flush(); err != nil {
+		logEntry.Error(err)
+		sentry.CaptureException(err)
+		return jobError(err)
+	}
+	if err := saver.removeStale(); err != nil {
+		logEntry.Error(err)
+		sentry.CaptureException(err)
+		return jobError(err)
+	}
+
+	logEntry.Info("Finish parsing comments")
+
+	return nil
+}
+
+// commentType categorizes a comment by what is attached to it
+func commentType(comment facebook.CommentORM) string {
+	if comment.MediaAttached {
+		return "media"
+	}
+
+	if comment.ExternalContextURL != "" {
+		return "link"
+	}
+
+	return "text"
+}
+
+type commentStat struct {
+	Comment *protomodel.Usage
+	IsSaved bool
+}
+
+type commentStatCounter struct {
+	lastWeekStat      *commentStat
+	currentWeekStat   *commentStat
+	lastYearStat      *commentStat
+	currentYearStat   *commentStat
+	lastDecadeStat    *commentStat
+	currentDecadeStat *commentStat
+	accountNumber     string
+	ctx               context.Context
+	saver             *statSaver
+	log               *log.Entry
+}
+
+func newCommentStatCounter(ctx context.Context, log *log.Entry, saver *statSaver, accountNumber string) *commentStatCounter {
+	return &commentStatCounter{
+		ctx:           ctx,
+		saver:         saver,
+		log:           log,
+		accountNumber: accountNumber,
+	}
+}
+
+func (r *commentStatCounter) createEmptyStat(period string, timestamp int64) *commentStat {
+	return &commentStat{
+		Comment: &protomodel.Usage{
+			SectionName:     "comment",
+			Period:          period,
+			PeriodStartedAt: timeutil.AbsPeriod(period, timestamp),
+			Groups: &protomodel.Group{
+				Type: &protomodel.PeriodData{
+					Data: make(map[string]int64),
+				},
+				SubPeriod: make([]*protomodel.PeriodData, 0),
+			},
+		},
+		IsSaved: false,
+	}
+}
+
+func (r *commentStatCounter) flushStat(period string, currentStat *commentStat, lastStat *commentStat) error {
+	if currentStat != nil && !currentStat.IsSaved {
+		// Calculate the difference
+		var lastQuantity int64
+		if lastStat != nil {
+			lastQuantity = lastStat.Comment.Quantity
+		}
+		currentStat.Comment.DiffFromPrevious = timeutil.GetDiff(float64(currentStat.Comment.Quantity), float64(lastQuantity))
+
+		statData, _ := proto.Marshal(currentStat.Comment)
+
+		// Save data
+		if err := r.saver.save(r.accountNumber+"/comment-"+period+"-stat", currentStat.Comment.PeriodStartedAt, statData); err != nil {
+			return err
+		}
+		currentStat.IsSaved = true
+	}
+	return nil
+}
+
+func (r *commentStatCounter) flush() error {
+	if err := r.flushStat("week", r.currentWeekStat, r.lastWeekStat); err != nil {
+		return err
+	}
+	if err := r.flushStat("year", r.currentYearStat, r.lastYearStat); err != nil {
+		return err
+	}
+	if err := r.flushStat("decade", r.currentDecadeStat, r.lastDecadeStat); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *commentStatCounter) count(comment *protomodel.Comment) error {
+	if err := r.countWeek(comment); err != nil {
+		return err
+	}
+	if err := r.countYear(comment); err != nil {
+		return err
+	}
+	if err := r.countDecade(comment); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *commentStatCounter) countWeek(comment *protomodel.Comment) error {
+	periodTimestamp := timeutil.AbsWeek(comment.Timestamp)
+
+	// Release the current period if next period has come
+	if r.currentWeekStat != nil && r.currentWeekStat.Comment.PeriodStartedAt != periodTimestamp {
+		if err := r.flushStat("week", r.currentWeekStat, r.lastWeekStat); err != nil {
+			return err
+		}
+		r.lastWeekStat = r.currentWeekStat
+		r.currentWeekStat = nil
+	}
+
+	// no data for current period yet, let's create one
+	if r.currentWeekStat == nil {
+		r.currentWeekStat = r.createEmptyStat("week", periodTimestamp)
+	}
+
+	r.currentWeekStat.Comment.Quantity++
+	plusOneValue(&r.currentWeekStat.Comment.Groups.Type.Data, comment.Type)
+
+	subPeriod := r.currentWeekStat.Comment.Groups.SubPeriod
+	subPeriodTimestamp := timeutil.AbsDay(comment.Timestamp)
+	needNewSubPeriod := len(subPeriod) == 0 || subPeriod[len(subPeriod)-1].Name != strconv.FormatInt(subPeriodTimestamp, 10)
+
+	if needNewSubPeriod {
+		subPeriod = append(subPeriod, &protomodel.PeriodData{
+			Name: strconv.FormatInt(subPeriodTimestamp, 10),
+			Data: make(map[string]int64),
+		})
+	}
+	plusOneValue(&subPeriod[len(subPeriod)-1].Data, comment.Type)
+	r.currentWeekStat.Comment.Groups.SubPeriod = subPeriod
+
+	return nil
+}
+
+func (r *commentStatCounter) countYear(comment *protomodel.Comment) error {
+	periodTimestamp := timeutil.AbsYear(comment.Timestamp)
+
+	// Release the current period if next period has come
+	if r.currentYearStat != nil && r.currentYearStat.Comment.PeriodStartedAt != periodTimestamp {
+		if err := r.flushStat("year", r.currentYearStat, r.lastYearStat); err != nil {
+			return err
+		}
+		r.lastYearStat = r.currentYearStat
+		r.currentYearStat = nil
+	}
+
+	// no data for current period yet, let's create one
+	if r.currentYearStat == nil {
+		r.currentYearStat = r.createEmptyStat("year", periodTimestamp)
+	}
+
+	r.currentYearStat.Comment.Quantity++
+	plusOneValue(&r.currentYearStat.Comment.Groups.Type.Data, comment.Type)
+
+	subPeriod := r.currentYearStat.Comment.Groups.SubPeriod
+	subPeriodTimestamp := timeutil.AbsMonth(comment.Timestamp)
+	needNewSubPeriod := len(subPeriod) == 0 || subPeriod[len(subPeriod)-1].Name != strconv.FormatInt(subPeriodTimestamp, 10)
+
+	if needNewSubPeriod {
+		subPeriod = append(subPeriod, &protomodel.PeriodData{
+			Name: strconv.FormatInt(subPeriodTimestamp, 10),
+			Data: make(map[string]int64),
+		})
+	}
+	plusOneValue(&subPeriod[len(subPeriod)-1].Data, comment.Type)
+	r.currentYearStat.Comment.Groups.SubPeriod = subPeriod
+
+	return nil
+}
+
+func (r *commentStatCounter) countDecade(comment *protomodel.Comment) error {
+	periodTimestamp := timeutil.AbsDecade(comment.Timestamp)
+
+	// Release the current period if next period has come
+	if r.currentDecadeStat != nil && r.currentDecadeStat.Comment.PeriodStartedAt != periodTimestamp {
+		if err := r.flushStat("decade", r.currentDecadeStat, r.lastDecadeStat); err != nil {
+			return err
+		}
+		r.lastDecadeStat = r.currentDecadeStat
+		r.currentDecadeStat = nil
+	}
+
+	// no data for current period yet, let's create one
+	if r.currentDecadeStat == nil {
+		r.currentDecadeStat = r.createEmptyStat("decade", periodTimestamp)
+	}
+
+	r.currentDecadeStat.Comment.Quantity++
+	plusOneValue(&r.currentDecadeStat.Comment.Groups.Type.Data, comment.Type)
+
+	subPeriod := r.currentDecadeStat.Comment.Groups.SubPeriod
+	subPeriodTimestamp := timeutil.AbsYear(comment.Timestamp)
+	needNewSubPeriod := len(subPeriod) == 0 || subPeriod[len(subPeriod)-1].Name != strconv.FormatInt(subPeriodTimestamp, 10)
+
+	if needNewSubPeriod {
+		subPeriod = append(subPeriod, &protomodel.PeriodData{
+			Name: strconv.FormatInt(subPeriodTimestamp, 10),
+			Data: make(map[string]int64),
+		})
+	}
+	plusOneValue(&subPeriod[len(subPeriod)-1].Data, comment.Type)
+	r.currentDecadeStat.Comment.Groups.SubPeriod = subPeriod
+
+	return nil
+}
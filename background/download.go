@@ -14,10 +14,11 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/RichardKnop/machinery/v1/tasks"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/bitmark-inc/spring-app-api/archives/facebook"
+	"github.com/bitmark-inc/spring-app-api/archives/instagram"
+	"github.com/bitmark-inc/spring-app-api/archives/twitter"
 	"github.com/bitmark-inc/spring-app-api/s3util"
 	"github.com/bitmark-inc/spring-app-api/store"
 	"github.com/getsentry/sentry-go"
@@ -117,10 +118,26 @@ func downloadFromLink(ctx context.Context, httpClient *http.Client, link, rawCoo
 	}
 }
 
-func (b *BackgroundContext) downloadArchive(ctx context.Context, fileURL, archiveType, rawCookie, accountNumber string, archiveid int64) error {
+// isValidArchiveFile validates an archive file by the layout of its archive type
+func isValidArchiveFile(archiveType, filename string) bool {
+	switch archiveType {
+	case "instagram":
+		return instagram.IsValidArchiveFile(filename)
+	case "twitter":
+		return twitter.IsValidArchiveFile(filename)
+	default:
+		return facebook.IsValidArchiveFile(filename)
+	}
+}
+
+func (b *BackgroundContext) downloadArchive(ctx context.Context, fileURL, archiveType, rawCookie, accountNumber string, archiveid int64) (err error) {
 	jobError := NewArchiveJobError(archiveid, facebook.ErrFailToDownloadArchive)
 	logEntity := log.WithField("prefix", "download_archive")
 
+	// the archive is hashed while it is uploaded to s3 after downloading
+	finishStage := b.startStage(ctx, archiveid, store.FBArchiveStageDownload)
+	defer func() { finishStage(1, err) }()
+
 	resp, err := downloadFromLink(ctx, b.httpClient, fileURL, rawCookie)
 	if err != nil {
 		logEntity.Error(err)
@@ -137,7 +154,13 @@ func (b *BackgroundContext) downloadArchive(ctx context.Context, fileURL, archiv
 		}
 		logEntity.WithField("dump", string(dumpBytes)).Error("Request failed")
 		sentry.CaptureException(errors.New("Request failed"))
-		return nil
+		requestError := fmt.Errorf("request failed with status %d", resp.StatusCode)
+
+		// the archive may be downloaded later if the server of the link is unavailable
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			return jobError(&transientError{requestError})
+		}
+		return jobError(requestError)
 	} else {
 		dumpBytes, err := httputil.DumpResponse(resp, false)
 		if err != nil {
@@ -161,11 +184,14 @@ func (b *BackgroundContext) downloadArchive(ctx context.Context, fileURL, archiv
 		return jobError(err)
 	}
 
-	if !facebook.IsValidArchiveFile(tmpfile.Name()) {
+	if !isValidArchiveFile(archiveType, tmpfile.Name()) {
 		jobError := NewArchiveJobError(archiveid, facebook.ErrInvalidArchive)
 		return jobError(fmt.Errorf("invalid archive file"))
 	}
 
+	finishStage(1, nil)
+	finishStage = b.startStage(ctx, archiveid, store.FBArchiveStageHash)
+
 	sess := session.New(b.awsConf)
 
 	logEntity.Info("Start uploading to S3")
@@ -174,10 +200,18 @@ func (b *BackgroundContext) downloadArchive(ctx context.Context, fileURL, archiv
 		return jobError(err)
 	}
 
+	// the fingerprint is the hash of the archive before it is encrypted
 	h := sha3.New512()
 	teeReader := io.TeeReader(tmpfile, h)
 
-	s3key, err := s3util.UploadArchive(sess, teeReader, accountNumber, archiveType, archiveid, map[string]*string{
+	data, err := b.encryptForAccount(ctx, accountNumber, teeReader)
+	if err != nil {
+		logEntity.Error(err)
+		return jobError(err)
+	}
+	defer data.Close()
+
+	s3key, err := s3util.UploadArchive(sess, data, accountNumber, archiveType, archiveid, map[string]*string{
 		"url":          aws.String(fileURL),
 		"archive_type": aws.String(archiveType),
 		"archive_id":   aws.String(strconv.FormatInt(archiveid, 10)),
@@ -204,24 +238,6 @@ func (b *BackgroundContext) downloadArchive(ctx context.Context, fileURL, archiv
 		return jobError(err)
 	}
 
-	server.SendTask(&tasks.Signature{
-		Name: jobParseArchive,
-		Args: []tasks.Arg{
-			{
-				Type:  "string",
-				Value: archiveType,
-			},
-			{
-				Type:  "string",
-				Value: accountNumber,
-			},
-			{
-				Type:  "int64",
-				Value: archiveid,
-			},
-		},
-	})
-
 	logEntity.Info("Finish...")
 
 	return nil
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/spf13/viper"
+
+	"github.com/bitmark-inc/spring-app-api/encryption"
+	"github.com/bitmark-inc/spring-app-api/s3util"
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// encryptForAccount returns a reader of the data of r encrypted for an account and the server.
+// The data is read as it is if encryption is disabled. The reader must be closed after it is read
+// to stop encrypting when the data is not completely read.
+func (b *BackgroundContext) encryptForAccount(ctx context.Context, accountNumber string, r io.Reader) (io.ReadCloser, error) {
+	if !viper.GetBool("encryption.enabled") {
+		return ioutil.NopCloser(r), nil
+	}
+
+	account, err := b.store.QueryAccount(ctx, &store.AccountQueryParam{
+		AccountNumber: &accountNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account %s not found", accountNumber)
+	}
+
+	// data must not be encrypted only for the server, or the account is unable to read it
+	if err := encryption.ValidatePublicKey(account.EncryptionPublicKey); err != nil {
+		return nil, fmt.Errorf("account %s: %s", accountNumber, err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		w, err := b.envelope.Encrypt(pw, account.EncryptionPublicKey)
+		if err == nil {
+			_, err = io.Copy(w, r)
+		}
+		if err == nil {
+			err = w.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// downloadDecrypted downloads a file from S3 and decrypts it if it is encrypted
+func (b *BackgroundContext) downloadDecrypted(sess *session.Session, key string, w io.Writer) error {
+	body, err := s3util.DownloadStream(sess, viper.GetString("aws.s3.bucket"), key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	r, err := b.envelope.Decrypt(body)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, r)
+	return err
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/getsentry/sentry-go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/events"
+	"github.com/bitmark-inc/spring-app-api/schema/spring"
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// taskArchiveID returns the id of the fb archive of a task, which is the last int64 argument of archive tasks
+func taskArchiveID(signature *tasks.Signature) (int64, bool) {
+	for i := len(signature.Args) - 1; i >= 0; i-- {
+		arg := signature.Args[i]
+		if arg.Type != "int64" {
+			continue
+		}
+
+		v, err := tasks.ReflectValue(arg.Type, arg.Value)
+		if err != nil {
+			return 0, false
+		}
+		return v.Int(), true
+	}
+	return 0, false
+}
+
+// taskState returns the state of a task in the result backend
+func taskState(signature *tasks.Signature) string {
+	state, err := server.GetBackend().GetState(signature.UUID)
+	if err != nil {
+		return ""
+	}
+	return state.State
+}
+
+// publishArchiveEvent publishes the status of the fb archive of a task when the task is done
+func (b *BackgroundContext) publishArchiveEvent(signature *tasks.Signature) {
+	archiveID, ok := taskArchiveID(signature)
+	if !ok {
+		return
+	}
+
+	logEntity := log.WithField("prefix", "events").
+		WithField("task", signature.Name).
+		WithField("archive_id", archiveID)
+
+	archives, err := b.store.GetFBArchives(context.Background(), &store.FBArchiveQueryParam{
+		ID: &archiveID,
+	})
+	if err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return
+	}
+	if len(archives) == 0 {
+		// the archive is removed along with its account
+		logEntity.Debug("archive not found")
+		return
+	}
+
+	archive := archives[0]
+	if err := b.events.Publish(events.Event{
+		Type:          events.TypeArchive,
+		AccountNumber: archive.AccountNumber,
+		ArchiveID:     archive.ID,
+		Status:        archive.ProcessingStatus,
+		Task:          signature.Name,
+		State:         taskState(signature),
+	}); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+}
+
+// publishArchiveErrorEvent publishes the error of an invalid fb archive
+func (b *BackgroundContext) publishArchiveErrorEvent(archiveID int64, codeError CodeError) {
+	logEntity := log.WithField("prefix", "events").WithField("archive_id", archiveID)
+
+	archives, err := b.store.GetFBArchives(context.Background(), &store.FBArchiveQueryParam{
+		ID: &archiveID,
+	})
+	if err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return
+	}
+	if len(archives) == 0 {
+		logEntity.Debug("archive not found")
+		return
+	}
+
+	archive := archives[0]
+	if err := b.events.Publish(events.Event{
+		Type:          events.TypeArchive,
+		AccountNumber: archive.AccountNumber,
+		ArchiveID:     archive.ID,
+		Status:        archive.ProcessingStatus,
+		Error:         codeError.Code(),
+	}); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+}
+
+// publishExportEvent publishes the status of the spring archive of an export task when the task is done
+func (b *BackgroundContext) publishExportEvent(signature *tasks.Signature) {
+	logEntity := log.WithField("prefix", "events").
+		WithField("task", signature.Name).
+		WithField("uuid", signature.UUID)
+
+	var archive spring.ArchiveORM
+	if err := b.ormDB.Where("job_id = ?", signature.UUID).First(&archive).Error; err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return
+	}
+
+	if err := b.events.Publish(events.Event{
+		Type:          events.TypeExport,
+		AccountNumber: archive.AccountNumber,
+		ExportID:      archive.ID.String(),
+		Status:        archive.Status,
+		Task:          signature.Name,
+		State:         archive.Status,
+	}); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+}
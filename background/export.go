@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,17 +10,20 @@ import (
 	"path"
 	"time"
 
+	"github.com/RichardKnop/machinery/v1/tasks"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/getsentry/sentry-go"
 	"github.com/gogo/protobuf/proto"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/bitmark-inc/spring-app-api/protomodel"
 	"github.com/bitmark-inc/spring-app-api/s3util"
 	"github.com/bitmark-inc/spring-app-api/schema/facebook"
 	"github.com/bitmark-inc/spring-app-api/schema/spring"
+	"github.com/bitmark-inc/spring-app-api/schema/twitter"
 	"github.com/bitmark-inc/spring-app-api/store"
 	"github.com/bitmark-inc/spring-app-api/ziputil"
 )
@@ -90,6 +94,26 @@ func (b *BackgroundContext) exportReactionsFromDynamo(ctx context.Context, accou
 	return reactions, nil
 }
 
+func (b *BackgroundContext) exportCommentsFromDynamo(ctx context.Context, accountNumber string) ([]*protomodel.Comment, error) {
+	data, err := b.fbDataStore.GetFBStat(ctx, accountNumber+"/comment", 0, time.Now().Unix(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*protomodel.Comment, 0)
+	for _, d := range data {
+		var comment protomodel.Comment
+		err := proto.Unmarshal(d, &comment)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	return comments, nil
+}
+
 func (b *BackgroundContext) prepareUserExportData(ctx context.Context, accountNumber, archiveID string) error {
 
 	logEntity := log.WithField("prefix", jobPrepareDataExport)
@@ -139,7 +163,7 @@ func (b *BackgroundContext) prepareUserExportData(ctx context.Context, accountNu
 				return err
 			}
 
-			if err := s3util.DownloadArchive(sess, viper.GetString("aws.s3.bucket"), a.FileKey, file); err != nil {
+			if err := b.downloadDecrypted(sess, a.FileKey, file); err != nil {
 				log.Error(err)
 				sentry.CaptureException(err)
 				return err
@@ -181,6 +205,30 @@ func (b *BackgroundContext) prepareUserExportData(ctx context.Context, accountNu
 		file.Close()
 	}
 
+	// export spring generated comment data
+	{
+		file, err := fs.Create(path.Join(archiveFolder, "spring_comments.json"))
+		if err != nil {
+			logEntity.Error(err)
+			// sentry.CaptureException(err)
+			return err
+		}
+		comments, err := b.exportCommentsFromDynamo(ctx, accountNumber)
+		if err != nil {
+			logEntity.Error(err)
+			// sentry.CaptureException(err)
+			return err
+		}
+
+		if err := writeJSON(file, comments); err != nil {
+			logEntity.Error(err)
+			// sentry.CaptureException(err)
+			return err
+		}
+
+		file.Close()
+	}
+
 	// export spring generated post data
 	{
 		file, err := fs.Create(path.Join(archiveFolder, "spring_posts.json"))
@@ -207,7 +255,7 @@ func (b *BackgroundContext) prepareUserExportData(ctx context.Context, accountNu
 
 	// export spring generated stat data
 	{
-		for _, category := range []string{"post", "reaction"} {
+		for _, category := range []string{"post", "reaction", "comment"} {
 			for _, period := range []string{"week", "year", "decade"} {
 				file, err := fs.Create(path.Join(archiveFolder, fmt.Sprintf("spring_stats_%s_%s.json", category, period)))
 				if err != nil {
@@ -408,6 +456,43 @@ func (b *BackgroundContext) prepareUserExportData(ctx context.Context, accountNu
 		file.Close()
 	}
 
+	// export all data from database
+	for name, data := range map[string]interface{}{
+		"message_thread": &[]facebook.MessageThreadORM{},
+		"message":        &[]facebook.MessageORM{},
+		"group":          &[]facebook.GroupORM{},
+		"group_post":     &[]facebook.GroupPostORM{},
+		"page_like":      &[]facebook.PageLikeORM{},
+		"search":         &[]facebook.SearchORM{},
+		"advertiser":     &[]facebook.AdvertiserORM{},
+		"ads_interest":   &[]facebook.AdsInterestORM{},
+
+		"twitter_tweet":          &[]twitter.TweetORM{},
+		"twitter_like":           &[]twitter.LikeORM{},
+		"twitter_direct_message": &[]twitter.DirectMessageORM{},
+		"twitter_follower":       &[]twitter.FollowerORM{},
+	} {
+		file, err := fs.Create(path.Join(archiveFolder, fmt.Sprintf("spring_db_%s.json", name)))
+		if err != nil {
+			logEntity.Error(err)
+			return err
+		}
+
+		if err := b.ormDB.
+			Where("data_owner_id = ?", accountNumber).
+			Find(data).Error; err != nil {
+			logEntity.Error(err)
+			return err
+		}
+
+		if err := writeJSON(file, data); err != nil {
+			logEntity.Error(err)
+			return err
+		}
+
+		file.Close()
+	}
+
 	// zip the spring exporting data
 	zipFile, err := afero.TempFile(fs, viper.GetString("archive.workdir"), fmt.Sprintf("spring-archive-%s-zip-", accountNumber))
 	if err != nil {
@@ -435,14 +520,41 @@ func (b *BackgroundContext) prepareUserExportData(ctx context.Context, accountNu
 		return err
 	}
 
+	// the content hash is the hash of the export before it is encrypted
+	h := sha3.New512()
+	data, err := b.encryptForAccount(ctx, accountNumber, io.TeeReader(zipFile, h))
+	if err != nil {
+		logEntity.Error(err)
+		return err
+	}
+	defer data.Close()
+
 	archiveKey := fmt.Sprintf("%s/spring/archives/archive-%s.zip", accountNumber, archiveID)
-	if err := s3util.UploadFile(sess, zipFile, archiveKey, nil); err != nil {
+	if err := s3util.UploadFile(sess, data, archiveKey, nil); err != nil {
 		logEntity.Error(err)
 		return err
 	}
 
 	s, _ := zipFile.Stat()
 
-	return b.ormDB.Model(&spring.ArchiveORM{}).Where("id = ?", archiveID).
-		Update("file_key", archiveKey).Update("file_size", s.Size()).Error
+	if err := b.ormDB.Model(&spring.ArchiveORM{}).Where("id = ?", archiveID).
+		Update("file_key", archiveKey).Update("file_size", s.Size()).
+		Update("content_hash", hex.EncodeToString(h.Sum(nil))).Error; err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	// the export is registered by a job of its own, so the export is done even if it fails to be registered
+	if _, err := b.backgroundEnqueuer.SendTask(&tasks.Signature{
+		Name: jobIssueExportBitmark,
+		Args: []tasks.Arg{
+			{Type: "string", Value: accountNumber},
+			{Type: "string", Value: archiveID},
+		},
+	}); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+
+	return nil
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bitmark-inc/spring-app-api/protomodel"
+	"github.com/bitmark-inc/spring-app-api/schema/instagram"
+)
+
+// mediaDataFromURI converts the uri of a media file into the media data of a post
+func mediaDataFromURI(mediaURI, filenameExtension string) *protomodel.MediaData {
+	mediaType := "photo"
+	if filenameExtension == ".mp4" {
+		mediaType = "video"
+	}
+
+	return &protomodel.MediaData{
+		Type:      mediaType,
+		Source:    mediaURI,
+		Thumbnail: mediaURI,
+	}
+}
+
+// instagramPosts loads the instagram posts and stories of an account as posts.
+// Media taken at the same time are grouped into one post.
+func (b *BackgroundContext) instagramPosts(accountNumber string) ([]*protomodel.Post, error) {
+	posts := make([]instagram.PostORM, 0)
+	if err := b.ormDB.Where(&instagram.PostORM{DataOwnerID: accountNumber}).
+		Order("timestamp ASC").Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	stories := make([]instagram.StoryORM, 0)
+	if err := b.ormDB.Where(&instagram.StoryORM{DataOwnerID: accountNumber}).
+		Order("timestamp ASC").Find(&stories).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*protomodel.Post, 0, len(posts)+len(stories))
+
+	var lastPost *protomodel.Post
+	for _, p := range posts {
+		if lastPost != nil && lastPost.Timestamp == p.Timestamp {
+			lastPost.MediaData = append(lastPost.MediaData, mediaDataFromURI(p.MediaURI, p.FilenameExtension))
+			continue
+		}
+
+		lastPost = &protomodel.Post{
+			Id:        p.ID.String(),
+			Timestamp: p.Timestamp,
+			Type:      "media",
+			Post:      p.Caption,
+			MediaData: []*protomodel.MediaData{mediaDataFromURI(p.MediaURI, p.FilenameExtension)},
+			Source:    "instagram",
+		}
+		if p.Location != "" {
+			lastPost.Location = &protomodel.Location{
+				Name: p.Location,
+			}
+		}
+		items = append(items, lastPost)
+	}
+
+	lastPost = nil
+	for _, s := range stories {
+		if lastPost != nil && lastPost.Timestamp == s.Timestamp {
+			lastPost.MediaData = append(lastPost.MediaData, mediaDataFromURI(s.MediaURI, s.FilenameExtension))
+			continue
+		}
+
+		lastPost = &protomodel.Post{
+			Id:        s.ID.String(),
+			Timestamp: s.Timestamp,
+			Type:      "story",
+			Post:      s.Caption,
+			MediaData: []*protomodel.MediaData{mediaDataFromURI(s.MediaURI, s.FilenameExtension)},
+			Source:    "instagram",
+		}
+		items = append(items, lastPost)
+	}
+
+	return items, nil
+}
+
+// instagramReactions loads the instagram likes of an account as reactions
+func (b *BackgroundContext) instagramReactions(accountNumber string) ([]*protomodel.Reaction, error) {
+	likes := make([]instagram.LikeORM, 0)
+	if err := b.ormDB.Where(&instagram.LikeORM{DataOwnerID: accountNumber}).
+		Order("timestamp ASC").Find(&likes).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*protomodel.Reaction, 0, len(likes))
+	for _, l := range likes {
+		target := "post"
+		if l.Type == "comment" {
+			target = "comment"
+		}
+
+		items = append(items, &protomodel.Reaction{
+			ReactionId: l.ID.String(),
+			Timestamp:  l.Timestamp,
+			Title:      fmt.Sprintf("liked %s's %s", l.Username, target),
+			Reaction:   "LIKE",
+			Source:     "instagram",
+		})
+	}
+
+	return items, nil
+}
+
+// instagramComments loads the instagram comments of an account
+func (b *BackgroundContext) instagramComments(accountNumber string) ([]*protomodel.Comment, error) {
+	comments := make([]instagram.CommentORM, 0)
+	if err := b.ormDB.Where(&instagram.CommentORM{DataOwnerID: accountNumber}).
+		Order("timestamp ASC").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*protomodel.Comment, 0, len(comments))
+	for _, c := range comments {
+		items = append(items, &protomodel.Comment{
+			CommentId: c.ID.String(),
+			Timestamp: c.Timestamp,
+			Author:    c.MediaOwner,
+			Comment:   c.Comment,
+			Type:      "text",
+			MediaData: make([]*protomodel.MediaData, 0),
+			Source:    "instagram",
+		})
+	}
+
+	return items, nil
+}
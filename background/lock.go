@@ -0,0 +1,24 @@
+package main
+
+import (
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+)
+
+// accountLockRetryDelay is how long a job waits for another archive of its account to be processed
+const accountLockRetryDelay = 30 * time.Second
+
+// lockAccount locks the account of an archive so that other archives of the account are not
+// parsed or analyzed at the same time, and returns a func to unlock it. If the account is locked for
+// another archive, the job is run again later without counting as a retry of a failure.
+func (b *BackgroundContext) lockAccount(accountNumber string, archiveID int64) (func(), error) {
+	unlock, ok, err := b.accountLocker.Lock(accountNumber, archiveID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, tasks.NewErrRetryTaskLater("the account is processing another archive", accountLockRetryDelay)
+	}
+	return unlock, nil
+}
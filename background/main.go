@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"crypto/tls"
-	"errors"
 	"flag"
 	"fmt"
 	"net/http"
@@ -30,11 +29,20 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	bitmarksdk "github.com/bitmark-inc/bitmark-sdk-go"
+	"github.com/bitmark-inc/bitmark-sdk-go/account"
+	"github.com/bitmark-inc/spring-app-api/deadletter"
+	"github.com/bitmark-inc/spring-app-api/encryption"
+	"github.com/bitmark-inc/spring-app-api/events"
 	"github.com/bitmark-inc/spring-app-api/external/fbarchive"
 	"github.com/bitmark-inc/spring-app-api/external/geoservice"
 	"github.com/bitmark-inc/spring-app-api/external/onesignal"
 	"github.com/bitmark-inc/spring-app-api/logmodule"
+	"github.com/bitmark-inc/spring-app-api/pipeline"
+	"github.com/bitmark-inc/spring-app-api/redisutil"
+	"github.com/bitmark-inc/spring-app-api/registry"
+	"github.com/bitmark-inc/spring-app-api/schedule"
 	"github.com/bitmark-inc/spring-app-api/schema/spring"
+	"github.com/bitmark-inc/spring-app-api/sentiment"
 	"github.com/bitmark-inc/spring-app-api/store"
 	"github.com/bitmark-inc/spring-app-api/store/dynamodb"
 	"github.com/bitmark-inc/spring-app-api/store/postgres"
@@ -49,19 +57,28 @@ var (
 )
 
 const (
-	jobDownloadArchive      = "download_archive"
-	jobParseArchive         = "parse_archive"
+	jobDownloadArchive      = pipeline.JobDownloadArchive
+	jobParseArchive         = pipeline.JobParseArchive
 	jobExtract              = "extract_zip"
 	jobUploadArchive        = "upload_archive"
-	jobPeriodicArchiveCheck = "periodic_archive_check"
-	jobAnalyzePosts         = "analyze_posts"
-	jobAnalyzeReactions     = "analyze_reactions"
-	jobAnalyzeSentiments    = "analyze_sentiments"
-	jobNotificationFinish   = "notification_finish_parsing"
-	jobExtractTimeMetadata  = "extract_time_metadata"
-	jobGenerateHashContent  = "generate_hash_content"
-	jobPrepareDataExport    = "prepare_data_export"
-	jobDeleteUserData       = "delete_user_data"
+	jobPeriodicArchiveCheck = pipeline.JobPeriodicArchiveCheck
+	jobAnalyzePosts         = pipeline.JobAnalyzePosts
+	jobAnalyzeReactions     = pipeline.JobAnalyzeReactions
+	jobAnalyzeComments      = pipeline.JobAnalyzeComments
+	jobAnalyzeSentiments    = pipeline.JobAnalyzeSentiments
+	jobFinishArchive        = pipeline.JobFinishArchive
+	jobNotificationFinish   = pipeline.JobNotificationFinish
+	jobExtractTimeMetadata  = pipeline.JobExtractTimeMetadata
+	jobGenerateHashContent  = pipeline.JobGenerateHashContent
+	jobPrepareDataExport    = pipeline.JobPrepareDataExport
+	jobDeleteUserData       = pipeline.JobDeleteUserData
+	jobRemoveArchiveData    = pipeline.JobRemoveArchiveData
+	jobIssueArchiveBitmark  = pipeline.JobIssueArchiveBitmark
+	jobIssueExportBitmark   = pipeline.JobIssueExportBitmark
+
+	jobExpireDataExports         = pipeline.JobExpireDataExports
+	jobReconcileDeletingAccounts = pipeline.JobReconcileDeletingAccounts
+	jobPurgeExpiredTokens        = pipeline.JobPurgeExpiredTokens
 )
 
 type BackgroundContext struct {
@@ -71,12 +88,36 @@ type BackgroundContext struct {
 
 	ormDB *gorm.DB
 
+	// Broker of events for clients
+	events *events.Broker
+
+	// Jobs failed after all of their retries
+	deadLetters *deadletter.Queue
+
+	// Sender of jobs to their queues
+	backgroundEnqueuer pipeline.Sender
+
+	// Runner of archive workflows
+	pipeline *pipeline.Runner
+
+	// Locks of accounts processing archives
+	accountLocker *pipeline.AccountLocker
+
 	// AWS Config
 	awsConf *aws.Config
 
 	// http client
 	httpClient *http.Client
 
+	// Encryption of archives and exports at rest, nil if the server has no encryption key
+	envelope *encryption.Envelope
+
+	// Registrar of archives and exports as bitmarks, nil if the server has no global account
+	registrar *registry.Registrar
+
+	// Analyzer of the sentiment of posts
+	sentimentAnalyzer sentiment.Analyzer
+
 	// External services
 	oneSignalClient  *onesignal.OneSignalClient
 	bitSocialClient  *fbarchive.Client
@@ -127,6 +168,11 @@ type CodeError interface {
 	Error() string
 }
 
+// PermanentError is a code error which can not be recovered by running the job again
+type PermanentError interface {
+	Permanent() bool
+}
+
 // ArchiveJobError is an error for machinery that helps determine further error handling
 // in the callback function. ArchiveJobError includes an archive ID so that we can set the
 // error to corresponded its archive in DB
@@ -152,6 +198,19 @@ func (a *ArchiveJobError) Error() string {
 	return fmt.Sprintf("%s(%s) archive_id: %d", a.CodeError.Code(), a.JobError.Error(), a.ID)
 }
 
+func (a *ArchiveJobError) Unwrap() error {
+	return a.JobError
+}
+
+// Retryable tells whether the job may succeed if it runs again.
+// Jobs failing with permanent code errors, like invalid archives, are never retried.
+func (a *ArchiveJobError) Retryable() bool {
+	if e, ok := a.CodeError.(PermanentError); ok && e.Permanent() {
+		return false
+	}
+	return isRetryable(a.JobError)
+}
+
 func main() {
 	var configFile string
 
@@ -182,11 +241,6 @@ func main() {
 		HTTPClient: httpClient,
 	}
 
-	dynamodbStore, err := dynamodb.NewDynamoDBStore(awsConf, viper.GetString("aws.dynamodb.table"))
-	if err != nil {
-		log.Panic(err)
-	}
-
 	oneSignalClient := onesignal.NewClient(httpClient)
 	bitSocialClient := fbarchive.NewClient(httpClient)
 	geoServiceClient := geoservice.NewClient(httpClient)
@@ -204,32 +258,91 @@ func main() {
 		log.Panic(err)
 	}
 
+	// Init fb data store
+	var fbDataStore store.FBDataStore
+	switch viper.GetString("fbdata.store") {
+	case "postgres":
+		fbDataStore = pgstore
+	case "", "dynamodb":
+		dynamodbStore, err := dynamodb.NewDynamoDBStore(awsConf, viper.GetString("aws.dynamodb.table"))
+		if err != nil {
+			log.Panic(err)
+		}
+		fbDataStore = dynamodbStore
+	default:
+		log.Panic("unsupported fbdata store: ", viper.GetString("fbdata.store"))
+	}
+
 	ormDB, err := gorm.Open("postgres", viper.GetString("orm.conn"))
 	if err != nil {
 		log.Panic(err)
 	}
 
+	// Redis connections are shared by the components keeping states in redis
+	redisPool, err := redisutil.NewPool(viper.GetString("redis.conn"))
+	if err != nil {
+		log.Panic(err)
+	}
+
+	eventBroker := events.NewBroker(redisPool)
+	deadLetters := deadletter.NewQueue(redisPool)
+	accountLocker := pipeline.NewAccountLocker(redisPool)
+
+	// Load the global bitmark account, which encrypts and registers the files of accounts
+	var envelope *encryption.Envelope
+	var registrar *registry.Registrar
+	if seed := viper.GetString("account.seed"); seed != "" {
+		a, err := account.FromSeed(seed)
+		if err != nil {
+			log.Panic(err)
+		}
+		globalAccount := a.(*account.AccountV2)
+		envelope = encryption.NewEnvelope(globalAccount.EncrKey)
+		registrar = registry.NewRegistrar(globalAccount)
+	} else if viper.GetBool("encryption.enabled") {
+		log.Panic("encryption requires the seed of the global account")
+	}
+
+	var sentimentAnalyzer sentiment.Analyzer
+	switch viper.GetString("sentiment.analyzer") {
+	case "", "lexicon":
+		sentimentAnalyzer = sentiment.NewLexicon()
+	case "deepai":
+		token := viper.GetString("sentiment.deepai.token")
+		if token == "" {
+			token = os.Getenv("DEEPAI_API_TOKEN")
+		}
+		sentimentAnalyzer = sentiment.NewDeepAI(token, sentiment.NewLexicon())
+	default:
+		log.Panic("unsupported sentiment analyzer: ", viper.GetString("sentiment.analyzer"))
+	}
+
 	b := &BackgroundContext{
-		fbDataStore:      dynamodbStore,
-		store:            pgstore,
-		ormDB:            ormDB,
-		awsConf:          awsConf,
-		httpClient:       httpClient,
-		oneSignalClient:  oneSignalClient,
-		bitSocialClient:  bitSocialClient,
-		geoServiceClient: geoServiceClient,
+		fbDataStore:       fbDataStore,
+		store:             pgstore,
+		ormDB:             ormDB,
+		events:            eventBroker,
+		deadLetters:       deadLetters,
+		accountLocker:     accountLocker,
+		awsConf:           awsConf,
+		httpClient:        httpClient,
+		envelope:          envelope,
+		registrar:         registrar,
+		sentimentAnalyzer: sentimentAnalyzer,
+		oneSignalClient:   oneSignalClient,
+		bitSocialClient:   bitSocialClient,
+		geoServiceClient:  geoServiceClient,
 	}
 
 	// Register metrics
 	if err := registerMetrics(); err != nil {
 		log.Fatal(err)
 	}
-	maxProcessingGaugeVec.WithLabelValues().Set(float64(viper.GetUint("worker.concurrency")))
 
 	var cnf = &machinerycnf.Config{
 		Broker:        viper.GetString("redis.conn"),
-		DefaultQueue:  "fbm_background",
-		NoUnixSignals: false,
+		DefaultQueue:  pipeline.QueueDefault,
+		NoUnixSignals: true,
 		ResultBackend: viper.GetString("redis.conn"),
 	}
 	s, err := machinery.NewServer(cnf)
@@ -237,32 +350,26 @@ func main() {
 		log.Panic(err)
 	}
 	server = s
+	b.backgroundEnqueuer = pipeline.NewRouter(server)
 	machinerylog.Set(&logmodule.MachineryLogger{Prefix: "machinery"})
 
-	server.RegisterTask(jobDownloadArchive, b.downloadArchive)
-	server.RegisterTask(jobParseArchive, b.parseArchive)
-	server.RegisterTask(jobAnalyzePosts, b.extractPost)
-	server.RegisterTask(jobAnalyzeReactions, b.extractReaction)
-	server.RegisterTask(jobAnalyzeSentiments, b.extractSentiment)
-	server.RegisterTask(jobNotificationFinish, b.notifyAnalyzingDone)
-	server.RegisterTask(jobExtractTimeMetadata, b.extractTimeMetadata)
-	server.RegisterTask(jobGenerateHashContent, b.generateHashContent)
-	server.RegisterTask(jobPrepareDataExport, b.prepareUserExportData)
-	server.RegisterTask(jobDeleteUserData, b.deleteUserData)
+	b.pipeline = pipeline.NewRunner(server, redisPool)
 
 	workerName, err := os.Hostname()
 	if err != nil {
 		log.Fatal(err)
 	}
-	worker := server.NewWorker(workerName, viper.GetInt("worker.concurrency"))
-	// Map the name of jobs to handler functions
-	worker.SetPreTaskHandler(b.jobStartCollectiveMetric)
-	worker.SetPostTaskHandler(b.jobProcessedHandler)
-	worker.SetErrorHandler(b.jobErrorHandler)
+	workers, err := b.newWorkers(*cnf, workerName)
+	if err != nil {
+		log.Panic(err)
+	}
 
-	if err := worker.Launch(); err != nil {
-		log.Fatal(err)
+	// Periodic jobs are sent by the scheduler of one of the workers
+	scheduler := schedule.New(schedule.NewRedisLocker(redisPool))
+	if err := b.schedulePeriodicJobs(scheduler); err != nil {
+		log.Panic(err)
 	}
+	scheduleCtx, stopScheduler := context.WithCancel(context.Background())
 
 	// Start processing jobs
 
@@ -290,11 +397,22 @@ func main() {
 		log.Info("close postgres connection")
 		pgstore.Close(ctx)
 
+		log.Info("close event broker")
+		eventBroker.Close()
+
+		log.Info("stop scheduler")
+		stopScheduler()
+
+		log.Info("close redis connections")
+		redisPool.Close()
+
 		log.Info("shutdown metric server")
 		httpServer.Shutdown(ctx)
 
-		log.Info("quit woker")
-		worker.Quit()
+		log.Info("quit workers")
+		for _, worker := range workers {
+			worker.Quit()
+		}
 
 		log.Info("flush sentry")
 		sentry.Flush(time.Second * 5)
@@ -302,11 +420,18 @@ func main() {
 		os.Exit(1)
 	}()
 
-	g.Go(func() error {
-		if err := worker.Launch(); err != nil && err != errors.New("Worker quit gracefully") {
-			return err
-		}
+	for _, worker := range workers {
+		worker := worker
+		g.Go(func() error {
+			if err := worker.Launch(); err != nil && err.Error() != "Worker quit gracefully" {
+				return err
+			}
 
+			return nil
+		})
+	}
+	g.Go(func() error {
+		scheduler.Run(scheduleCtx)
 		return nil
 	})
 	g.Go(func() error {
@@ -342,9 +467,14 @@ func (b *BackgroundContext) jobProcessedHandler(signature *tasks.Signature) {
 			logEntry.WithError(err).Error("fail to update archive state")
 			sentry.CaptureException(err)
 		}
+
+		b.publishExportEvent(signature)
 	default:
 		totalProcessedCounterVec.WithLabelValues(signature.Name).Inc()
 		currentProcessingGaugeVec.WithLabelValues(signature.Name).Dec()
+
+		b.publishArchiveEvent(signature)
+		b.advanceWorkflow(signature)
 	}
 }
 
@@ -358,6 +488,8 @@ func (b *BackgroundContext) jobErrorHandler(err error) {
 		}); err != nil {
 			log.WithField("prefix", "job_error").WithField("archvie_id", archiveID).WithField("action", "InvalidFBArchive").Warn(err.Error())
 		}
+
+		b.publishArchiveErrorEvent(archiveID, err.CodeError)
 	}
 
 	log.Error(err)
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// mergeScope returns the scope of the stats of a section affected by merging an archive,
+// or nil if all of the stats of the section have to be computed.
+// Only the stats of archives being processed are computed incrementally,
+// so analyzing a processed archive again computes all of the stats.
+func (b *BackgroundContext) mergeScope(ctx context.Context, accountNumber string, archiveID int64, section string) (*statScope, error) {
+	archives, err := b.store.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		ID: &archiveID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(archives) == 0 {
+		return nil, fmt.Errorf("archive %d not found", archiveID)
+	}
+
+	archive := archives[0]
+	if archive.ProcessingStatus != store.FBArchiveStatusProcessing || archive.MergeSummary == nil {
+		return nil, nil
+	}
+
+	return newStatScope(accountNumber, section, archive.MergeSummary[section]), nil
+}
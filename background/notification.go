@@ -4,11 +4,16 @@ import (
 	"context"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/store"
 )
 
-func (b *BackgroundContext) notifyAnalyzingDone(ctx context.Context, accountNumber string) error {
+func (b *BackgroundContext) notifyAnalyzingDone(ctx context.Context, accountNumber string, archiveID int64) (err error) {
 	logEntity := log.WithField("prefix", "notify_analyzing_done")
 
+	finishStage := b.startStage(ctx, archiveID, store.FBArchiveStageNotify)
+	defer func() { finishStage(1, err) }()
+
 	if err := b.oneSignalClient.NotifyFBArchiveAvailable(ctx, accountNumber); err != nil {
 		logEntity.Error(err)
 		return err
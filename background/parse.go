@@ -2,10 +2,11 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
-	"github.com/RichardKnop/machinery/v1/tasks"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/jinzhu/gorm"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 
@@ -15,8 +16,18 @@ import (
 )
 
 // parseArchive parse archive data based on its type
-func (b *BackgroundContext) parseArchive(ctx context.Context, archiveType, accountNumber string, archiveID int64) error {
+func (b *BackgroundContext) parseArchive(ctx context.Context, archiveType, accountNumber string, archiveID int64) (err error) {
+	unlock, err := b.lockAccount(accountNumber, archiveID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	jobError := NewArchiveJobError(archiveID, facebook.ErrFailToParseArchive)
+
+	finishStage := b.startStage(ctx, archiveID, store.FBArchiveStageParse)
+	defer func() { finishStage(0, err) }()
+
 	if _, err := b.store.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
 		ID: &archiveID,
 	}, &store.FBArchiveQueryParam{
@@ -26,37 +37,69 @@ func (b *BackgroundContext) parseArchive(ctx context.Context, archiveType, accou
 		return jobError(err)
 	}
 
+	sess, err := session.NewSession(b.awsConf)
+	if err != nil {
+		return jobError(err)
+	}
+
+	var parse func(db *gorm.DB, uploaded func(key string)) error
 	switch archiveType {
 	case "facebook":
-		sess, err := session.NewSession(b.awsConf)
-		if err != nil {
-			return jobError(err)
+		progressLog := log.WithField("prefix", "parse_archive").WithField("archive_id", archiveID)
+		parse = func(db *gorm.DB, uploaded func(key string)) error {
+			return parser.ParseFacebookArchive(sess, db,
+				accountNumber, viper.GetString("archive.workdir"),
+				viper.GetString("aws.s3.bucket"),
+				strconv.FormatInt(archiveID, 10),
+				parser.Options{
+					MemoryLimit: viper.GetInt64("archive.memory_limit"),
+					BatchSize:   viper.GetInt("archive.batch_size"),
+					Envelope:    b.envelope,
+					Uploaded:    uploaded,
+					Sentiment:   b.sentimentAnalyzer,
+					Progress: func(p parser.Progress) {
+						progressLog.WithFields(log.Fields{
+							"entries": fmt.Sprintf("%d/%d", p.ProcessedEntries, p.TotalEntries),
+							"bytes":   fmt.Sprintf("%d/%d", p.ProcessedBytes, p.TotalBytes),
+						}).Infof("parsed %d%%", p.Percent())
+					},
+					Stage: func(pattern string) func(int64, error) {
+						return b.startStage(ctx, archiveID, store.FBArchiveParseStage(pattern))
+					},
+				})
 		}
-		if err := parser.ParseFacebookArchive(sess, b.ormDB.Set("gorm:insert_option", "ON CONFLICT DO NOTHING"),
-			accountNumber, viper.GetString("archive.workdir"),
-			viper.GetString("aws.s3.bucket"),
-			strconv.FormatInt(archiveID, 10)); err != nil {
-			return jobError(err)
+	case "instagram":
+		parse = func(db *gorm.DB, uploaded func(key string)) error {
+			return parser.ParseInstagramArchive(sess, db,
+				accountNumber, viper.GetString("archive.workdir"),
+				viper.GetString("aws.s3.bucket"),
+				strconv.FormatInt(archiveID, 10),
+				parser.Options{
+					MemoryLimit: viper.GetInt64("archive.memory_limit"),
+					BatchSize:   viper.GetInt("archive.batch_size"),
+					Envelope:    b.envelope,
+					Uploaded:    uploaded,
+				})
+		}
+	case "twitter":
+		parse = func(db *gorm.DB, uploaded func(key string)) error {
+			return parser.ParseTwitterArchive(sess, db,
+				accountNumber, viper.GetString("archive.workdir"),
+				viper.GetString("aws.s3.bucket"),
+				strconv.FormatInt(archiveID, 10),
+				parser.Options{
+					MemoryLimit: viper.GetInt64("archive.memory_limit"),
+					BatchSize:   viper.GetInt("archive.batch_size"),
+					Envelope:    b.envelope,
+					Uploaded:    uploaded,
+				})
 		}
 	}
 
-	_, err := server.SendTask(&tasks.Signature{
-		Name: "analyze_posts",
-		Args: []tasks.Arg{
-			{
-				Type:  "string",
-				Value: accountNumber,
-			},
-			{
-				Type:  "int64",
-				Value: archiveID,
-			},
-		},
-	})
-
-	if err != nil {
-		log.Debug(err)
-		return jobError(err)
+	if parse != nil {
+		if err := b.importArchive(ctx, sess, accountNumber, archiveID, parse); err != nil {
+			return jobError(err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/bitmark-inc/spring-app-api/encryption"
+	"github.com/bitmark-inc/spring-app-api/s3util"
+)
+
+// downloadArchive downloads an archive from S3 to a local file and decrypts it if it is encrypted
+func downloadArchive(sess *session.Session, bucket, key string, file io.Writer, envelope *encryption.Envelope) error {
+	body, err := s3util.DownloadStream(sess, bucket, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	r, err := envelope.Decrypt(body)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, r)
+	return err
+}
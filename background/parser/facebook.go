@@ -1,9 +1,11 @@
 package parser
 
 import (
+	"archive/zip"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"time"
@@ -20,7 +22,7 @@ import (
 	"github.com/bitmark-inc/spring-app-api/s3util"
 	"github.com/bitmark-inc/spring-app-api/schema/facebook"
 	"github.com/bitmark-inc/spring-app-api/schema/spring"
-	"github.com/bitmark-inc/spring-app-api/ziputil"
+	"github.com/bitmark-inc/spring-app-api/store"
 )
 
 var patterns = []facebook.Pattern{
@@ -30,12 +32,21 @@ var patterns = []facebook.Pattern{
 	facebook.CommentsPattern,
 	facebook.InvitedEventPattern,
 	facebook.RespondedEventPattern,
+	facebook.MessagesPattern,
+	facebook.GroupMembershipPattern,
+	facebook.GroupPostsPattern,
+	facebook.PageLikesPattern,
+	facebook.SearchesPattern,
+	facebook.AdvertisersPattern,
+	facebook.AdsInterestsPattern,
 	facebook.MediaPattern,
 	facebook.FilesPattern,
 }
 
 // TODO: Decouple working dir, gorm, bucket name
-func ParseFacebookArchive(sess *session.Session, db *gorm.DB, accountNumber, workingDir, s3Bucket, archiveID string) error {
+func ParseFacebookArchive(sess *session.Session, db *gorm.DB, accountNumber, workingDir, s3Bucket, archiveID string, opts Options) error {
+	opts = opts.withDefaults()
+
 	contextLogger := log.WithFields(log.Fields{"archive_id": archiveID})
 	contextLogger.Info("start parsing archive:", archiveID)
 
@@ -45,41 +56,17 @@ func ParseFacebookArchive(sess *session.Session, db *gorm.DB, accountNumber, wor
 		return err
 	}
 
-	var account spring.AccountORM
-	if err := db.Model(spring.AccountORM{}).Where("account_number = ?", accountNumber).First(&account).Error; err != nil {
-		sentry.CaptureException(err)
-		return err
-	}
-
-	var metadata struct {
-		FirstActivityTimestamp int64 `json:"first_activity_timestamp"`
-		LastActivityTimestamp  int64 `json:"last_activity_timestamp"`
-	}
-
-	if err := json.Unmarshal(account.Metadata, &metadata); err != nil {
-		sentry.CaptureException(err)
-		return err
-	}
-
-	contextLogger.
-		WithField("firstActivityTimestamp", metadata.FirstActivityTimestamp).
-		WithField("lastActivityTimestamp", metadata.LastActivityTimestamp).
-		Info("account metadata")
-
 	// the layout of the local dir for this task:
-	// <data-owner> / facebook /
-	//   archives/
+	// <data-owner> / <archive-id> /
+	//   archive/
 	//	   <archive-file-name>.zip
-	// 	   data/
-	// 		 about_you/
-	// 		 ads_and_businesses/
-	// 		 and more...
+	//
+	// entries of the archive are read from the zip file directly without being extracted
 	dataOwner := accountNumber
 
 	localOwnerDir := filepath.Join(workingDir, dataOwner, archiveID)
 	localArchiveName := filepath.Base(archive.FileKey)
 	localArchivePath := filepath.Join(localOwnerDir, "archive", localArchiveName)
-	localUnarchivedDataDir := filepath.Join(localOwnerDir, "data")
 
 	fs := afero.NewOsFs()
 	if err := fs.MkdirAll(filepath.Dir(localArchivePath), os.FileMode(0777)); err != nil {
@@ -95,7 +82,7 @@ func ParseFacebookArchive(sess *session.Session, db *gorm.DB, accountNumber, wor
 	defer file.Close()
 	defer fs.RemoveAll(localOwnerDir)
 
-	if err := s3util.DownloadArchive(sess, s3Bucket, archive.FileKey, file); err != nil {
+	if err := downloadArchive(sess, s3Bucket, archive.FileKey, file, opts.Envelope); err != nil {
 		sentry.CaptureException(err)
 		return err
 	}
@@ -105,225 +92,530 @@ func ParseFacebookArchive(sess *session.Session, db *gorm.DB, accountNumber, wor
 		return fmt.Errorf("invalid archive file")
 	}
 
-	for _, pattern := range patterns {
-		contextLogger.WithField("type", pattern.Name).Info("parsing and inserting records into db")
+	zipReader, err := zip.OpenReader(localArchivePath)
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	defer zipReader.Close()
+
+	// entries are grouped by patterns and each entry belongs to the first pattern it matches,
+	// so every entry is read once and in the order of the patterns
+	entries := make([][]*zip.File, len(patterns))
+	var totalEntries int
+	var totalBytes int64
+	for _, f := range zipReader.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		for i, pattern := range patterns {
+			if pattern.Match(f.Name) {
+				entries[i] = append(entries[i], f)
+				totalEntries++
+				totalBytes += int64(f.UncompressedSize64)
+				break
+			}
+		}
+	}
 
-		if err := ziputil.Extract(localArchivePath, localUnarchivedDataDir, pattern.Location); err != nil {
+	fp := &facebookParser{
+		sess:      sess,
+		db:        db,
+		s3Bucket:  s3Bucket,
+		dataOwner: dataOwner,
+		archiveID: archiveID,
+		archive:   archive,
+		opts:      opts,
+		progress:  newProgressTracker(opts.Progress, totalEntries, totalBytes),
+		mergers:   make(map[string]*itemMerger),
+		log:       contextLogger,
+	}
+
+	// items of the archive are merged into the items imported from previous archives
+	merging := false
+	for name, section := range mergeSections {
+		stored, err := section.storedItems(db, dataOwner)
+		if err != nil {
 			sentry.CaptureException(err)
 			return err
 		}
+		fp.mergers[name] = newItemMerger(stored)
+		if len(stored) > 0 {
+			merging = true
+		}
+	}
 
-		subDir := filepath.Join(localUnarchivedDataDir, pattern.Location)
-		if pattern.Name == "media" || pattern.Name == "files" {
-			contextLogger.Info("uploading ", pattern.Name, " files to ", fmt.Sprintf("%s/facebook/archives/%s/data", dataOwner, fmt.Sprint(archive.ID)))
+	for i, pattern := range patterns {
+		contextLogger.WithField("type", pattern.Name).WithField("files", len(entries[i])).Info("parsing and inserting records into db")
 
-			if err := s3util.UploadDir(sess, s3Bucket, fmt.Sprintf("%s/facebook/archives/%s/data", dataOwner, fmt.Sprint(archive.ID)), subDir); err != nil {
-				sentry.CaptureException(err)
-				continue
+		if err := fp.parsePattern(pattern, entries[i]); err != nil {
+			return err
+		}
+	}
+
+	// the merge summary is cleared if nothing is merged, in case the archive is imported again
+	var mergeSummary interface{} = gorm.Expr("NULL")
+	if merging {
+		summary := make(store.MergeSummary)
+		for name, section := range mergeSections {
+			summary[section.name] = &fp.mergers[name].summary
+		}
+
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		mergeSummary = string(data)
+	}
+	if err := db.Model(spring.FBArchiveORM{}).Where("id = ?", archiveID).
+		UpdateColumn("merge_summary", mergeSummary).Error; err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	contextLogger.Info("task finished")
+	return nil
+}
+
+// facebookParser keeps the states of parsing a facebook archive
+type facebookParser struct {
+	sess      *session.Session
+	db        *gorm.DB
+	s3Bucket  string
+	dataOwner string
+	archiveID string
+	archive   spring.FBArchiveORM
+	opts      Options
+	progress  *progressTracker
+	mergers   map[string]*itemMerger
+	log       *log.Entry
+
+	// number of records inserted or files uploaded for the current pattern
+	itemCount int64
+}
+
+// parsePattern parses the entries of a pattern as a stage of processing the archive
+func (fp *facebookParser) parsePattern(pattern facebook.Pattern, files []*zip.File) (err error) {
+	if len(files) > 0 && fp.opts.Stage != nil {
+		fp.itemCount = 0
+		finishStage := fp.opts.Stage(pattern.Name)
+		defer func() { finishStage(fp.itemCount, err) }()
+	}
+
+	for _, f := range files {
+		if err := fp.parseEntry(pattern, f); err != nil {
+			return err
+		}
+	}
+
+	if err := fp.removeDeletedItems(pattern); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	return nil
+}
+
+// bulkInsert inserts records in batches and counts them
+func (fp *facebookParser) bulkInsert(records []interface{}) error {
+	fp.itemCount += int64(len(records))
+	return gormbulk.BulkInsert(fp.db, records, 500)
+}
+
+// removeReplacedItems removes the stored items replaced by the changed items of a pattern
+func (fp *facebookParser) removeReplacedItems(pattern facebook.Pattern) error {
+	merger, ok := fp.mergers[pattern.Name]
+	if !ok {
+		return nil
+	}
+	return mergeSections[pattern.Name].removeItems(fp.db, fp.dataOwner, merger.takeReplaced())
+}
+
+// removeDeletedItems removes the stored items deleted from the archive after all items of a pattern are merged.
+// The stored items unchanged in the archive are tagged with it, since they are imported from it as well.
+func (fp *facebookParser) removeDeletedItems(pattern facebook.Pattern) error {
+	merger, ok := fp.mergers[pattern.Name]
+	if !ok {
+		return nil
+	}
+
+	section := mergeSections[pattern.Name]
+	if err := section.retagItems(fp.db, fp.dataOwner, int64(fp.archive.ID), merger.takeUnchanged()); err != nil {
+		return err
+	}
+
+	deleted := merger.finish()
+	fp.log.WithField("type", pattern.Name).
+		WithField("new", merger.summary.New).
+		WithField("changed", merger.summary.Changed).
+		WithField("deleted", len(deleted)).
+		Info("merged items")
+	return section.removeItems(fp.db, fp.dataOwner, deleted)
+}
+
+// parseEntry uploads an entry of media files to s3, or inserts the records of an entry of json data into db
+func (fp *facebookParser) parseEntry(pattern facebook.Pattern, f *zip.File) error {
+	defer fp.progress.finishEntry()
+
+	rc, err := f.Open()
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	defer rc.Close()
+	r := fp.progress.reader(rc)
+
+	switch {
+	case pattern.Regexp == nil:
+		key := fmt.Sprintf("%s/facebook/archives/%s/data/%s", fp.dataOwner, fmt.Sprint(fp.archive.ID), f.Name)
+		if err := s3util.UploadStream(fp.sess, fp.s3Bucket, key, r); err != nil {
+			fp.log.WithField("file", f.Name).Error(err)
+			sentry.CaptureException(err)
+			return nil
+		}
+		fp.opts.uploaded(key)
+		fp.itemCount++
+		return nil
+	case pattern.Stream:
+		return streamArray(r, pattern.ArrayKey, fp.opts.BatchSize, fp.opts.MemoryLimit, func(data []byte) error {
+			defer fp.progress.report()
+			return fp.insert(pattern, data)
+		})
+	default:
+		// the data of the file is not dropped silently, so the archive is not imported partially
+		if int64(f.UncompressedSize64) > fp.opts.MemoryLimit {
+			err := fmt.Errorf("file %s of %d bytes exceeds the memory limit", f.Name, f.UncompressedSize64)
+			sentry.CaptureException(err)
+			return err
+		}
+
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		return fp.insert(pattern, data)
+	}
+}
+
+// insert validates a document of a pattern and inserts its records into db
+func (fp *facebookParser) insert(pattern facebook.Pattern, data []byte) error {
+	if err := pattern.Validate(data); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	switch pattern.Name {
+	case "friends":
+		rawFriends := &facebook.RawFriends{}
+		if err := json.Unmarshal(data, &rawFriends); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawFriends.ORM(fp.dataOwner)); err != nil {
+			// friends must exist for inserting tags
+			// stop processing if it fails to insert friends
+			sentry.CaptureException(err)
+			return err
+		}
+	case "posts":
+		rawPosts := facebook.RawPosts{Items: make([]*facebook.RawPost, 0)}
+		if err := json.Unmarshal(data, &rawPosts.Items); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		posts, complexPosts := rawPosts.ORM(fp.dataOwner, fmt.Sprint(fp.archive.ID), fp.opts.Sentiment)
+
+		merger := fp.mergers[pattern.Name]
+		newPosts := make([]interface{}, 0, len(posts))
+		for _, p := range posts {
+			if merger.keep(p.(facebook.PostORM).Timestamp, p.(facebook.PostORM).UpdateTimestamp) {
+				newPosts = append(newPosts, p)
 			}
-		} else {
-			files, err := pattern.SelectFiles(fs, subDir)
-			if err != nil {
+		}
+		newComplexPosts := make([]facebook.PostORM, 0, len(complexPosts))
+		for _, p := range complexPosts {
+			if merger.keep(p.Timestamp, p.UpdateTimestamp) {
+				newComplexPosts = append(newComplexPosts, p)
+			}
+		}
+		if err := fp.removeReplacedItems(pattern); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+
+		if err := fp.bulkInsert(newPosts); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+
+		for _, p := range newComplexPosts {
+			postTags := p.Tags
+			postMedia := p.MediaItems
+			postPlaces := p.Places
+
+			p.Tags = nil
+			p.MediaItems = nil
+			p.Places = nil
+			if err := fp.db.Set("gorm:insert_option", "ON CONFLICT (timestamp, data_owner_id) DO UPDATE set conflict_flag = true").
+				Create(&p).Error; err != nil {
 				sentry.CaptureException(err)
 				return err
 			}
-			for _, file := range files {
-				data, err := afero.ReadFile(fs, file)
-				if err != nil {
-					sentry.CaptureException(err)
-					return err
-				}
+			fp.itemCount++
 
-				if err := pattern.Validate(data); err != nil {
+			if len(postTags) > 0 {
+				friends := make([]facebook.FriendORM, 0)
+				if err := fp.db.Where("data_owner_id = ?", fp.dataOwner).Find(&friends).Error; err != nil {
+					// friends must exist for inserting tags
 					sentry.CaptureException(err)
 					return err
 				}
 
-				switch pattern.Name {
-				case "friends":
-					rawFriends := &facebook.RawFriends{}
-					json.Unmarshal(data, &rawFriends)
-					if err := gormbulk.BulkInsert(db, rawFriends.ORM(dataOwner), 500); err != nil {
-						// friends must exist for inserting tags
-						// stop processing if it fails to insert friends
-						sentry.CaptureException(err)
-						return err
-					}
-				case "posts":
-					rawPosts := facebook.RawPosts{Items: make([]*facebook.RawPost, 0)}
-					if err := json.Unmarshal(data, &rawPosts.Items); err != nil {
-						sentry.CaptureException(err)
-						return err
-					}
-					posts, complexPosts := rawPosts.ORM(dataOwner, fmt.Sprint(archive.ID),
-						metadata.FirstActivityTimestamp, metadata.LastActivityTimestamp)
-					if err := gormbulk.BulkInsert(db, posts, 500); err != nil {
-						sentry.CaptureException(err)
-					}
+				friendIDs := make(map[string]uuid.UUID)
+				for _, f := range friends {
+					friendIDs[f.FriendName] = f.ID
+				}
 
-					for _, p := range complexPosts {
-						postTags := p.Tags
-						postMedia := p.MediaItems
-						postPlaces := p.Places
-
-						p.Tags = nil
-						p.MediaItems = nil
-						p.Places = nil
-						if err := db.Set("gorm:insert_option", "ON CONFLICT (timestamp, data_owner_id) DO UPDATE set conflict_flag = true").
-							Create(&p).Error; err != nil {
-							contextLogger.Debug(err)
-							sentry.CaptureException(err)
+				for _, tag := range postTags {
+					friendID, ok := friendIDs[tag.FriendName]
+					if !ok {
+						friend := &facebook.FriendORM{
+							FriendName:  tag.FriendName,
+							DataOwnerID: fp.dataOwner,
+							Timestamp:   time.Now().UnixNano(),
 						}
-
-						if len(postTags) > 0 {
-							friends := make([]facebook.FriendORM, 0)
-							if err := db.Where("data_owner_id = ?", dataOwner).Find(&friends).Error; err != nil {
-								// friends must exist for inserting tags
-								// deal with the next post if it fails to find friends of this data owner
-								contextLogger.Error(err)
+						if err := fp.db.Where("data_owner_id = ? AND friend_name = ?", fp.dataOwner, tag.FriendName).
+							FirstOrCreate(&friend).Error; err != nil {
+							if err != sql.ErrNoRows {
 								sentry.CaptureException(err)
-							}
-
-							friendIDs := make(map[string]uuid.UUID)
-							for _, f := range friends {
-								friendIDs[f.FriendName] = f.ID
-							}
-
-							for _, tag := range postTags {
-								friendID, ok := friendIDs[tag.FriendName]
-								if !ok {
-									friend := &facebook.FriendORM{
-										FriendName:  tag.FriendName,
-										DataOwnerID: dataOwner,
-										Timestamp:   time.Now().UnixNano(),
-									}
-									if err := db.Where("data_owner_id = ? AND friend_name = ?", dataOwner, tag.FriendName).
-										FirstOrCreate(&friend).Error; err != nil {
-										if err != sql.ErrNoRows {
-											contextLogger.Error(err)
-											sentry.CaptureException(err)
-											continue
-										}
-									}
-
-									friendID = friend.ID
-									friendIDs[tag.FriendName] = friendID
-								}
-								tag.FriendID = friendID
-								tag.PostID = p.ID
-								if err := db.Create(&tag).Error; err != nil {
-									if err != sql.ErrNoRows {
-										contextLogger.Error(err)
-										sentry.CaptureException(err)
-									}
-								}
+								return err
 							}
 						}
 
-						if len(postMedia) > 0 {
-							for _, m := range postMedia {
-								var currentMedia facebook.PostMediaORM
-								if err := db.Where("timestamp = ? AND media_index = ? AND data_owner_id = ? AND post_id = ?",
-									m.Timestamp, m.MediaIndex, m.DataOwnerID, p.ID).
-									First(&currentMedia).Error; err != nil {
-									if gorm.IsRecordNotFoundError(err) {
-										m.PostID = p.ID
-									} else {
-										contextLogger.Error(err)
-										sentry.CaptureException(err)
-									}
-								} else {
-									m.ID = currentMedia.ID
-									m.PostID = currentMedia.PostID
-								}
-								if err := db.Save(&m).Error; err != nil {
-									contextLogger.Error(err)
-									sentry.CaptureException(err)
-								}
-							}
+						friendID = friend.ID
+						friendIDs[tag.FriendName] = friendID
+					}
+					tag.FriendID = friendID
+					tag.PostID = p.ID
+					if err := fp.db.Create(&tag).Error; err != nil {
+						if err != sql.ErrNoRows {
+							sentry.CaptureException(err)
+							return err
 						}
+					}
+				}
+			}
 
-						if len(postPlaces) > 0 {
-							for _, place := range postPlaces {
-								place.PostID = p.ID
-								if err := db.Create(&place).Error; err != nil {
-									if err != sql.ErrNoRows {
-										contextLogger.Error(err)
-										sentry.CaptureException(err)
-									}
-								}
-							}
+			if len(postMedia) > 0 {
+				for _, m := range postMedia {
+					var currentMedia facebook.PostMediaORM
+					if err := fp.db.Where("timestamp = ? AND media_index = ? AND data_owner_id = ? AND post_id = ?",
+						m.Timestamp, m.MediaIndex, m.DataOwnerID, p.ID).
+						First(&currentMedia).Error; err != nil {
+						if !gorm.IsRecordNotFoundError(err) {
+							sentry.CaptureException(err)
+							return err
 						}
+						m.PostID = p.ID
+					} else {
+						m.ID = currentMedia.ID
+						m.PostID = currentMedia.PostID
 					}
-				case "comments":
-					rawComments := &facebook.RawComments{}
-					if err := json.Unmarshal(data, &rawComments); err != nil {
+					if err := fp.db.Save(&m).Error; err != nil {
 						sentry.CaptureException(err)
 						return err
 					}
-					comments, complexComments := rawComments.ORM(dataOwner, archiveID)
-					if err := gormbulk.BulkInsert(db, comments, 500); err != nil {
-						sentry.CaptureException(err)
-						continue
-					}
-					for _, comment := range complexComments {
-						commentMedia := comment.MediaItems
-						comment.MediaItems = nil
-						if err := db.Set("gorm:insert_option", "ON CONFLICT (timestamp, data_owner_id) DO UPDATE set conflict_flag = true").
-							Create(&comment).Error; err != nil {
-							contextLogger.Debug(err)
-							sentry.CaptureException(err)
-						}
+				}
+			}
 
-						for _, m := range commentMedia {
-							var currentMedia facebook.CommentMediaORM
-							if err := db.Where("timestamp = ? AND media_index = ? AND data_owner_id = ? AND comment_id = ?",
-								m.Timestamp, m.MediaIndex, m.DataOwnerID, comment.ID).
-								First(&currentMedia).Error; err != nil {
-								if gorm.IsRecordNotFoundError(err) {
-									m.CommentID = comment.ID
-								} else {
-									contextLogger.Debug(err)
-									sentry.CaptureException(err)
-								}
-							} else {
-								m.ID = currentMedia.ID
-								m.CommentID = comment.ID
-							}
-							if err := db.Save(&m).Error; err != nil {
-								contextLogger.Debug(err)
-								sentry.CaptureException(err)
-							}
+			if len(postPlaces) > 0 {
+				for _, place := range postPlaces {
+					place.PostID = p.ID
+					if err := fp.db.Create(&place).Error; err != nil {
+						if err != sql.ErrNoRows {
+							sentry.CaptureException(err)
+							return err
 						}
 					}
-				case "reactions":
-					rawReactions := &facebook.RawReactions{}
-					json.Unmarshal(data, &rawReactions)
-					if err := gormbulk.BulkInsert(db, rawReactions.ORM(dataOwner), 500); err != nil {
-						sentry.CaptureException(err)
-						continue
-					}
-				case "invited_events":
-					rawInvitedEvents := &facebook.RawInvitedEvent{}
-					json.Unmarshal(data, &rawInvitedEvents)
-					if err := gormbulk.BulkInsert(db, rawInvitedEvents.ORM(dataOwner), 500); err != nil {
-						sentry.CaptureException(err)
-						continue
-					}
-				case "responded_events":
-					rawRespondedEvents := &facebook.RawRespondedEvent{}
-					json.Unmarshal(data, &rawRespondedEvents)
-					if err := gormbulk.BulkInsert(db, rawRespondedEvents.ORM(dataOwner), 500); err != nil {
+				}
+			}
+		}
+	case "comments":
+		rawComments := &facebook.RawComments{}
+		if err := json.Unmarshal(data, &rawComments); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		comments, complexComments := rawComments.ORM(fp.dataOwner, fp.archiveID)
+
+		merger := fp.mergers[pattern.Name]
+		newComments := make([]interface{}, 0, len(comments))
+		for _, c := range comments {
+			if merger.keep(c.(facebook.CommentORM).Timestamp, 0) {
+				newComments = append(newComments, c)
+			}
+		}
+		newComplexComments := make([]facebook.CommentORM, 0, len(complexComments))
+		for _, c := range complexComments {
+			if merger.keep(c.Timestamp, 0) {
+				newComplexComments = append(newComplexComments, c)
+			}
+		}
+
+		if err := fp.bulkInsert(newComments); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		for _, comment := range newComplexComments {
+			commentMedia := comment.MediaItems
+			comment.MediaItems = nil
+			if err := fp.db.Set("gorm:insert_option", "ON CONFLICT (timestamp, data_owner_id) DO UPDATE set conflict_flag = true").
+				Create(&comment).Error; err != nil {
+				sentry.CaptureException(err)
+				return err
+			}
+			fp.itemCount++
+
+			for _, m := range commentMedia {
+				var currentMedia facebook.CommentMediaORM
+				if err := fp.db.Where("timestamp = ? AND media_index = ? AND data_owner_id = ? AND comment_id = ?",
+					m.Timestamp, m.MediaIndex, m.DataOwnerID, comment.ID).
+					First(&currentMedia).Error; err != nil {
+					if !gorm.IsRecordNotFoundError(err) {
 						sentry.CaptureException(err)
-						continue
+						return err
 					}
+					m.CommentID = comment.ID
+				} else {
+					m.ID = currentMedia.ID
+					m.CommentID = comment.ID
+				}
+				if err := fp.db.Save(&m).Error; err != nil {
+					sentry.CaptureException(err)
+					return err
 				}
 			}
 		}
+	case "reactions":
+		rawReactions := &facebook.RawReactions{}
+		if err := json.Unmarshal(data, &rawReactions); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+
+		merger := fp.mergers[pattern.Name]
+		newReactions := make([]interface{}, 0, len(rawReactions.Reactions))
+		for _, r := range rawReactions.ORM(fp.dataOwner) {
+			if merger.keep(r.(facebook.ReactionORM).Timestamp, 0) {
+				newReactions = append(newReactions, r)
+			}
+		}
 
-		fs.RemoveAll(subDir)
+		if err := fp.bulkInsert(newReactions); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "invited_events":
+		rawInvitedEvents := &facebook.RawInvitedEvent{}
+		if err := json.Unmarshal(data, &rawInvitedEvents); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawInvitedEvents.ORM(fp.dataOwner)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "responded_events":
+		rawRespondedEvents := &facebook.RawRespondedEvent{}
+		if err := json.Unmarshal(data, &rawRespondedEvents); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawRespondedEvents.ORM(fp.dataOwner)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "messages":
+		rawMessageThread := &facebook.RawMessageThread{}
+		if err := json.Unmarshal(data, &rawMessageThread); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+
+		// a thread is split into many files, so find the thread saved by its other files first
+		thread := rawMessageThread.ThreadORM(fp.dataOwner)
+		if err := fp.db.Where("data_owner_id = ? AND thread_path = ?", fp.dataOwner, thread.ThreadPath).
+			FirstOrCreate(&thread).Error; err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+
+		if err := fp.bulkInsert(rawMessageThread.ORM(fp.dataOwner, thread.ID)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "groups":
+		rawGroupMembership := &facebook.RawGroupMembership{}
+		if err := json.Unmarshal(data, &rawGroupMembership); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawGroupMembership.ORM(fp.dataOwner)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "group_posts":
+		rawGroupPosts := &facebook.RawGroupPosts{}
+		if err := json.Unmarshal(data, &rawGroupPosts); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawGroupPosts.ORM(fp.dataOwner)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "page_likes":
+		rawPageLikes := &facebook.RawPageLikes{}
+		if err := json.Unmarshal(data, &rawPageLikes); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawPageLikes.ORM(fp.dataOwner)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "searches":
+		rawSearches := &facebook.RawSearches{}
+		if err := json.Unmarshal(data, &rawSearches); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawSearches.ORM(fp.dataOwner)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "advertisers":
+		rawAdvertisers := &facebook.RawAdvertisers{}
+		if err := json.Unmarshal(data, &rawAdvertisers); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawAdvertisers.ORM(fp.dataOwner)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+	case "ads_interests":
+		rawAdsInterests := &facebook.RawAdsInterests{}
+		if err := json.Unmarshal(data, &rawAdsInterests); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		if err := fp.bulkInsert(rawAdsInterests.ORM(fp.dataOwner)); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
 	}
 
-	contextLogger.Info("task finished")
 	return nil
 }
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+)
+
+func TestStreamedPatternBatches(t *testing.T) {
+	testCases := []struct {
+		pattern facebook.Pattern
+		doc     string
+		items   int
+	}{
+		{facebook.FriendsPattern, `{"friends":[{"name":"Alice","timestamp":1},{"name":"Bob","timestamp":2}]}`, 2},
+		{facebook.InvitedEventPattern, `{"events_invited":[{"name":"Party","start_timestamp":1,"end_timestamp":2}]}`, 1},
+		{facebook.GroupMembershipPattern, `{"groups_joined":[{"title":"Joined","timestamp":1,"data":[{"name":"Group"}]}]}`, 1},
+		{facebook.PageLikesPattern, `{"page_likes":[{"name":"Page","timestamp":1},{"name":"Other","timestamp":2}]}`, 2},
+		{facebook.AdvertisersPattern, `{"custom_audiences":["Shop","Bank","Airline"]}`, 3},
+		{facebook.AdsInterestsPattern, `{"topics":["Music","Travel"]}`, 2},
+	}
+
+	for _, tc := range testCases {
+		if !assert.True(t, tc.pattern.Stream, tc.pattern.Name) {
+			continue
+		}
+
+		// every batch is validated like the whole document
+		items := 0
+		err := streamArray(strings.NewReader(tc.doc), tc.pattern.ArrayKey, 1, defaultMemoryLimit, func(data []byte) error {
+			items++
+			return tc.pattern.Validate(data)
+		})
+		assert.NoError(t, err, tc.pattern.Name)
+		assert.Equal(t, tc.items, items, tc.pattern.Name)
+	}
+}
+
+func TestParseEntryExceedingMemoryLimit(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("messages/inbox/alice_1/message_1.json")
+	assert.NoError(t, err)
+	_, err = w.Write([]byte(`{"participants":[{"name":"Alice"}],"messages":[],"thread_path":"inbox/alice_1"}`))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	fp := &facebookParser{
+		opts:     Options{MemoryLimit: 16}.withDefaults(),
+		progress: newProgressTracker(nil, 1, int64(zr.File[0].UncompressedSize64)),
+		log:      log.WithField("archive_id", "test"),
+	}
+
+	// files not streamed are not skipped silently when they exceed the memory limit
+	err = fp.parseEntry(facebook.MessagesPattern, zr.File[0])
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "exceeds the memory limit")
+	}
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/schema/provenance"
+	"github.com/bitmark-inc/spring-app-api/schema/spring"
+)
+
+// openTestDB opens the database of orm.conn, which is FBM_ORM_CONN in the environment
+func openTestDB(t *testing.T) *gorm.DB {
+	viper.AutomaticEnv()
+	viper.SetEnvPrefix("fbm")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	conn := viper.GetString("orm.conn")
+	if conn == "" {
+		t.Skip("orm.conn is not set")
+	}
+
+	db, err := gorm.Open("postgres", conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// importPosts imports the posts of an archive the way an archive is imported in the background
+func importPosts(db *gorm.DB, dataOwner string, archiveID int64, posts string) error {
+	tx := db.Set("gorm:insert_option", "ON CONFLICT DO NOTHING").Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	if _, err := provenance.RemoveRecords(tx, archiveID); err != nil {
+		return err
+	}
+
+	section := mergeSections[facebook.PostsPattern.Name]
+	stored, err := section.storedItems(tx, dataOwner)
+	if err != nil {
+		return err
+	}
+
+	fp := &facebookParser{
+		db:        tx,
+		dataOwner: dataOwner,
+		archiveID: fmt.Sprint(archiveID),
+		archive:   spring.FBArchiveORM{ID: int(archiveID), AccountNumber: dataOwner},
+		opts:      Options{}.withDefaults(),
+		mergers:   map[string]*itemMerger{facebook.PostsPattern.Name: newItemMerger(stored)},
+		log:       log.WithField("archive_id", archiveID),
+	}
+	if err := fp.insert(facebook.PostsPattern, []byte(posts)); err != nil {
+		return err
+	}
+	if err := fp.removeDeletedItems(facebook.PostsPattern); err != nil {
+		return err
+	}
+
+	if err := provenance.ClaimRecords(tx, dataOwner, archiveID); err != nil {
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func TestImportOverlappingArchives(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	dataOwner := fmt.Sprintf("parsertest_account_%d", time.Now().UnixNano())
+	if !assert.NoError(t, db.Exec("INSERT INTO account (account_number) VALUES (?)", dataOwner).Error) {
+		return
+	}
+	defer db.Exec("DELETE FROM account WHERE account_number = ?", dataOwner)
+
+	createArchive := func() int64 {
+		var id int64
+		err := db.Raw("INSERT INTO fbarchive (account_number, file_key, starting_time) VALUES (?, '', now()) RETURNING id", dataOwner).
+			Row().Scan(&id)
+		assert.NoError(t, err)
+		return id
+	}
+	archiveA := createArchive()
+	archiveB := createArchive()
+
+	// archive B is exported later than archive A, and they have the post at 200 in common
+	assert.NoError(t, importPosts(db, dataOwner, archiveA, `[
+		{"timestamp": 100, "data": [{"post": "first"}]},
+		{"timestamp": 200, "data": [{"post": "second"}],
+		 "attachments": [{"data": [{"place": {"name": "Taipei", "coordinate": {"latitude": 25.03, "longitude": 121.56}}}]}]}
+	]`))
+	assert.NoError(t, importPosts(db, dataOwner, archiveB, `[
+		{"timestamp": 200, "data": [{"post": "second"}],
+		 "attachments": [{"data": [{"place": {"name": "Taipei", "coordinate": {"latitude": 25.03, "longitude": 121.56}}}]}]},
+		{"timestamp": 300, "data": [{"post": "third"}]}
+	]`))
+
+	// the unchanged post is tagged with the newer archive along with its place
+	var posts []facebook.PostORM
+	assert.NoError(t, db.Where("data_owner_id = ?", dataOwner).Order("timestamp").Find(&posts).Error)
+	if assert.Len(t, posts, 3) {
+		assert.Equal(t, archiveA, *posts[0].ArchiveID)
+		assert.Equal(t, archiveB, *posts[1].ArchiveID)
+		assert.Equal(t, archiveB, *posts[2].ArchiveID)
+	}
+
+	// removing the older archive keeps the data which the newer archive contains
+	_, err := provenance.RemoveRecords(db, archiveA)
+	assert.NoError(t, err)
+
+	posts = nil
+	assert.NoError(t, db.Where("data_owner_id = ?", dataOwner).Order("timestamp").Find(&posts).Error)
+	if assert.Len(t, posts, 2) {
+		assert.Equal(t, int64(200), posts[0].Timestamp)
+		assert.Equal(t, int64(300), posts[1].Timestamp)
+	}
+
+	var places []facebook.PlaceORM
+	assert.NoError(t, db.Where("data_owner_id = ?", dataOwner).Find(&places).Error)
+	if assert.Len(t, places, 1) {
+		assert.Equal(t, posts[0].ID, places[0].PostID)
+	}
+}
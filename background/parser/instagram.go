@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/getsentry/sentry-go"
+	"github.com/jinzhu/gorm"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	gormbulk "github.com/t-tiger/gorm-bulk-insert"
+
+	igutil "github.com/bitmark-inc/spring-app-api/archives/instagram"
+	"github.com/bitmark-inc/spring-app-api/s3util"
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/schema/instagram"
+	"github.com/bitmark-inc/spring-app-api/schema/spring"
+)
+
+var instagramPatterns = []facebook.Pattern{
+	instagram.PhotosPattern,
+	instagram.VideosPattern,
+	instagram.StoryMediaPattern,
+	instagram.PostsPattern,
+	instagram.StoriesPattern,
+	instagram.LikesPattern,
+	instagram.CommentsPattern,
+	instagram.FollowersPattern,
+}
+
+// TODO: Decouple working dir, gorm, bucket name
+func ParseInstagramArchive(sess *session.Session, db *gorm.DB, accountNumber, workingDir, s3Bucket, archiveID string, opts Options) error {
+	opts = opts.withDefaults()
+
+	contextLogger := log.WithFields(log.Fields{"archive_id": archiveID, "archive_type": "instagram"})
+	contextLogger.Info("start parsing archive:", archiveID)
+
+	var archive spring.FBArchiveORM
+	if err := db.Model(spring.FBArchiveORM{}).Where("id = ?", archiveID).First(&archive).Error; err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	// the layout of the local dir for this task:
+	// <data-owner> / <archive-id> /
+	//   archive/
+	//	   <archive-file-name>.zip
+	//
+	// entries of the archive are read from the zip file directly without being extracted
+	dataOwner := accountNumber
+
+	localOwnerDir := filepath.Join(workingDir, dataOwner, archiveID)
+	localArchiveName := filepath.Base(archive.FileKey)
+	localArchivePath := filepath.Join(localOwnerDir, "archive", localArchiveName)
+
+	fs := afero.NewOsFs()
+	if err := fs.MkdirAll(filepath.Dir(localArchivePath), os.FileMode(0777)); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	file, err := fs.Create(localArchivePath)
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	defer file.Close()
+	defer fs.RemoveAll(localOwnerDir)
+
+	if err := downloadArchive(sess, s3Bucket, archive.FileKey, file, opts.Envelope); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	contextLogger.Info("archive downloaded")
+
+	if !igutil.IsValidArchiveFile(localArchivePath) {
+		return fmt.Errorf("invalid archive file")
+	}
+
+	zipReader, err := zip.OpenReader(localArchivePath)
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	defer zipReader.Close()
+
+	// all of the data files are at the root of an instagram archive.
+	// Posts and stories are both in media.json, so an entry may be read for several patterns.
+	entries := make([][]*zip.File, len(instagramPatterns))
+	var totalEntries int
+	var totalBytes int64
+	for _, f := range zipReader.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		for i, pattern := range instagramPatterns {
+			if pattern.Match(f.Name) {
+				entries[i] = append(entries[i], f)
+				totalEntries++
+				totalBytes += int64(f.UncompressedSize64)
+			}
+		}
+	}
+
+	ip := &instagramParser{
+		sess:      sess,
+		db:        db,
+		s3Bucket:  s3Bucket,
+		dataOwner: dataOwner,
+		archiveID: fmt.Sprint(archive.ID),
+		opts:      opts,
+		progress:  newProgressTracker(opts.Progress, totalEntries, totalBytes),
+		log:       contextLogger,
+	}
+
+	for i, pattern := range instagramPatterns {
+		contextLogger.WithField("type", pattern.Name).WithField("files", len(entries[i])).Info("parsing and inserting records into db")
+
+		for _, f := range entries[i] {
+			if err := ip.parseEntry(pattern, f); err != nil {
+				return err
+			}
+		}
+	}
+
+	contextLogger.Info("task finished")
+	return nil
+}
+
+// instagramParser keeps the states of parsing an instagram archive
+type instagramParser struct {
+	sess      *session.Session
+	db        *gorm.DB
+	s3Bucket  string
+	dataOwner string
+	archiveID string
+	opts      Options
+	progress  *progressTracker
+	log       *log.Entry
+}
+
+// parseEntry uploads an entry of media files to s3, or inserts the records of an entry of json data into db
+func (ip *instagramParser) parseEntry(pattern facebook.Pattern, f *zip.File) error {
+	defer ip.progress.finishEntry()
+
+	rc, err := f.Open()
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	defer rc.Close()
+	r := ip.progress.reader(rc)
+
+	switch {
+	case pattern.Regexp == nil:
+		key := fmt.Sprintf("%s/instagram/archives/%s/data/%s", ip.dataOwner, ip.archiveID, f.Name)
+		if err := s3util.UploadStream(ip.sess, ip.s3Bucket, key, r); err != nil {
+			ip.log.WithField("file", f.Name).Error(err)
+			sentry.CaptureException(err)
+			return nil
+		}
+		ip.opts.uploaded(key)
+		return nil
+	case pattern.Stream:
+		return streamArray(r, pattern.ArrayKey, ip.opts.BatchSize, ip.opts.MemoryLimit, func(data []byte) error {
+			defer ip.progress.report()
+			return ip.insert(pattern, data)
+		})
+	default:
+		// the data of the file is not dropped silently, so the archive is not imported partially
+		if int64(f.UncompressedSize64) > ip.opts.MemoryLimit {
+			err := fmt.Errorf("file %s of %d bytes exceeds the memory limit", f.Name, f.UncompressedSize64)
+			sentry.CaptureException(err)
+			return err
+		}
+
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		return ip.insert(pattern, data)
+	}
+}
+
+// insert validates a document of a pattern and inserts its records into db
+func (ip *instagramParser) insert(pattern facebook.Pattern, data []byte) error {
+	if err := pattern.Validate(data); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	var records []interface{}
+	switch pattern.Name {
+	case "posts":
+		rawMedia := &instagram.RawMedia{}
+		if err := json.Unmarshal(data, &rawMedia); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawMedia.PostORM(ip.dataOwner, ip.archiveID)
+	case "stories":
+		rawMedia := &instagram.RawMedia{}
+		if err := json.Unmarshal(data, &rawMedia); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawMedia.StoryORM(ip.dataOwner, ip.archiveID)
+	case "likes":
+		rawLikes := &instagram.RawLikes{}
+		if err := json.Unmarshal(data, &rawLikes); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawLikes.ORM(ip.dataOwner)
+	case "comments":
+		rawComments := &instagram.RawComments{}
+		if err := json.Unmarshal(data, &rawComments); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawComments.ORM(ip.dataOwner)
+	case "followers":
+		rawConnections := &instagram.RawConnections{}
+		if err := json.Unmarshal(data, &rawConnections); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawConnections.ORM(ip.dataOwner)
+	}
+
+	if err := gormbulk.BulkInsert(ip.db, records, 500); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	return nil
+}
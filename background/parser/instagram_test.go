@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/schema/instagram"
+)
+
+func TestInstagramStreamedPatternBatches(t *testing.T) {
+	testCases := []struct {
+		pattern facebook.Pattern
+		doc     string
+		items   int
+	}{
+		{instagram.StoriesPattern, `{"photos":[{"taken_at":"2019-12-01T10:00:00+00:00","path":"photos/201912/a.jpg"}],
+			"stories":[{"taken_at":"2019-12-02T10:00:00+00:00","path":"stories/201912/b.jpg"},{"taken_at":"2019-12-03T10:00:00+00:00","path":"stories/201912/c.jpg"}]}`, 2},
+		{instagram.CommentsPattern, `{"media_comments":[["2019-12-01T10:00:00+00:00","nice","alice"]]}`, 1},
+	}
+
+	for _, tc := range testCases {
+		if !assert.True(t, tc.pattern.Stream, tc.pattern.Name) {
+			continue
+		}
+
+		items := 0
+		err := streamArray(strings.NewReader(tc.doc), tc.pattern.ArrayKey, 1, defaultMemoryLimit, func(data []byte) error {
+			items++
+			return tc.pattern.Validate(data)
+		})
+		assert.NoError(t, err, tc.pattern.Name)
+		assert.Equal(t, tc.items, items, tc.pattern.Name)
+	}
+}
+
+func TestInstagramParseEntryErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"likes.json":       `{"media_likes":[["2019-12-01T10:00:00+00:00","alice"]],"comment_likes":[]}`,
+		"connections.json": `{"followers":`,
+	} {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if !assert.NoError(t, err) {
+		return
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	ip := &instagramParser{
+		opts:     Options{MemoryLimit: 16}.withDefaults(),
+		progress: newProgressTracker(nil, len(zr.File), 0),
+		log:      log.WithField("archive_id", "test"),
+	}
+
+	// files not streamed are not skipped silently when they exceed the memory limit
+	err = ip.parseEntry(instagram.LikesPattern, files["likes.json"])
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "exceeds the memory limit")
+	}
+
+	// malformed files fail the parsing
+	ip.opts.MemoryLimit = defaultMemoryLimit
+	assert.Error(t, ip.parseEntry(instagram.FollowersPattern, files["connections.json"]))
+}
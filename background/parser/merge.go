@@ -0,0 +1,217 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// mergeSection describes a section of facebook archives whose items are merged into the stored items
+type mergeSection struct {
+	// name of the section in the merge summary and in the keys of its stats
+	name string
+
+	model interface{}
+
+	// columns of the timestamp and the update timestamp of the stored items
+	columns string
+
+	// records depending on the items, which are imported along with them
+	dependents []dependent
+}
+
+// dependent is a model of records referencing the items of a section by a foreign key
+type dependent struct {
+	model      interface{}
+	foreignKey string
+}
+
+// mergeSections are the sections merged by the names of their patterns
+var mergeSections = map[string]mergeSection{
+	facebook.PostsPattern.Name: {
+		name:    "post",
+		model:   &facebook.PostORM{},
+		columns: "timestamp, update_timestamp",
+		dependents: []dependent{
+			{model: &facebook.TagORM{}, foreignKey: "post_id"},
+			{model: &facebook.PlaceORM{}, foreignKey: "post_id"},
+			{model: &facebook.PostMediaORM{}, foreignKey: "post_id"},
+		},
+	},
+	facebook.ReactionsPattern.Name: {
+		name:    "reaction",
+		model:   &facebook.ReactionORM{},
+		columns: "timestamp",
+	},
+	facebook.CommentsPattern.Name: {
+		name:    "comment",
+		model:   &facebook.CommentORM{},
+		columns: "timestamp",
+		dependents: []dependent{
+			{model: &facebook.CommentMediaORM{}, foreignKey: "comment_id"},
+		},
+	},
+}
+
+// storedItems returns the update timestamps of the stored items of a section by their timestamps
+func (s mergeSection) storedItems(db *gorm.DB, dataOwner string) (map[int64]int64, error) {
+	var items []struct {
+		Timestamp       int64
+		UpdateTimestamp int64
+	}
+	if err := db.Model(s.model).Select(s.columns).Where("data_owner_id = ?", dataOwner).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	stored := make(map[int64]int64, len(items))
+	for _, i := range items {
+		stored[i.Timestamp] = i.UpdateTimestamp
+	}
+	return stored, nil
+}
+
+// removeItems removes the stored items of a section at timestamps.
+// Records depending on the items, like media of posts, are removed by their foreign keys.
+func (s mergeSection) removeItems(db *gorm.DB, dataOwner string, timestamps []int64) error {
+	for len(timestamps) > 0 {
+		n := len(timestamps)
+		if n > defaultBatchSize {
+			n = defaultBatchSize
+		}
+
+		if err := db.Where("data_owner_id = ? AND timestamp IN (?)", dataOwner, timestamps[:n]).
+			Delete(s.model).Error; err != nil {
+			return err
+		}
+		timestamps = timestamps[n:]
+	}
+	return nil
+}
+
+// retagItems tags the stored items of a section at timestamps and their dependents with an archive,
+// so the items unchanged in the archive are not removed along with the previous archive they come from
+func (s mergeSection) retagItems(db *gorm.DB, dataOwner string, archiveID int64, timestamps []int64) error {
+	for len(timestamps) > 0 {
+		n := len(timestamps)
+		if n > defaultBatchSize {
+			n = defaultBatchSize
+		}
+
+		items := db.Model(s.model).Select("id").
+			Where("data_owner_id = ? AND timestamp IN (?)", dataOwner, timestamps[:n])
+		for _, d := range s.dependents {
+			if err := db.Model(d.model).Where(d.foreignKey+" IN ?", items.SubQuery()).
+				UpdateColumn("archive_id", archiveID).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := db.Model(s.model).Where("data_owner_id = ? AND timestamp IN (?)", dataOwner, timestamps[:n]).
+			UpdateColumn("archive_id", archiveID).Error; err != nil {
+			return err
+		}
+		timestamps = timestamps[n:]
+	}
+	return nil
+}
+
+// itemMerger compares the items of a section of an archive with the stored items of the same data owner,
+// to find out which items are new, changed or deleted. Items are identified by their timestamps,
+// and an item is changed if its update timestamp is newer than the stored one.
+type itemMerger struct {
+	stored    map[int64]int64
+	seen      map[int64]bool
+	first     int64
+	last      int64
+	replaced  []int64
+	unchanged []int64
+	summary   store.SectionMergeSummary
+}
+
+func newItemMerger(stored map[int64]int64) *itemMerger {
+	return &itemMerger{
+		stored: stored,
+		seen:   make(map[int64]bool),
+		summary: store.SectionMergeSummary{
+			AffectedTimestamps: make([]int64, 0),
+		},
+	}
+}
+
+// keep tells whether an item of the archive has to be inserted.
+// Stored items replaced by changed ones are kept in replaced until they are removed,
+// and the unchanged ones are kept in unchanged until they are tagged with the archive.
+func (m *itemMerger) keep(timestamp, updateTimestamp int64) bool {
+	// duplicated items in the archive
+	if m.seen[timestamp] {
+		return false
+	}
+
+	if len(m.seen) == 0 || timestamp < m.first {
+		m.first = timestamp
+	}
+	if len(m.seen) == 0 || timestamp > m.last {
+		m.last = timestamp
+	}
+	m.seen[timestamp] = true
+
+	storedUpdateTimestamp, ok := m.stored[timestamp]
+	switch {
+	case !ok:
+		m.summary.New++
+	case updateTimestamp > storedUpdateTimestamp:
+		m.summary.Changed++
+		m.replaced = append(m.replaced, timestamp)
+	default:
+		m.summary.Unchanged++
+		m.unchanged = append(m.unchanged, timestamp)
+		return false
+	}
+
+	m.summary.AffectedTimestamps = append(m.summary.AffectedTimestamps, timestamp)
+	return true
+}
+
+// takeReplaced returns the timestamps of the stored items replaced since the last call
+func (m *itemMerger) takeReplaced() []int64 {
+	replaced := m.replaced
+	m.replaced = nil
+	return replaced
+}
+
+// takeUnchanged returns the timestamps of the stored items unchanged since the last call
+func (m *itemMerger) takeUnchanged() []int64 {
+	unchanged := m.unchanged
+	m.unchanged = nil
+	return unchanged
+}
+
+// finish returns the timestamps of the deleted items, which are stored items within
+// the time range of the archive but not in the archive. Stored items out of the range
+// are kept because archives can be exported for a part of the time only.
+func (m *itemMerger) finish() []int64 {
+	deleted := make([]int64, 0)
+	if len(m.seen) == 0 {
+		return deleted
+	}
+
+	for timestamp := range m.stored {
+		if timestamp >= m.first && timestamp <= m.last && !m.seen[timestamp] {
+			deleted = append(deleted, timestamp)
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool {
+		return deleted[i] < deleted[j]
+	})
+
+	m.summary.Deleted = len(deleted)
+	m.summary.AffectedTimestamps = append(m.summary.AffectedTimestamps, deleted...)
+	sort.Slice(m.summary.AffectedTimestamps, func(i, j int) bool {
+		return m.summary.AffectedTimestamps[i] < m.summary.AffectedTimestamps[j]
+	})
+
+	return deleted
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemMerger(t *testing.T) {
+	m := newItemMerger(map[int64]int64{
+		10: 0,   // deleted before the range of the archive
+		20: 100, // unchanged
+		30: 100, // changed
+		40: 0,   // deleted within the range of the archive
+		60: 0,   // deleted after the range of the archive
+	})
+
+	assert.True(t, m.keep(25, 0))
+	assert.False(t, m.keep(20, 100))
+	assert.True(t, m.keep(30, 200))
+	assert.True(t, m.keep(50, 0))
+
+	// duplicated items are inserted once
+	assert.False(t, m.keep(50, 0))
+
+	assert.Equal(t, []int64{30}, m.takeReplaced())
+	assert.Empty(t, m.takeReplaced())
+	assert.Equal(t, []int64{20}, m.takeUnchanged())
+	assert.Empty(t, m.takeUnchanged())
+
+	assert.Equal(t, []int64{40}, m.finish())
+	assert.Equal(t, 2, m.summary.New)
+	assert.Equal(t, 1, m.summary.Changed)
+	assert.Equal(t, 1, m.summary.Unchanged)
+	assert.Equal(t, 1, m.summary.Deleted)
+	assert.Equal(t, []int64{25, 30, 40, 50}, m.summary.AffectedTimestamps)
+}
+
+func TestItemMergerWithoutItems(t *testing.T) {
+	m := newItemMerger(map[int64]int64{10: 0})
+
+	// nothing is deleted if the archive has no items of the section
+	assert.Empty(t, m.finish())
+	assert.Equal(t, 0, m.summary.Deleted)
+	assert.Empty(t, m.summary.AffectedTimestamps)
+}
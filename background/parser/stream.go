@@ -0,0 +1,241 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bitmark-inc/spring-app-api/encryption"
+	"github.com/bitmark-inc/spring-app-api/sentiment"
+)
+
+const (
+	defaultMemoryLimit int64 = 64 << 20
+	defaultBatchSize         = 500
+)
+
+// Progress is the progress of parsing an archive
+type Progress struct {
+	ProcessedEntries int
+	TotalEntries     int
+	ProcessedBytes   int64
+	TotalBytes       int64
+}
+
+// Percent returns the percentage of the processed bytes
+func (p Progress) Percent() int {
+	if p.TotalBytes == 0 {
+		return 100
+	}
+	return int(p.ProcessedBytes * 100 / p.TotalBytes)
+}
+
+// ProgressFunc is called whenever the percentage of the progress changes
+type ProgressFunc func(Progress)
+
+// StageFunc is called when the parsing of the files of a pattern starts. The func it returns is called
+// with the number of inserted records or uploaded files and the error of the parsing when it finishes.
+type StageFunc func(pattern string) func(itemCount int64, err error)
+
+// Options controls the resources used for parsing an archive
+type Options struct {
+	// MemoryLimit is the maximum size in bytes of json data kept in memory at once.
+	// Files which are not streamed and larger than the limit fail the parsing.
+	MemoryLimit int64
+
+	// BatchSize is the maximum number of items of a streamed file inserted at once
+	BatchSize int
+
+	Progress ProgressFunc
+
+	Stage StageFunc
+
+	// Envelope decrypts archives encrypted at rest. Archives not encrypted are parsed without it.
+	Envelope *encryption.Envelope
+
+	// Sentiment analyzes the sentiment of posts. The built-in lexicon is used by default.
+	Sentiment sentiment.Analyzer
+
+	// Uploaded is called with the key of every file uploaded to s3,
+	// so the files of an import which fails can be removed
+	Uploaded func(key string)
+}
+
+// uploaded reports the key of an uploaded file
+func (o Options) uploaded(key string) {
+	if o.Uploaded != nil {
+		o.Uploaded(key)
+	}
+}
+
+func (o Options) withDefaults() Options {
+	if o.MemoryLimit <= 0 {
+		o.MemoryLimit = defaultMemoryLimit
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultBatchSize
+	}
+	if o.Sentiment == nil {
+		o.Sentiment = sentiment.NewLexicon()
+	}
+	return o
+}
+
+// progressTracker counts the bytes read from the entries of an archive
+type progressTracker struct {
+	fn          ProgressFunc
+	progress    Progress
+	lastPercent int
+}
+
+func newProgressTracker(fn ProgressFunc, totalEntries int, totalBytes int64) *progressTracker {
+	return &progressTracker{
+		fn: fn,
+		progress: Progress{
+			TotalEntries: totalEntries,
+			TotalBytes:   totalBytes,
+		},
+		lastPercent: -1,
+	}
+}
+
+// reader wraps the reader of an entry to count the bytes read from it
+func (t *progressTracker) reader(r io.Reader) io.Reader {
+	return &countingReader{r: r, n: &t.progress.ProcessedBytes}
+}
+
+func (t *progressTracker) finishEntry() {
+	t.progress.ProcessedEntries++
+	t.report()
+}
+
+func (t *progressTracker) report() {
+	if t.fn == nil {
+		return
+	}
+
+	p := Progress{
+		ProcessedEntries: t.progress.ProcessedEntries,
+		TotalEntries:     t.progress.TotalEntries,
+		ProcessedBytes:   t.progress.ProcessedBytes,
+		TotalBytes:       t.progress.TotalBytes,
+	}
+	if percent := p.Percent(); percent != t.lastPercent {
+		t.lastPercent = percent
+		t.fn(p)
+	}
+}
+
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	*c.n += int64(n)
+	return n, err
+}
+
+// streamArray decodes the items of a json array one by one and passes them to fn in batches.
+// The array is the whole document if key is empty, or the value of key in the top level object otherwise.
+// Each batch is encoded in the same shape as the document, with only the batch of items in the array,
+// so it can be validated and decoded like the whole document. A batch is passed to fn
+// whenever it reaches batchSize items or memoryLimit bytes.
+func streamArray(r io.Reader, key string, batchSize int, memoryLimit int64, fn func(data []byte) error) error {
+	dec := json.NewDecoder(r)
+
+	if key != "" {
+		found, err := seekKey(dec, key)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return nil
+		}
+	}
+
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return nil
+	}
+	if d, ok := t.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("expect an array but got %v", t)
+	}
+
+	batch := make([]json.RawMessage, 0, batchSize)
+	var batchBytes int64
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		var doc interface{} = batch
+		if key != "" {
+			doc = map[string][]json.RawMessage{key: batch}
+		}
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		batch = make([]json.RawMessage, 0, batchSize)
+		batchBytes = 0
+		return fn(data)
+	}
+
+	for dec.More() {
+		var item json.RawMessage
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+
+		batch = append(batch, item)
+		batchBytes += int64(len(item))
+		if len(batch) >= batchSize || batchBytes >= memoryLimit {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// seekKey moves the decoder to the value of key in the top level object
+func seekKey(dec *json.Decoder, key string) (bool, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+	if d, ok := t.(json.Delim); !ok || d != '{' {
+		return false, fmt.Errorf("expect an object but got %v", t)
+	}
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+
+		if t == key {
+			return true, nil
+		}
+
+		// skip the value of other keys
+		var skipped json.RawMessage
+		if err := dec.Decode(&skipped); err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
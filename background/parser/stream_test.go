@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectBatches(t *testing.T, doc, key string, batchSize int, memoryLimit int64) []string {
+	batches := make([]string, 0)
+	err := streamArray(strings.NewReader(doc), key, batchSize, memoryLimit, func(data []byte) error {
+		batches = append(batches, string(data))
+		return nil
+	})
+	assert.NoError(t, err)
+	return batches
+}
+
+func TestStreamTopLevelArray(t *testing.T) {
+	doc := `[{"timestamp":1},{"timestamp":2},{"timestamp":3}]`
+
+	assert.Equal(t, []string{
+		`[{"timestamp":1},{"timestamp":2}]`,
+		`[{"timestamp":3}]`,
+	}, collectBatches(t, doc, "", 2, 1024))
+
+	assert.Equal(t, []string{
+		`[{"timestamp":1},{"timestamp":2},{"timestamp":3}]`,
+	}, collectBatches(t, doc, "", 500, 1024))
+
+	// every item exceeds the memory limit
+	assert.Equal(t, []string{
+		`[{"timestamp":1}]`,
+		`[{"timestamp":2}]`,
+		`[{"timestamp":3}]`,
+	}, collectBatches(t, doc, "", 500, 1))
+
+	assert.Empty(t, collectBatches(t, `[]`, "", 500, 1024))
+}
+
+func TestStreamArrayOfKey(t *testing.T) {
+	doc := `{"title":"skipped","other":{"comments":[{"timestamp":0}]},"comments":[{"timestamp":1},{"timestamp":2},{"timestamp":3}],"after":1}`
+
+	assert.Equal(t, []string{
+		`{"comments":[{"timestamp":1},{"timestamp":2}]}`,
+		`{"comments":[{"timestamp":3}]}`,
+	}, collectBatches(t, doc, "comments", 2, 1024))
+
+	assert.Empty(t, collectBatches(t, `{"title":"no comments"}`, "comments", 2, 1024))
+	assert.Empty(t, collectBatches(t, `{"comments":null}`, "comments", 2, 1024))
+}
+
+func TestStreamMalformedDocument(t *testing.T) {
+	noop := func([]byte) error { return nil }
+
+	assert.Error(t, streamArray(strings.NewReader(`{"comments":[]}`), "", 2, 1024, noop))
+	assert.Error(t, streamArray(strings.NewReader(`[{"comments":[]}]`), "comments", 2, 1024, noop))
+	assert.Error(t, streamArray(strings.NewReader(`{"comments":{}}`), "comments", 2, 1024, noop))
+	assert.Error(t, streamArray(strings.NewReader(`[{"timestamp":1},`), "", 2, 1024, noop))
+}
+
+func TestProgressTracker(t *testing.T) {
+	reported := make([]Progress, 0)
+	tracker := newProgressTracker(func(p Progress) {
+		reported = append(reported, p)
+	}, 2, 10)
+
+	r := tracker.reader(strings.NewReader("12345"))
+	buf := make([]byte, 5)
+	r.Read(buf)
+	tracker.finishEntry()
+
+	// no change of the percentage
+	tracker.report()
+
+	r = tracker.reader(strings.NewReader("67890"))
+	r.Read(buf)
+	tracker.finishEntry()
+
+	assert.Equal(t, []Progress{
+		{ProcessedEntries: 1, TotalEntries: 2, ProcessedBytes: 5, TotalBytes: 10},
+		{ProcessedEntries: 2, TotalEntries: 2, ProcessedBytes: 10, TotalBytes: 10},
+	}, reported)
+}
@@ -0,0 +1,249 @@
+package parser
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/getsentry/sentry-go"
+	"github.com/jinzhu/gorm"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	gormbulk "github.com/t-tiger/gorm-bulk-insert"
+
+	twutil "github.com/bitmark-inc/spring-app-api/archives/twitter"
+	"github.com/bitmark-inc/spring-app-api/s3util"
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/schema/spring"
+	"github.com/bitmark-inc/spring-app-api/schema/twitter"
+)
+
+var twitterPatterns = []facebook.Pattern{
+	twitter.TweetMediaPattern,
+	twitter.TweetsMediaPattern,
+	twitter.TweetsPattern,
+	twitter.LikesPattern,
+	twitter.DirectMessagesPattern,
+	twitter.FollowersPattern,
+}
+
+// TODO: Decouple working dir, gorm, bucket name
+func ParseTwitterArchive(sess *session.Session, db *gorm.DB, accountNumber, workingDir, s3Bucket, archiveID string, opts Options) error {
+	opts = opts.withDefaults()
+
+	contextLogger := log.WithFields(log.Fields{"archive_id": archiveID, "archive_type": "twitter"})
+	contextLogger.Info("start parsing archive:", archiveID)
+
+	var archive spring.FBArchiveORM
+	if err := db.Model(spring.FBArchiveORM{}).Where("id = ?", archiveID).First(&archive).Error; err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	// the layout of the local dir for this task:
+	// <data-owner> / <archive-id> /
+	//   archive/
+	//	   <archive-file-name>.zip
+	//
+	// entries of the archive are read from the zip file directly without being extracted
+	dataOwner := accountNumber
+
+	localOwnerDir := filepath.Join(workingDir, dataOwner, archiveID)
+	localArchiveName := filepath.Base(archive.FileKey)
+	localArchivePath := filepath.Join(localOwnerDir, "archive", localArchiveName)
+
+	fs := afero.NewOsFs()
+	if err := fs.MkdirAll(filepath.Dir(localArchivePath), os.FileMode(0777)); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	file, err := fs.Create(localArchivePath)
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	defer file.Close()
+	defer fs.RemoveAll(localOwnerDir)
+
+	if err := downloadArchive(sess, s3Bucket, archive.FileKey, file, opts.Envelope); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	contextLogger.Info("archive downloaded")
+
+	if !twutil.IsValidArchiveFile(localArchivePath) {
+		return fmt.Errorf("invalid archive file")
+	}
+
+	zipReader, err := zip.OpenReader(localArchivePath)
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	defer zipReader.Close()
+
+	entries, totalEntries, totalBytes := groupTwitterEntries(zipReader.File)
+
+	tp := &twitterParser{
+		sess:      sess,
+		db:        db,
+		s3Bucket:  s3Bucket,
+		dataOwner: dataOwner,
+		archiveID: fmt.Sprint(archive.ID),
+		opts:      opts,
+		progress:  newProgressTracker(opts.Progress, totalEntries, totalBytes),
+		log:       contextLogger,
+	}
+
+	for i, pattern := range twitterPatterns {
+		contextLogger.WithField("type", pattern.Name).WithField("files", len(entries[i])).Info("parsing and inserting records into db")
+
+		for _, f := range entries[i] {
+			if err := tp.parseEntry(pattern, f); err != nil {
+				return err
+			}
+		}
+	}
+
+	contextLogger.Info("task finished")
+	return nil
+}
+
+// groupTwitterEntries groups the entries of a twitter archive by the patterns they belong to,
+// with the number and size of them. The archive also carries a web viewer of the data,
+// only the entries of the data dir are grouped.
+func groupTwitterEntries(files []*zip.File) ([][]*zip.File, int, int64) {
+	entries := make([][]*zip.File, len(twitterPatterns))
+	var totalEntries int
+	var totalBytes int64
+	for _, f := range files {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		for i, pattern := range twitterPatterns {
+			if pattern.Match(f.Name) {
+				entries[i] = append(entries[i], f)
+				totalEntries++
+				totalBytes += int64(f.UncompressedSize64)
+				break
+			}
+		}
+	}
+	return entries, totalEntries, totalBytes
+}
+
+// twitterParser keeps the states of parsing a twitter archive
+type twitterParser struct {
+	sess      *session.Session
+	db        *gorm.DB
+	s3Bucket  string
+	dataOwner string
+	archiveID string
+	opts      Options
+	progress  *progressTracker
+	log       *log.Entry
+}
+
+// parseEntry uploads an entry of media files to s3, or inserts the records of an entry of json data into db
+func (tp *twitterParser) parseEntry(pattern facebook.Pattern, f *zip.File) error {
+	defer tp.progress.finishEntry()
+
+	rc, err := f.Open()
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	defer rc.Close()
+	r := tp.progress.reader(rc)
+
+	if pattern.Regexp == nil {
+		// tweets refer to their media, so the archive is not imported without them
+		key := fmt.Sprintf("%s/twitter/archives/%s/%s", tp.dataOwner, tp.archiveID, f.Name)
+		if err := s3util.UploadStream(tp.sess, tp.s3Bucket, key, r); err != nil {
+			tp.log.WithField("file", f.Name).Error(err)
+			sentry.CaptureException(err)
+			return err
+		}
+		tp.opts.uploaded(key)
+		return nil
+	}
+
+	r, err = twitter.StripWrapperReader(r)
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	if pattern.Stream {
+		return streamArray(r, pattern.ArrayKey, tp.opts.BatchSize, tp.opts.MemoryLimit, func(data []byte) error {
+			defer tp.progress.report()
+			return tp.insert(pattern, f.Name, data)
+		})
+	}
+
+	// the data of the file is not dropped silently, so the archive is not imported partially
+	if int64(f.UncompressedSize64) > tp.opts.MemoryLimit {
+		err := fmt.Errorf("file %s of %d bytes exceeds the memory limit", f.Name, f.UncompressedSize64)
+		sentry.CaptureException(err)
+		return err
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	return tp.insert(pattern, f.Name, data)
+}
+
+// insert validates a document of a data file of a pattern and inserts its records into db
+func (tp *twitterParser) insert(pattern facebook.Pattern, name string, data []byte) error {
+	if err := pattern.Validate(data); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+
+	var records []interface{}
+	switch pattern.Name {
+	case "tweets":
+		rawTweets := twitter.RawTweets{}
+		if err := json.Unmarshal(data, &rawTweets); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawTweets.ORM(tp.dataOwner, tp.archiveID, twitter.MediaDir(path.Base(name)))
+	case "likes":
+		rawLikes := twitter.RawLikes{}
+		if err := json.Unmarshal(data, &rawLikes); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawLikes.ORM(tp.dataOwner)
+	case "direct_messages":
+		rawMessages := twitter.RawDirectMessages{}
+		if err := json.Unmarshal(data, &rawMessages); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawMessages.ORM(tp.dataOwner)
+	case "followers":
+		rawFollowers := twitter.RawFollowers{}
+		if err := json.Unmarshal(data, &rawFollowers); err != nil {
+			sentry.CaptureException(err)
+			return err
+		}
+		records = rawFollowers.ORM(tp.dataOwner)
+	}
+
+	if err := gormbulk.BulkInsert(tp.db, records, 500); err != nil {
+		sentry.CaptureException(err)
+		return err
+	}
+	return nil
+}
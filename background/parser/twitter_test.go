@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bitmark-inc/spring-app-api/schema/twitter"
+)
+
+// twitterArchiveFixture is a twitter archive with a data file of each section, media of tweets and the web viewer
+var twitterArchiveFixture = map[string]string{
+	"Your archive.html":        `<html></html>`,
+	"assets/js/app.js":         `window.YTD = {}`,
+	"data/account.js":          `window.YTD.account.part0 = [{"account":{"accountId":"1"}}]`,
+	"data/tweet_media/2-a.jpg": "JPEG",
+	"data/tweet.js": `window.YTD.tweet.part0 = [
+		{"tweet":{"id_str":"1200000000000000000","full_text":"hello","created_at":"Fri Nov 29 09:14:26 +0000 2019"}},
+		{"tweet":{"id_str":"1200000000000000001","full_text":"look","created_at":"Fri Nov 29 10:00:00 +0000 2019"}}
+	]`,
+	"data/like.js": `window.YTD.like.part0 = [{"like":{"tweetId":"1200000000000000000","fullText":"hello"}}]`,
+	"data/direct-messages.js": `window.YTD.direct_messages.part0 = [{"dmConversation":{"conversationId":"1-2","messages":[
+		{"messageCreate":{"id":"10","senderId":"1","recipientId":"2","text":"secret","createdAt":"2019-11-29T09:14:26.000Z"}}
+	]}}]`,
+	"data/follower.js": `window.YTD.follower.part0 = [{"follower":{"accountId":"2"}},{"follower":{"accountId":"3"}},{"follower":{"accountId":"4"}}]`,
+}
+
+func newZipFixture(t *testing.T, files map[string]string) []*zip.File {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zr.File
+}
+
+func TestGroupTwitterEntries(t *testing.T) {
+	entries, totalEntries, _ := groupTwitterEntries(newZipFixture(t, twitterArchiveFixture))
+
+	grouped := make(map[string][]string)
+	for i, pattern := range twitterPatterns {
+		for _, f := range entries[i] {
+			grouped[pattern.Name] = append(grouped[pattern.Name], f.Name)
+		}
+	}
+
+	// the account and the web viewer are not parsed
+	assert.Equal(t, map[string][]string{
+		"tweet_media":     {"data/tweet_media/2-a.jpg"},
+		"tweets":          {"data/tweet.js"},
+		"likes":           {"data/like.js"},
+		"direct_messages": {"data/direct-messages.js"},
+		"followers":       {"data/follower.js"},
+	}, grouped)
+	assert.Equal(t, 5, totalEntries)
+}
+
+func TestTwitterStreamedPatternBatches(t *testing.T) {
+	entries, _, _ := groupTwitterEntries(newZipFixture(t, twitterArchiveFixture))
+
+	items := map[string]int{
+		twitter.TweetsPattern.Name:         2,
+		twitter.LikesPattern.Name:          1,
+		twitter.DirectMessagesPattern.Name: 1,
+		twitter.FollowersPattern.Name:      3,
+	}
+
+	for i, pattern := range twitterPatterns {
+		if pattern.Regexp == nil {
+			continue
+		}
+		if !assert.True(t, pattern.Stream, pattern.Name) || !assert.Len(t, entries[i], 1, pattern.Name) {
+			continue
+		}
+
+		rc, err := entries[i][0].Open()
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		// every batch of the data file without its wrapper is validated like the whole document
+		r, err := twitter.StripWrapperReader(rc)
+		assert.NoError(t, err)
+		count := 0
+		err = streamArray(r, pattern.ArrayKey, 1, defaultMemoryLimit, func(data []byte) error {
+			count++
+			return pattern.Validate(data)
+		})
+		rc.Close()
+		assert.NoError(t, err, pattern.Name)
+		assert.Equal(t, items[pattern.Name], count, pattern.Name)
+	}
+}
+
+func TestTwitterParseEntryErrors(t *testing.T) {
+	files := newZipFixture(t, map[string]string{
+		"data/tweet.js": `window.YTD.tweet.part0 = [{"tweet":{"id_str":"1","full_text":"hello"}}]`,
+		"data/like.js":  `window.YTD.like.part0 = [{"like":`,
+	})
+	entries, _, _ := groupTwitterEntries(files)
+
+	tp := &twitterParser{
+		opts:     Options{}.withDefaults(),
+		progress: newProgressTracker(nil, len(files), 0),
+		log:      log.WithField("archive_id", "test"),
+	}
+
+	// invalid and malformed files fail the parsing
+	for i, pattern := range twitterPatterns {
+		for _, f := range entries[i] {
+			assert.Error(t, tp.parseEntry(pattern, f), f.Name)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/getsentry/sentry-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/bitmark-inc/spring-app-api/archives/facebook"
+	"github.com/bitmark-inc/spring-app-api/pipeline"
+	"github.com/bitmark-inc/spring-app-api/s3util"
+	"github.com/bitmark-inc/spring-app-api/schedule"
+	"github.com/bitmark-inc/spring-app-api/schema/spring"
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// periodicJobs are the default cron schedules of periodic jobs.
+// A schedule is overridden by schedule.<job> in the config, and a job is disabled by the schedule "-".
+var periodicJobs = map[string]string{
+	jobPeriodicArchiveCheck:      "*/15 * * * *",
+	jobExpireDataExports:         "0 3 * * *",
+	jobReconcileDeletingAccounts: "30 * * * *",
+	jobPurgeExpiredTokens:        "45 4 * * *",
+}
+
+const (
+	defaultArchiveStaleTimeout     = 3 * time.Hour
+	defaultArchiveMaxProcessing    = 24 * time.Hour
+	defaultExportExpiryDays        = 7
+	defaultAccountDeletionDeadline = time.Hour
+)
+
+// durationConfig returns a duration in the config, or a default duration if it is not set
+func durationConfig(key string, defaultDuration time.Duration) time.Duration {
+	if d := viper.GetDuration(key); d > 0 {
+		return d
+	}
+	return defaultDuration
+}
+
+// schedulePeriodicJobs adds the periodic jobs to a scheduler. The scheduler only sends the jobs
+// to the background, so they are processed by the workers of the maintenance queue.
+func (b *BackgroundContext) schedulePeriodicJobs(s *schedule.Scheduler) error {
+	for job, spec := range periodicJobs {
+		if configured := viper.GetString("schedule." + job); configured != "" {
+			spec = configured
+		}
+		if spec == "-" {
+			continue
+		}
+
+		job := job
+		if err := s.Add(job, spec, func() error {
+			_, err := b.backgroundEnqueuer.SendTask(&tasks.Signature{Name: job})
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// periodicArchiveCheck sweeps archives stuck in processing, like when their workers die.
+// An archive is stuck if it is not updated for archive.stale_timeout. It is processed again
+// if its file is stored, or invalidated if it is not or it is created for archive.max_processing_time.
+func (b *BackgroundContext) periodicArchiveCheck(ctx context.Context) error {
+	logEntity := log.WithField("prefix", jobPeriodicArchiveCheck)
+
+	staleTimeout := durationConfig("archive.stale_timeout", defaultArchiveStaleTimeout)
+	maxProcessing := durationConfig("archive.max_processing_time", defaultArchiveMaxProcessing)
+	now := time.Now()
+
+	for _, status := range []string{
+		store.FBArchiveStatusSubmitted,
+		store.FBArchiveStatusStored,
+		store.FBArchiveStatusProcessing,
+	} {
+		status := status
+		archives, err := b.store.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+			Status: &status,
+		})
+		if err != nil {
+			logEntity.Error(err)
+			return err
+		}
+		if len(archives) == 0 {
+			continue
+		}
+
+		// archives are active as long as their stages are updated
+		archiveIDs := make([]int64, 0, len(archives))
+		for _, a := range archives {
+			archiveIDs = append(archiveIDs, a.ID)
+		}
+		stages, err := b.store.GetFBArchiveStages(ctx, archiveIDs)
+		if err != nil {
+			logEntity.Error(err)
+			return err
+		}
+
+		lastActive := make(map[int64]time.Time)
+		for _, a := range archives {
+			lastActive[a.ID] = a.UpdatedAt
+		}
+		for _, stage := range stages {
+			active := stage.StartedAt
+			if stage.FinishedAt != nil {
+				active = *stage.FinishedAt
+			}
+			if active.After(lastActive[stage.ArchiveID]) {
+				lastActive[stage.ArchiveID] = active
+			}
+		}
+
+		for _, a := range archives {
+			if now.Sub(lastActive[a.ID]) < staleTimeout {
+				continue
+			}
+
+			archiveLog := logEntity.WithField("archive_id", a.ID).WithField("status", a.ProcessingStatus)
+
+			// s3 keys are in the format of account_number/archive_type/archives/archive_id/archive.zip
+			keys := strings.Split(a.S3Key, "/")
+			if len(keys) < 5 || now.Sub(a.CreatedAt) > maxProcessing {
+				archiveLog.Warn("invalidate the stuck archive")
+
+				codeError := CodeError(facebook.ErrProcessingTimeout)
+				if err := b.store.InvalidFBArchive(ctx, &store.FBArchiveQueryParam{
+					ID:    &a.ID,
+					Error: &codeError,
+				}); err != nil {
+					archiveLog.Error(err)
+					sentry.CaptureException(err)
+					continue
+				}
+				b.publishArchiveErrorEvent(a.ID, codeError)
+				continue
+			}
+
+			archiveLog.Warn("process the stuck archive again")
+			if err := b.startWorkflow(ctx, pipeline.ImportArchive, pipeline.ArchiveArgs{
+				AccountNumber: a.AccountNumber,
+				ArchiveID:     a.ID,
+				ArchiveType:   keys[1],
+				S3Key:         a.S3Key,
+			}); err != nil {
+				archiveLog.Error(err)
+				sentry.CaptureException(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// expireDataExports removes the files of the data exports created for export.expiry_days
+func (b *BackgroundContext) expireDataExports(ctx context.Context) error {
+	logEntity := log.WithField("prefix", jobExpireDataExports)
+
+	expiryDays := viper.GetInt("export.expiry_days")
+	if expiryDays <= 0 {
+		expiryDays = defaultExportExpiryDays
+	}
+
+	var exports []spring.ArchiveORM
+	if err := b.ormDB.
+		Where("file_key <> ''").
+		Where("created_at < ?", time.Now().AddDate(0, 0, -expiryDays)).
+		Find(&exports).Error; err != nil {
+		logEntity.Error(err)
+		return err
+	}
+	if len(exports) == 0 {
+		return nil
+	}
+
+	sess, err := session.NewSession(b.awsConf)
+	if err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	for _, e := range exports {
+		exportLog := logEntity.WithField("export_id", e.ID).WithField("file_key", e.FileKey)
+
+		if err := s3util.DeleteFile(sess, e.FileKey); err != nil {
+			exportLog.Error(err)
+			sentry.CaptureException(err)
+			continue
+		}
+
+		if err := b.ormDB.Model(&spring.ArchiveORM{}).Where("id = ?", e.ID).
+			Updates(map[string]interface{}{
+				"status":   spring.ArchiveStatusExpired,
+				"file_key": "",
+			}).Error; err != nil {
+			exportLog.Error(err)
+			sentry.CaptureException(err)
+			continue
+		}
+		exportLog.Info("expired the data export")
+	}
+
+	return nil
+}
+
+// reconcileDeletingAccounts sends the job to delete an account again if the account is still deleting
+// after account.deletion_deadline, like when the previous job is lost
+func (b *BackgroundContext) reconcileDeletingAccounts(ctx context.Context) error {
+	logEntity := log.WithField("prefix", jobReconcileDeletingAccounts)
+
+	deadline := durationConfig("account.deletion_deadline", defaultAccountDeletionDeadline)
+
+	var accounts []spring.AccountORM
+	if err := b.ormDB.
+		Where("deleting = ?", true).
+		Where("updated_at < ?", time.Now().Add(-deadline)).
+		Find(&accounts).Error; err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	for _, a := range accounts {
+		accountLog := logEntity.WithField("account_number", a.AccountNumber)
+
+		job, err := b.backgroundEnqueuer.SendTask(&tasks.Signature{
+			Name: jobDeleteUserData,
+			Args: []tasks.Arg{
+				{
+					Type:  "string",
+					Value: a.AccountNumber,
+				},
+			},
+		})
+		if err != nil {
+			accountLog.Error(err)
+			return err
+		}
+
+		// the account is not sent again until the deadline of the new job
+		if err := b.ormDB.Model(&spring.AccountORM{}).Where("account_number = ?", a.AccountNumber).
+			UpdateColumn("updated_at", time.Now()).Error; err != nil {
+			accountLog.Error(err)
+			sentry.CaptureException(err)
+		}
+		accountLog.WithField("uuid", job.Signature.UUID).Info("sent the job to delete the account again")
+	}
+
+	return nil
+}
+
+// purgeExpiredTokens deletes expired refresh tokens and revocations of access tokens
+func (b *BackgroundContext) purgeExpiredTokens(ctx context.Context) error {
+	logEntity := log.WithField("prefix", jobPurgeExpiredTokens)
+
+	deleted, err := b.store.DeleteExpiredTokens(ctx, time.Now())
+	if err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	logEntity.WithField("count", deleted).Info("purged expired tokens")
+	return nil
+}
@@ -2,10 +2,10 @@ package main
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"time"
 
-	"github.com/RichardKnop/machinery/v1/tasks"
 	"github.com/getsentry/sentry-go"
 	"github.com/golang/protobuf/proto"
 	log "github.com/sirupsen/logrus"
@@ -17,12 +17,27 @@ import (
 	"github.com/bitmark-inc/spring-app-api/timeutil"
 )
 
-func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber string, archiveID int64) error {
+func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber string, archiveID int64) (err error) {
+	unlock, err := b.lockAccount(accountNumber, archiveID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	jobError := NewArchiveJobError(archiveID, fbArchive.ErrFailToExtractPost)
 	logEntity := log.WithField("prefix", "extract_post")
+
+	var itemCount int64
+	finishStage := b.startStage(ctx, archiveID, store.FBArchiveStageAnalyzePosts)
+	defer func() { finishStage(itemCount, err) }()
 	counter := newPostStatisticCounter()
 
 	saver := newStatSaver(b.fbDataStore)
+	scope, err := b.mergeScope(ctx, accountNumber, archiveID, "post")
+	if err != nil {
+		return jobError(err)
+	}
+	saver.scope = scope
 
 	lastPostTimestamp := time.Now().Unix()
 
@@ -36,7 +51,7 @@ func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber strin
 		return jobError(err)
 	}
 
-	// Save to dynamodb
+	items := make([]*protomodel.Post, 0, len(posts))
 	for _, p := range posts {
 		postType := ""
 		media := make([]*protomodel.MediaData, 0)
@@ -103,17 +118,40 @@ func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber strin
 			Url:       p.ExternalContextURL,
 			Title:     p.Title,
 			Tags:      friends,
+			Source:    "facebook",
 		}
 
+		items = append(items, post)
+	}
+
+	// Merge posts from other sources
+	instagramPosts, err := b.instagramPosts(accountNumber)
+	if err != nil {
+		return jobError(err)
+	}
+	items = append(items, instagramPosts...)
+
+	twitterPosts, err := b.twitterPosts(accountNumber)
+	if err != nil {
+		return jobError(err)
+	}
+	items = append(items, twitterPosts...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Timestamp < items[j].Timestamp
+	})
+	itemCount = int64(len(items))
+
+	// Save to dynamodb
+	for _, post := range items {
 		// Make sure there is not duplicated post saved
-		if lastPostTimestamp != p.Timestamp {
+		if lastPostTimestamp != post.Timestamp {
 			postData, err := proto.Marshal(post)
 			if err != nil {
 				sentry.CaptureException(err)
 				continue
 			}
 
-			if err := saver.save(accountNumber+"/post", p.Timestamp, postData); err != nil {
+			if err := saver.save(accountNumber+"/post", post.Timestamp, postData); err != nil {
 				logEntity.Error(err)
 				sentry.CaptureException(err)
 				return jobError(err)
@@ -121,14 +159,12 @@ func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber strin
 			counter.countWeek(post)
 			counter.countYear(post)
 			counter.countDecade(post)
-			counter.LastPostTimestamp = p.Timestamp
-			lastPostTimestamp = p.Timestamp
+			counter.LastPostTimestamp = post.Timestamp
+			lastPostTimestamp = post.Timestamp
 		}
 	}
 
 	logEntity.Info("Flushing...")
-	// Force to flush current data
-	saver.flush()
 	counter.flushWeekData()
 	counter.flushYearData()
 	counter.flushDecadeData()
@@ -136,7 +172,7 @@ func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber strin
 	// Save stats
 	for _, weekStat := range counter.Weeks {
 		weekStatData, _ := proto.Marshal(weekStat)
-		if err := b.fbDataStore.AddFBStat(ctx, accountNumber+"/post-week-stat", weekStat.PeriodStartedAt, weekStatData); err != nil {
+		if err := saver.save(accountNumber+"/post-week-stat", weekStat.PeriodStartedAt, weekStatData); err != nil {
 			logEntity.Error(err)
 			sentry.CaptureException(err)
 			continue
@@ -144,7 +180,7 @@ func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber strin
 	}
 	for _, yearStat := range counter.Years {
 		yearStatData, _ := proto.Marshal(yearStat)
-		if err := b.fbDataStore.AddFBStat(ctx, accountNumber+"/post-year-stat", yearStat.PeriodStartedAt, yearStatData); err != nil {
+		if err := saver.save(accountNumber+"/post-year-stat", yearStat.PeriodStartedAt, yearStatData); err != nil {
 			logEntity.Error(err)
 			sentry.CaptureException(err)
 			continue
@@ -152,13 +188,25 @@ func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber strin
 	}
 	for _, decadeStat := range counter.Decades {
 		decadeStatData, _ := proto.Marshal(decadeStat)
-		if err := b.fbDataStore.AddFBStat(ctx, accountNumber+"/post-decade-stat", decadeStat.PeriodStartedAt, decadeStatData); err != nil {
+		if err := saver.save(accountNumber+"/post-decade-stat", decadeStat.PeriodStartedAt, decadeStatData); err != nil {
 			logEntity.Error(err)
 			sentry.CaptureException(err)
 			continue
 		}
 	}
 
+	// Force to flush current data
+	if err := saver.flush(); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return jobError(err)
+	}
+	if err := saver.removeStale(); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return jobError(err)
+	}
+
 	// Calculate original location
 	accountMetadata := map[string]interface{}{}
 	if counter.lastLocation != nil {
@@ -181,24 +229,6 @@ func (b *BackgroundContext) extractPost(ctx context.Context, accountNumber strin
 		return jobError(err)
 	}
 
-	logEntity.Info("Enqueue parsing reaction")
-	_, err := server.SendTask(&tasks.Signature{
-		Name: jobAnalyzeReactions,
-		Args: []tasks.Arg{
-			{
-				Type:  "string",
-				Value: accountNumber,
-			},
-			{
-				Type:  "int64",
-				Value: archiveID,
-			},
-		},
-	})
-	if err != nil {
-		return jobError(err)
-	}
-
 	logEntity.Info("Finish...")
 
 	return nil
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/getsentry/sentry-go"
+	"github.com/jinzhu/gorm"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/bitmark-inc/spring-app-api/pipeline"
+	"github.com/bitmark-inc/spring-app-api/schema/provenance"
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// importArchive imports the records of an archive in a transaction,
+// so an archive is either fully imported or not imported at all.
+// Re-importing an archive replaces the records imported from it before.
+// The files uploaded by an import which fails are removed, except the ones the previous import refers to.
+func (b *BackgroundContext) importArchive(ctx context.Context, sess *session.Session, accountNumber string, archiveID int64, parse func(db *gorm.DB, uploaded func(key string)) error) error {
+	logEntity := log.WithField("prefix", "import_archive").WithField("archive_id", archiveID)
+
+	// files of the previous import are kept when the import fails, since its records are restored
+	dataPrefix, err := b.archiveDataPrefix(ctx, archiveID)
+	if err != nil {
+		return err
+	}
+	existingKeys, err := listKeys(ctx, sess, dataPrefix)
+	if err != nil {
+		return err
+	}
+
+	tx := b.ormDB.Set("gorm:insert_option", "ON CONFLICT DO NOTHING").Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	removed, err := provenance.RemoveRecords(tx, archiveID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	logEntity.WithField("records", removed).Info("removed records of the previous import")
+
+	uploadedKeys := make([]string, 0)
+	uploaded := func(key string) {
+		uploadedKeys = append(uploadedKeys, key)
+	}
+
+	rollback := func(err error) error {
+		logEntity.WithError(err).Warn("rollback the import")
+		tx.Rollback()
+
+		// files left by previous imports are kept if their records are restored
+		newKeys := importedKeys(uploadedKeys, existingKeys, removed > 0)
+		logEntity.WithField("files", len(newKeys)).Info("remove files of the import")
+		if err := removeKeys(ctx, sess, newKeys); err != nil {
+			logEntity.Error(err)
+			sentry.CaptureException(err)
+		}
+		return err
+	}
+
+	if err := parse(tx, uploaded); err != nil {
+		return rollback(err)
+	}
+
+	if err := provenance.ClaimRecords(tx, accountNumber, archiveID); err != nil {
+		return rollback(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return rollback(err)
+	}
+
+	return nil
+}
+
+// importedKeys returns the keys of the files written by an import, which are removed when the import fails.
+// The files it overwrites are extracted from the same archive as the previous import, so they still match
+// the records of the previous import. The existing files are included if no records are restored.
+func importedKeys(uploadedKeys []string, existingKeys map[string]bool, keepExisting bool) []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0, len(uploadedKeys))
+	for _, key := range uploadedKeys {
+		if seen[key] || (keepExisting && existingKeys[key]) {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+
+	if !keepExisting {
+		for key := range existingKeys {
+			if !seen[key] {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// archiveDataPrefix returns the prefix of the files extracted from an archive on s3.
+// The files are in the data dir next to the archive.
+func (b *BackgroundContext) archiveDataPrefix(ctx context.Context, archiveID int64) (string, error) {
+	archives, err := b.store.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		ID: &archiveID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(archives) == 0 {
+		return "", fmt.Errorf("archive %d not found", archiveID)
+	}
+
+	return path.Join(path.Dir(archives[0].S3Key), "data") + "/", nil
+}
+
+// listKeys returns the keys of the files with a prefix on s3
+func listKeys(ctx context.Context, sess *session.Session, prefix string) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	err := s3.New(sess).ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(viper.GetString("aws.s3.bucket")),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys[aws.StringValue(object.Key)] = true
+		}
+		return true
+	})
+	return keys, err
+}
+
+// removeKeys removes files on s3 by their keys
+func removeKeys(ctx context.Context, sess *session.Session, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	bucket := viper.GetString("aws.s3.bucket")
+	objects := make([]s3manager.BatchDeleteObject, 0, len(keys))
+	for _, key := range keys {
+		objects = append(objects, s3manager.BatchDeleteObject{
+			Object: &s3.DeleteObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			},
+		})
+	}
+
+	return s3manager.NewBatchDelete(sess).Delete(ctx, &s3manager.DeleteObjectsIterator{Objects: objects})
+}
+
+// removeArchiveFiles removes the files extracted from an archive on s3, and keeps the archive itself.
+func (b *BackgroundContext) removeArchiveFiles(ctx context.Context, sess *session.Session, archiveID int64) error {
+	dataPrefix, err := b.archiveDataPrefix(ctx, archiveID)
+	if err != nil {
+		return err
+	}
+
+	svc := s3.New(sess)
+	iter := s3manager.NewDeleteListIterator(svc, &s3.ListObjectsInput{
+		Bucket: aws.String(viper.GetString("aws.s3.bucket")),
+		Prefix: aws.String(dataPrefix),
+	})
+
+	return s3manager.NewBatchDeleteWithClient(svc).Delete(ctx, iter)
+}
+
+// removeArchiveData removes all of the data imported from an archive
+// and recomputes the stats from the data of other archives
+func (b *BackgroundContext) removeArchiveData(ctx context.Context, accountNumber string, archiveID int64) error {
+	unlock, err := b.lockAccount(accountNumber, archiveID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logEntity := log.WithField("prefix", "remove_archive_data").WithField("archive_id", archiveID)
+
+	tx := b.ormDB.Begin()
+	if err := tx.Error; err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	removed, err := provenance.RemoveRecords(tx, archiveID)
+	if err != nil {
+		tx.Rollback()
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+		return err
+	}
+	logEntity.WithField("records", removed).Info("Removed records")
+
+	sess, err := session.NewSession(b.awsConf)
+	if err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	logEntity.Info("Remove archive files")
+	if err := b.removeArchiveFiles(ctx, sess, archiveID); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+
+	if _, err := b.store.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+		ID: &archiveID,
+	}, &store.FBArchiveQueryParam{
+		Status: &store.FBArchiveStatusRemoved,
+	}); err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	// Stats are computed from all of the data of an account, so drop them and compute again
+	logEntity.Info("Remove stats")
+	b.removeFBStats(ctx, accountNumber)
+
+	archives, err := b.store.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		AccountNumber: &accountNumber,
+		Status:        &store.FBArchiveStatusProcessed,
+	})
+	if err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	var lastArchive *store.FBArchive
+	for i, a := range archives {
+		if lastArchive == nil || a.CreatedAt.After(lastArchive.CreatedAt) {
+			lastArchive = &archives[i]
+		}
+	}
+	if lastArchive == nil {
+		logEntity.Info("No archive left to compute stats")
+		return nil
+	}
+
+	logEntity.Info("Start analyzing the last archive")
+	if err := b.startWorkflow(ctx, pipeline.AnalyzeArchive, pipeline.ArchiveArgs{
+		AccountNumber: accountNumber,
+		ArchiveID:     lastArchive.ID,
+	}); err != nil {
+		logEntity.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportedKeys(t *testing.T) {
+	uploaded := []string{"data/a.jpg", "data/b.jpg", "data/a.jpg"}
+	existing := map[string]bool{"data/a.jpg": true, "data/c.jpg": true}
+
+	// files overwritten by a re-import are kept for the restored records
+	assert.Equal(t, []string{"data/b.jpg"}, importedKeys(uploaded, existing, true))
+
+	// all of the files are removed if no records refer to them
+	assert.ElementsMatch(t, []string{"data/a.jpg", "data/b.jpg", "data/c.jpg"}, importedKeys(uploaded, existing, false))
+
+	assert.Empty(t, importedKeys(nil, existing, true))
+}
@@ -2,9 +2,9 @@ package main
 
 import (
 	"context"
+	"sort"
 	"strconv"
 
-	"github.com/RichardKnop/machinery/v1/tasks"
 	"github.com/getsentry/sentry-go"
 	"github.com/golang/protobuf/proto"
 	log "github.com/sirupsen/logrus"
@@ -16,11 +16,27 @@ import (
 	"github.com/bitmark-inc/spring-app-api/timeutil"
 )
 
-func (b *BackgroundContext) extractReaction(ctx context.Context, accountNumber string, archiveID int64) error {
+func (b *BackgroundContext) extractReaction(ctx context.Context, accountNumber string, archiveID int64) (err error) {
+	unlock, err := b.lockAccount(accountNumber, archiveID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	jobError := NewArchiveJobError(archiveID, fbArchive.ErrFailToExtractReaction)
 	logEntry := log.WithField("prefix", "extract_reaction")
 
+	var itemCount int64
+	finishStage := b.startStage(ctx, archiveID, store.FBArchiveStageAnalyzeReactions)
+	defer func() { finishStage(itemCount, err) }()
+
 	saver := newStatSaver(b.fbDataStore)
+	scope, err := b.mergeScope(ctx, accountNumber, archiveID, "reaction")
+	if err != nil {
+		logEntry.Error(err)
+		return jobError(err)
+	}
+	saver.scope = scope
 	counter := newReactionStatCounter(ctx, logEntry, saver, accountNumber)
 
 	var lastTimestamp int64
@@ -30,19 +46,44 @@ func (b *BackgroundContext) extractReaction(ctx context.Context, accountNumber s
 	b.ormDB.Where(&facebook.ReactionORM{DataOwnerID: accountNumber}).
 		Order("timestamp ASC").Find(&reactions)
 
+	items := make([]*protomodel.Reaction, 0, len(reactions))
 	for _, reaction := range reactions {
-		if lastTimestamp == reaction.Timestamp {
-			continue
-		}
-		lastTimestamp = reaction.Timestamp
-
-		reactionData, _ := proto.Marshal(&protomodel.Reaction{
+		items = append(items, &protomodel.Reaction{
 			ReactionId: reaction.ID.String(),
 			Timestamp:  reaction.Timestamp,
 			Title:      reaction.Title,
 			Actor:      reaction.Actor,
 			Reaction:   reaction.Reaction,
+			Source:     "facebook",
 		})
+	}
+
+	// Merge reactions from other sources
+	instagramReactions, err := b.instagramReactions(accountNumber)
+	if err != nil {
+		logEntry.Error(err)
+		return jobError(err)
+	}
+	items = append(items, instagramReactions...)
+
+	twitterReactions, err := b.twitterReactions(accountNumber)
+	if err != nil {
+		logEntry.Error(err)
+		return jobError(err)
+	}
+	items = append(items, twitterReactions...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Timestamp < items[j].Timestamp
+	})
+	itemCount = int64(len(items))
+
+	for _, reaction := range items {
+		if lastTimestamp == reaction.Timestamp {
+			continue
+		}
+		lastTimestamp = reaction.Timestamp
+
+		reactionData, _ := proto.Marshal(reaction)
 		if err := saver.save(accountNumber+"/reaction", reaction.Timestamp, reactionData); err != nil {
 			logEntry.Error(err)
 			sentry.CaptureException(err)
@@ -66,36 +107,9 @@ func (b *BackgroundContext) extractReaction(ctx context.Context, accountNumber s
 		sentry.CaptureException(err)
 		return jobError(err)
 	}
-
-	logEntry.Info("Enqueue parsing time meta")
-	server.SendTask(&tasks.Signature{
-		Name: jobExtractTimeMetadata,
-		Args: []tasks.Arg{
-			{
-				Type:  "string",
-				Value: accountNumber,
-			},
-		},
-	})
-
-	logEntry.Info("Enqueue push notification")
-	server.SendTask(&tasks.Signature{
-		Name: jobNotificationFinish,
-		Args: []tasks.Arg{
-			{
-				Type:  "string",
-				Value: accountNumber,
-			},
-		},
-	})
-
-	// Mark the archive is processed
-	if _, err := b.store.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
-		ID: &archiveID,
-	}, &store.FBArchiveQueryParam{
-		Status: &store.FBArchiveStatusProcessed,
-	}); err != nil {
+	if err := saver.removeStale(); err != nil {
 		logEntry.Error(err)
+		sentry.CaptureException(err)
 		return jobError(err)
 	}
 
@@ -181,7 +195,7 @@ func (r *reactionStatCounter) flush() error {
 	return nil
 }
 
-func (r *reactionStatCounter) count(reaction facebook.ReactionORM) error {
+func (r *reactionStatCounter) count(reaction *protomodel.Reaction) error {
 	if err := r.countWeek(reaction); err != nil {
 		return err
 	}
@@ -194,7 +208,7 @@ func (r *reactionStatCounter) count(reaction facebook.ReactionORM) error {
 	return nil
 }
 
-func (r *reactionStatCounter) countWeek(reaction facebook.ReactionORM) error {
+func (r *reactionStatCounter) countWeek(reaction *protomodel.Reaction) error {
 	periodTimestamp := timeutil.AbsWeek(reaction.Timestamp)
 
 	// Release the current period if next period has come
@@ -230,7 +244,7 @@ func (r *reactionStatCounter) countWeek(reaction facebook.ReactionORM) error {
 	return nil
 }
 
-func (r *reactionStatCounter) countYear(reaction facebook.ReactionORM) error {
+func (r *reactionStatCounter) countYear(reaction *protomodel.Reaction) error {
 	periodTimestamp := timeutil.AbsYear(reaction.Timestamp)
 
 	// Release the current period if next period has come
@@ -266,7 +280,7 @@ func (r *reactionStatCounter) countYear(reaction facebook.ReactionORM) error {
 	return nil
 }
 
-func (r *reactionStatCounter) countDecade(reaction facebook.ReactionORM) error {
+func (r *reactionStatCounter) countDecade(reaction *protomodel.Reaction) error {
 	periodTimestamp := timeutil.AbsDecade(reaction.Timestamp)
 
 	// Release the current period if next period has come
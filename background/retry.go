@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/getsentry/sentry-go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/deadletter"
+)
+
+// retryPolicy is the retry budget of a job. The delay before a retry doubles on every attempt.
+type retryPolicy struct {
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// delay returns the delay before the retry of an attempt, starting from 0
+func (p retryPolicy) delay(attempt int) time.Duration {
+	d := p.backoff
+	for i := 0; i < attempt && d < p.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.maxBackoff {
+		d = p.maxBackoff
+	}
+	return d
+}
+
+var defaultRetryPolicy = retryPolicy{maxRetries: 3, backoff: 30 * time.Second, maxBackoff: 10 * time.Minute}
+
+// retryPolicies are the retry policies of jobs by their names
+var retryPolicies = map[string]retryPolicy{
+	jobDownloadArchive:     {maxRetries: 5, backoff: time.Minute, maxBackoff: 30 * time.Minute},
+	jobParseArchive:        {maxRetries: 3, backoff: time.Minute, maxBackoff: 15 * time.Minute},
+	jobNotificationFinish:  {maxRetries: 5, backoff: 10 * time.Second, maxBackoff: 5 * time.Minute},
+	jobGenerateHashContent: {maxRetries: 5, backoff: time.Minute, maxBackoff: 30 * time.Minute},
+	jobPrepareDataExport:   {maxRetries: 3, backoff: time.Minute, maxBackoff: 15 * time.Minute},
+	jobDeleteUserData:      {maxRetries: 10, backoff: time.Minute, maxBackoff: time.Hour},
+	jobRemoveArchiveData:   {maxRetries: 10, backoff: time.Minute, maxBackoff: time.Hour},
+	jobIssueArchiveBitmark: {maxRetries: 5, backoff: time.Minute, maxBackoff: 30 * time.Minute},
+	jobIssueExportBitmark:  {maxRetries: 5, backoff: time.Minute, maxBackoff: 30 * time.Minute},
+}
+
+func retryPolicyOf(name string) retryPolicy {
+	if p, ok := retryPolicies[name]; ok {
+		return p
+	}
+	return defaultRetryPolicy
+}
+
+// RetryableError is an error telling whether the job may succeed if it runs again
+type RetryableError interface {
+	Retryable() bool
+}
+
+// transientError marks an error as transient, like a failure of an unavailable external service
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Retryable() bool {
+	return true
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable classifies an error as retryable if it is caused by a transient failure
+// of aws services, the network or an external service. Wrapped errors are classified by
+// the first error in their chains which tells whether it is retryable.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var retryableErr RetryableError
+	if errors.As(err, &retryableErr) {
+		return retryableErr.Retryable()
+	}
+
+	var requestErr awserr.RequestFailure
+	if errors.As(err, &requestErr) {
+		if requestErr.StatusCode() >= 500 || requestErr.StatusCode() == 429 {
+			return true
+		}
+		return request.IsErrorRetryable(requestErr) || request.IsErrorThrottle(requestErr)
+	}
+
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return request.IsErrorRetryable(awsErr) || request.IsErrorThrottle(awsErr)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout() || netErr.Temporary()
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// withRetry wraps a task to retry it with the retry policy of its name when it fails with a retryable error.
+// The task is put into the dead-letter queue when its retries are exhausted.
+// Tasks must take a context as their first argument and return an error as their last result.
+func (b *BackgroundContext) withRetry(name string, task interface{}) interface{} {
+	fn := reflect.ValueOf(task)
+	return reflect.MakeFunc(fn.Type(), func(args []reflect.Value) []reflect.Value {
+		results := fn.Call(args)
+
+		last := len(results) - 1
+		if results[last].IsNil() {
+			return results
+		}
+
+		ctx := args[0].Interface().(context.Context)
+		err := b.retryJob(ctx, name, results[last].Interface().(error))
+		results[last] = reflect.ValueOf(&err).Elem()
+		return results
+	}).Interface()
+}
+
+// retryJob returns an error to retry the job later if the error is retryable and the job has retry budget,
+// or the error itself to fail the job
+func (b *BackgroundContext) retryJob(ctx context.Context, name string, err error) error {
+	signature := tasks.SignatureFromContext(ctx)
+	if signature == nil || !isRetryable(err) {
+		return err
+	}
+
+	logEntity := log.WithField("prefix", "job_retry").
+		WithField("task", name).
+		WithField("uuid", signature.UUID)
+
+	policy := retryPolicyOf(name)
+	attempt := deadletter.RetryAttempt(signature)
+	if attempt < policy.maxRetries {
+		delay := policy.delay(attempt)
+		deadletter.SetRetryAttempt(signature, attempt+1)
+		logEntity.WithField("attempt", attempt+1).WithField("delay", delay).WithError(err).Warn("retry the failed job later")
+		return tasks.NewErrRetryTaskLater(err.Error(), delay)
+	}
+
+	logEntity.WithError(err).Error("retries of the job are exhausted")
+	if err := b.deadLetters.Add(deadletter.Job{
+		Signature: signature,
+		Error:     err.Error(),
+		Attempts:  attempt + 1,
+		FailedAt:  time.Now(),
+	}); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+
+	return err
+}
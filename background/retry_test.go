@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bitmark-inc/spring-app-api/archives/facebook"
+)
+
+func TestIsRetryable(t *testing.T) {
+	timeout := &net.DNSError{Err: "timeout", IsTimeout: true}
+	unavailable := awserr.NewRequestFailure(awserr.New("ServiceUnavailable", "unavailable", nil), 503, "request-id")
+
+	testCases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("failed"), false},
+		{"transient error", &transientError{errors.New("unavailable")}, true},
+		{"wrapped transient error", fmt.Errorf("call service: %w", &transientError{errors.New("unavailable")}), true},
+		{"aws server error", unavailable, true},
+		{"wrapped aws server error", fmt.Errorf("upload: %w", unavailable), true},
+		{"aws too many requests", awserr.NewRequestFailure(awserr.New("TooManyRequests", "slow down", nil), 429, "request-id"), true},
+		{"aws client error", awserr.NewRequestFailure(awserr.New("AccessDenied", "denied", nil), 403, "request-id"), false},
+		{"aws throttling", awserr.New("Throttling", "rate exceeded", nil), true},
+		{"wrapped aws request timeout", fmt.Errorf("download: %w", awserr.New("RequestTimeout", "timeout", nil)), true},
+		{"aws validation error", awserr.New("ValidationError", "invalid", nil), false},
+		{"network timeout", timeout, true},
+		{"wrapped network timeout", fmt.Errorf("dial: %w", timeout), true},
+		{"network error", &net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"wrapped deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), true},
+		{"wrapped unexpected eof", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true},
+		{"canceled", context.Canceled, false},
+		{"archive job error", NewArchiveJobError(1, facebook.ErrFailToParseArchive)(timeout), true},
+		{"archive job error of plain error", NewArchiveJobError(1, facebook.ErrFailToParseArchive)(errors.New("failed")), false},
+		{"wrapped archive job error", fmt.Errorf("parse: %w", NewArchiveJobError(1, facebook.ErrFailToDownloadArchive)(unavailable)), true},
+		{"invalid archive", NewArchiveJobError(1, facebook.ErrInvalidArchive)(timeout), false},
+		{"wrapped invalid archive", fmt.Errorf("download: %w", NewArchiveJobError(1, facebook.ErrInvalidArchive)(unavailable)), false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.retryable, isRetryable(tc.err), tc.name)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := retryPolicy{maxRetries: 5, backoff: time.Second, maxBackoff: 10 * time.Second}
+
+	testCases := []struct {
+		attempt int
+		delay   time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{4, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.delay, policy.delay(tc.attempt), "attempt %d", tc.attempt)
+	}
+}
@@ -5,52 +5,158 @@ import (
 	"errors"
 	"math"
 
-	"github.com/RichardKnop/machinery/v1/tasks"
 	"github.com/bitmark-inc/spring-app-api/protomodel"
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/sentiment"
+	"github.com/bitmark-inc/spring-app-api/store"
 	"github.com/bitmark-inc/spring-app-api/timeutil"
 	"github.com/getsentry/sentry-go"
 	"github.com/golang/protobuf/proto"
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// weekly scores are in the range of 1 to 10, as the scores from bitsocial
+const (
+	minWeeklySentiment = 1
+	maxWeeklySentiment = 10
 )
 
 func (b *BackgroundContext) extractSentiment(ctx context.Context, accountNumber string, archiveid int64) (err error) {
+	unlock, err := b.lockAccount(accountNumber, archiveid)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	logEntry := log.WithField("prefix", "extract_sentiment")
 
-	defer func() error {
-		if err == nil {
-			logEntry.Info("Finish parsing sentiments")
-
-			server.SendTask(&tasks.Signature{
-				Name: jobAnalyzeReactions,
-				Args: []tasks.Arg{
-					{
-						Type:  "string",
-						Value: accountNumber,
-					},
-					{
-						Type:  "int64",
-						Value: archiveid,
-					},
-				},
-			})
-		}
-		return err
-	}()
+	var itemCount int64
+	finishStage := b.startStage(ctx, archiveid, store.FBArchiveStageAnalyzeSentiments)
+	defer func() { finishStage(itemCount, err) }()
 
 	saver := newStatSaver(b.fbDataStore)
 	counter := newSentimentStatCounter(ctx, logEntry, saver, accountNumber)
 
+	switch viper.GetString("sentiment.weekly_source") {
+	case "bitsocial":
+		itemCount, err = b.countBitSocialSentiment(ctx, logEntry, counter, accountNumber)
+	default:
+		itemCount, err = b.countLocalSentiment(ctx, logEntry, counter, accountNumber)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := counter.flush(); err != nil {
+		logEntry.Error(err)
+		sentry.CaptureException(err)
+		return err
+	}
+	if err := saver.flush(); err != nil {
+		logEntry.Error(err)
+		sentry.CaptureException(err)
+		return err
+	}
+
+	return nil
+}
+
+// countLocalSentiment counts the average sentiment of the posts of every week with posts.
+// The sentiment stored with a post by the parser is used, and posts without it are analyzed again.
+func (b *BackgroundContext) countLocalSentiment(ctx context.Context, logEntry *log.Entry, counter *sentimentStatCounter, accountNumber string) (int64, error) {
+	rows, err := b.ormDB.Model(&facebook.PostORM{}).
+		Select("timestamp, post, sentiment").
+		Where("data_owner_id = ?", accountNumber).
+		Order("timestamp ASC").Rows()
+	if err != nil {
+		logEntry.Error(err)
+		return 0, err
+	}
+	defer rows.Close()
+
+	var weekCount int64
+	currentWeek := int64(-1)
+	scores := make([]float64, 0)
+
+	countWeek := func() error {
+		if len(scores) == 0 {
+			return nil
+		}
+		if err := counter.count(currentWeek, weeklySentiment(scores)); err != nil {
+			logEntry.Error(err)
+			sentry.CaptureException(err)
+			return err
+		}
+		weekCount++
+		scores = scores[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		var timestamp int64
+		var post, stored string
+		if err := rows.Scan(&timestamp, &post, &stored); err != nil {
+			logEntry.Error(err)
+			return weekCount, err
+		}
+		if post == "" {
+			continue
+		}
+
+		postScores := sentiment.ParseScores(stored)
+		if len(postScores) == 0 {
+			postScores, err = b.sentimentAnalyzer.Analyze(ctx, post)
+			if err != nil {
+				logEntry.WithError(err).WithField("timestamp", timestamp).Warn("fail to analyze sentiment of post")
+				continue
+			}
+		}
+		if len(postScores) == 0 {
+			continue
+		}
+
+		if week := timeutil.AbsWeek(timestamp); week != currentWeek {
+			if err := countWeek(); err != nil {
+				return weekCount, err
+			}
+			currentWeek = week
+		}
+		scores = append(scores, sentiment.Average(postScores))
+	}
+	if err := rows.Err(); err != nil {
+		logEntry.Error(err)
+		return weekCount, err
+	}
+
+	return weekCount, countWeek()
+}
+
+// weeklySentiment scales the average of the scores of the posts of a week into the range of weekly scores
+func weeklySentiment(scores []float64) float64 {
+	total := 0.0
+	for _, s := range scores {
+		total += s
+	}
+	average := total / float64(len(scores))
+
+	ratio := (average - sentiment.VeryNegative) / (sentiment.VeryPositive - sentiment.VeryNegative)
+	return minWeeklySentiment + ratio*(maxWeeklySentiment-minWeeklySentiment)
+}
+
+// countBitSocialSentiment counts the sentiment of every week between the first and the last posts analyzed by bitsocial
+func (b *BackgroundContext) countBitSocialSentiment(ctx context.Context, logEntry *log.Entry, counter *sentimentStatCounter, accountNumber string) (int64, error) {
 	// Get first post to get the starting timestamp
 	firstPost, err := b.bitSocialClient.GetFirstPost(ctx, accountNumber)
 	if err != nil {
 		logEntry.Error(err)
 		sentry.CaptureException(errors.New("Request first post failed for onwer " + accountNumber))
-		return err
+		return 0, err
 	}
 
 	// This user has no post at all, no sentiment to calculate
 	if firstPost == nil {
-		return nil
+		return 0, nil
 	}
 
 	// Get last post to get the ending timestamp
@@ -58,7 +164,7 @@ func (b *BackgroundContext) extractSentiment(ctx context.Context, accountNumber
 	if err != nil {
 		logEntry.Error(err)
 		sentry.CaptureException(errors.New("Request last post failed for onwer " + accountNumber))
-		return err
+		return 0, err
 	}
 
 	// last post can not be nil
@@ -66,25 +172,27 @@ func (b *BackgroundContext) extractSentiment(ctx context.Context, accountNumber
 		err := errors.New("Last post can not be nil")
 		logEntry.Error(err)
 		sentry.CaptureException(err)
-		return err
+		return 0, err
 	}
 
 	timestampOffset := timeutil.AbsWeek(firstPost.Timestamp)
 	nextWeek := timeutil.AbsWeek(lastPost.Timestamp) + 7*24*60*60
 	toEndOfWeek := int64(7*24*60*60 - 1)
 
+	var weekCount int64
 	for {
 		data, err := b.bitSocialClient.GetLast7DaysOfSentiment(ctx, accountNumber, timestampOffset+toEndOfWeek)
 		if err != nil {
-			return err
+			return weekCount, err
 		}
 		logEntry.Debug(data)
 
 		if err := counter.count(timestampOffset, data.Score); err != nil {
 			logEntry.Error(err)
 			sentry.CaptureException(err)
-			return err
+			return weekCount, err
 		}
+		weekCount++
 
 		timestampOffset += 7 * 24 * 60 * 60 // means next week
 		if timestampOffset >= nextWeek {
@@ -92,18 +200,7 @@ func (b *BackgroundContext) extractSentiment(ctx context.Context, accountNumber
 		}
 	}
 
-	if err := counter.flush(); err != nil {
-		logEntry.Error(err)
-		sentry.CaptureException(err)
-		return err
-	}
-	if err := saver.flush(); err != nil {
-		logEntry.Error(err)
-		sentry.CaptureException(err)
-		return err
-	}
-
-	return nil
+	return weekCount, nil
 }
 
 type sentimentStat struct {
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+
+	"github.com/getsentry/sentry-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// startStage records the start of a stage of processing an archive, and returns a func
+// to record the end of the stage with the number of processed items and the error of the stage.
+// Failing to record a stage does not fail the stage itself.
+func (b *BackgroundContext) startStage(ctx context.Context, archiveID int64, name string) func(itemCount int64, err error) {
+	logEntity := log.WithField("prefix", "archive_stage").
+		WithField("archive_id", archiveID).
+		WithField("stage", name)
+
+	if err := b.store.StartFBArchiveStage(ctx, archiveID, name); err != nil {
+		logEntity.Error(err)
+		sentry.CaptureException(err)
+	}
+
+	return func(itemCount int64, err error) {
+		var stageError string
+		if err != nil {
+			stageError = err.Error()
+		}
+
+		if err := b.store.FinishFBArchiveStage(ctx, archiveID, name, itemCount, stageError); err != nil {
+			logEntity.Error(err)
+			sentry.CaptureException(err)
+		}
+	}
+}
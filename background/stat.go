@@ -50,9 +50,9 @@ var (
 			Namespace: "fbm",
 			Subsystem: "jobs",
 			Name:      "processing_max",
-			Help:      "Max number of jobs that can be processed by the worker",
+			Help:      "Max number of jobs that can be processed by the workers of a queue",
 		},
-		[]string{},
+		[]string{"queue"},
 	)
 )
 
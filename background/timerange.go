@@ -10,9 +10,18 @@ import (
 	"github.com/bitmark-inc/spring-app-api/store"
 )
 
-func (b *BackgroundContext) extractTimeMetadata(ctx context.Context, accountNumber string) error {
+func (b *BackgroundContext) extractTimeMetadata(ctx context.Context, accountNumber string, archiveID int64) (err error) {
+	unlock, err := b.lockAccount(accountNumber, archiveID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	logEntry := log.WithField("prefix", "extract_time_metadata")
 
+	finishStage := b.startStage(ctx, archiveID, store.FBArchiveStageTimeMetadata)
+	defer func() { finishStage(0, err) }()
+
 	var firstPostTimestamp, lastPostTimestamp int64
 	var firstReactionTimestamp, lastReactionTimestamp int64
 
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/bitmark-inc/spring-app-api/protomodel"
+	"github.com/bitmark-inc/spring-app-api/schema/twitter"
+)
+
+// twitterPosts loads the tweets of an account as posts
+func (b *BackgroundContext) twitterPosts(accountNumber string) ([]*protomodel.Post, error) {
+	tweets := make([]twitter.TweetORM, 0)
+	if err := b.ormDB.Where(&twitter.TweetORM{DataOwnerID: accountNumber}).
+		Order("timestamp ASC").Find(&tweets).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*protomodel.Post, 0, len(tweets))
+	for _, t := range tweets {
+		media := make([]*protomodel.MediaData, 0)
+		for _, uri := range t.MediaURIs {
+			media = append(media, mediaDataFromURI(uri, filepath.Ext(uri)))
+		}
+
+		postType := "update"
+		if len(media) > 0 {
+			postType = "media"
+		} else if t.URL != "" {
+			postType = "link"
+		}
+
+		items = append(items, &protomodel.Post{
+			Id:        t.ID.String(),
+			Timestamp: t.Timestamp,
+			Type:      postType,
+			Post:      t.Text,
+			Url:       t.URL,
+			MediaData: media,
+			Source:    "twitter",
+		})
+	}
+
+	return items, nil
+}
+
+// twitterReactions loads the liked tweets of an account as reactions.
+// Likes of tweets older than snowflake ids have no time and are left out.
+func (b *BackgroundContext) twitterReactions(accountNumber string) ([]*protomodel.Reaction, error) {
+	likes := make([]twitter.LikeORM, 0)
+	if err := b.ormDB.Where(&twitter.LikeORM{DataOwnerID: accountNumber}).
+		Where("timestamp > 0").
+		Order("timestamp ASC").Find(&likes).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*protomodel.Reaction, 0, len(likes))
+	for _, l := range likes {
+		items = append(items, &protomodel.Reaction{
+			ReactionId: l.ID.String(),
+			Timestamp:  l.Timestamp,
+			Title:      "liked a tweet",
+			Reaction:   "LIKE",
+			Source:     "twitter",
+		})
+	}
+
+	return items, nil
+}
@@ -2,13 +2,19 @@ package main
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/bitmark-inc/spring-app-api/store"
+	"github.com/bitmark-inc/spring-app-api/timeutil"
 )
 
 type statSaver struct {
 	store store.FBDataStore
 	queue []store.FbData
+
+	// scope limits the saved stats if it is not nil
+	scope *statScope
 }
 
 func newStatSaver(fbstore store.FBDataStore) *statSaver {
@@ -19,6 +25,10 @@ func newStatSaver(fbstore store.FBDataStore) *statSaver {
 }
 
 func (s *statSaver) save(key string, timestamp int64, data []byte) error {
+	if s.scope != nil && !s.scope.includes(key, timestamp) {
+		return nil
+	}
+
 	s.queue = append(s.queue, store.FbData{
 		Key:       key,
 		Timestamp: timestamp,
@@ -45,3 +55,133 @@ func (s *statSaver) flush() error {
 	}
 	return nil
 }
+
+// removeStale removes the stats in the scope which are not saved again, since they no longer exist.
+// It must be called after all stats are saved.
+func (s *statSaver) removeStale() error {
+	if s.scope == nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	for key, timestamps := range s.scope.stale() {
+		for _, ts := range timestamps {
+			if err := s.store.RemoveExactFBStat(ctx, key, ts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// statScope is the part of stats of a section, like post, affected by merging an archive into the data of an account.
+// Items of the section are affected at the timestamps of the new, changed and deleted items,
+// and period stats of the section are affected for the periods of these timestamps.
+// The stats of the next period with data are affected as well, because of their differences from the previous period.
+type statScope struct {
+	accountNumber string
+
+	// affected timestamps of items by the key of the section
+	items map[string]map[int64]bool
+
+	// sorted start time of affected periods by the keys of period stats, like post-week-stat
+	periods map[string][]int64
+
+	// timestamps of the stats asked to be saved by keys
+	computed map[string]map[int64]bool
+	last     map[string]int64
+}
+
+var statPeriods = []string{"week", "year", "decade"}
+
+// newStatScope returns the scope of a section with its merge summary.
+// Nothing is affected if the summary is nil.
+func newStatScope(accountNumber, section string, summary *store.SectionMergeSummary) *statScope {
+	s := &statScope{
+		accountNumber: accountNumber,
+		items:         make(map[string]map[int64]bool),
+		periods:       make(map[string][]int64),
+		computed:      make(map[string]map[int64]bool),
+		last:          make(map[string]int64),
+	}
+
+	items := make(map[int64]bool)
+	if summary != nil {
+		for _, ts := range summary.AffectedTimestamps {
+			items[ts] = true
+		}
+	}
+	s.items[section] = items
+
+	for _, period := range statPeriods {
+		starts := make(map[int64]bool)
+		for ts := range items {
+			starts[timeutil.AbsPeriod(period, ts)] = true
+		}
+
+		periods := make([]int64, 0, len(starts))
+		for start := range starts {
+			periods = append(periods, start)
+		}
+		sort.Slice(periods, func(i, j int) bool {
+			return periods[i] < periods[j]
+		})
+		s.periods[section+"-"+period+"-stat"] = periods
+	}
+
+	return s
+}
+
+// includes tells whether a stat is in the scope. Stats of a key must be asked in the order of their timestamps.
+// Keys of other sections are always included.
+func (s *statScope) includes(key string, timestamp int64) bool {
+	name := strings.TrimPrefix(key, s.accountNumber+"/")
+
+	if _, ok := s.computed[name]; !ok {
+		s.computed[name] = make(map[int64]bool)
+	}
+	s.computed[name][timestamp] = true
+
+	if items, ok := s.items[name]; ok {
+		return items[timestamp]
+	}
+
+	if periods, ok := s.periods[name]; ok {
+		// a period is affected if any affected period is between the previous period with data and itself
+		from, hasPrevious := s.last[name]
+		s.last[name] = timestamp
+
+		i := 0
+		if hasPrevious {
+			i = sort.Search(len(periods), func(i int) bool {
+				return periods[i] >= from
+			})
+		}
+		return i < len(periods) && periods[i] <= timestamp
+	}
+
+	return true
+}
+
+// stale returns the timestamps of the affected stats which are not computed by keys
+func (s *statScope) stale() map[string][]int64 {
+	result := make(map[string][]int64)
+
+	for name, items := range s.items {
+		for ts := range items {
+			if !s.computed[name][ts] {
+				result[s.accountNumber+"/"+name] = append(result[s.accountNumber+"/"+name], ts)
+			}
+		}
+	}
+
+	for name, periods := range s.periods {
+		for _, ts := range periods {
+			if !s.computed[name][ts] {
+				result[s.accountNumber+"/"+name] = append(result[s.accountNumber+"/"+name], ts)
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/RichardKnop/machinery/v1"
+	machinerycnf "github.com/RichardKnop/machinery/v1/config"
+	"github.com/spf13/viper"
+
+	"github.com/bitmark-inc/spring-app-api/pipeline"
+)
+
+// queueConcurrency returns the number of jobs of a queue processed at the same time.
+// It is set by worker.queues.<queue>.concurrency and defaults to worker.concurrency.
+func queueConcurrency(key string) int {
+	if concurrency := viper.GetInt(fmt.Sprintf("worker.queues.%s.concurrency", key)); concurrency > 0 {
+		return concurrency
+	}
+	return viper.GetInt("worker.concurrency")
+}
+
+// registerTasks maps the names of jobs to their task functions
+func (b *BackgroundContext) registerTasks(s *machinery.Server) error {
+	return s.RegisterTasks(map[string]interface{}{
+		jobDownloadArchive:     b.withRetry(jobDownloadArchive, b.downloadArchive),
+		jobParseArchive:        b.withRetry(jobParseArchive, b.parseArchive),
+		jobAnalyzePosts:        b.withRetry(jobAnalyzePosts, b.extractPost),
+		jobAnalyzeReactions:    b.withRetry(jobAnalyzeReactions, b.extractReaction),
+		jobAnalyzeComments:     b.withRetry(jobAnalyzeComments, b.extractComment),
+		jobAnalyzeSentiments:   b.withRetry(jobAnalyzeSentiments, b.extractSentiment),
+		jobFinishArchive:       b.withRetry(jobFinishArchive, b.finishArchive),
+		jobNotificationFinish:  b.withRetry(jobNotificationFinish, b.notifyAnalyzingDone),
+		jobExtractTimeMetadata: b.withRetry(jobExtractTimeMetadata, b.extractTimeMetadata),
+		jobGenerateHashContent: b.withRetry(jobGenerateHashContent, b.generateHashContent),
+		jobPrepareDataExport:   b.withRetry(jobPrepareDataExport, b.prepareUserExportData),
+		jobDeleteUserData:      b.withRetry(jobDeleteUserData, b.deleteUserData),
+		jobRemoveArchiveData:   b.withRetry(jobRemoveArchiveData, b.removeArchiveData),
+		jobIssueArchiveBitmark: b.withRetry(jobIssueArchiveBitmark, b.issueArchiveBitmark),
+		jobIssueExportBitmark:  b.withRetry(jobIssueExportBitmark, b.issueExportBitmark),
+
+		// periodic jobs are not retried since they run again on their schedules
+		jobPeriodicArchiveCheck:      b.periodicArchiveCheck,
+		jobExpireDataExports:         b.expireDataExports,
+		jobReconcileDeletingAccounts: b.reconcileDeletingAccounts,
+		jobPurgeExpiredTokens:        b.purgeExpiredTokens,
+	})
+}
+
+// newQueueWorker creates a worker processing the jobs of a queue. Every queue is consumed
+// by a machinery server of its own, since a broker consumes one queue at a time.
+func (b *BackgroundContext) newQueueWorker(cnf machinerycnf.Config, consumerTag, queue string, concurrency int) (*machinery.Worker, error) {
+	cnf.DefaultQueue = queue
+	s, err := machinery.NewServer(&cnf)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.registerTasks(s); err != nil {
+		return nil, err
+	}
+
+	worker := s.NewWorker(fmt.Sprintf("%s:%s", consumerTag, queue), concurrency)
+	worker.SetPreTaskHandler(b.jobStartCollectiveMetric)
+	worker.SetPostTaskHandler(b.jobProcessedHandler)
+	worker.SetErrorHandler(b.jobErrorHandler)
+
+	maxProcessingGaugeVec.WithLabelValues(queue).Set(float64(concurrency))
+	return worker, nil
+}
+
+// newWorkers creates the workers of all queues, including the default queue
+// which keeps the jobs sent before they are routed to queues
+func (b *BackgroundContext) newWorkers(cnf machinerycnf.Config, consumerTag string) ([]*machinery.Worker, error) {
+	workers := make([]*machinery.Worker, 0, len(pipeline.Queues)+1)
+	for key, queue := range pipeline.Queues {
+		worker, err := b.newQueueWorker(cnf, consumerTag, queue, queueConcurrency(key))
+		if err != nil {
+			return nil, err
+		}
+		workers = append(workers, worker)
+	}
+
+	worker, err := b.newQueueWorker(cnf, consumerTag, pipeline.QueueDefault, viper.GetInt("worker.concurrency"))
+	if err != nil {
+		return nil, err
+	}
+	return append(workers, worker), nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/getsentry/sentry-go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bitmark-inc/spring-app-api/pipeline"
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// startWorkflow starts a workflow of an archive and keeps the workflow id in the archive
+func (b *BackgroundContext) startWorkflow(ctx context.Context, w pipeline.Workflow, args pipeline.ArchiveArgs) error {
+	workflowID, err := b.pipeline.Start(w, args)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.store.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+		ID: &args.ArchiveID,
+	}, &store.FBArchiveQueryParam{
+		WorkflowID: &workflowID,
+	})
+	return err
+}
+
+// advanceWorkflow starts the next step of the workflow of a job when the job succeeds
+func (b *BackgroundContext) advanceWorkflow(signature *tasks.Signature) {
+	if _, ok := signature.Headers[pipeline.WorkflowIDHeader]; !ok {
+		return
+	}
+
+	// the job may fail or be retried later
+	if taskState(signature) != tasks.StateSuccess {
+		return
+	}
+
+	if err := b.pipeline.Advance(signature); err != nil {
+		log.WithField("prefix", "workflow").
+			WithField("task", signature.Name).
+			WithField("workflow_id", signature.Headers[pipeline.WorkflowIDHeader]).
+			Error(err)
+		sentry.CaptureException(err)
+	}
+}
@@ -0,0 +1,132 @@
+package deadletter
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/gomodule/redigo/redis"
+)
+
+const jobsKey = "spring:dead_letter_jobs"
+
+// RetryAttemptHeader is the header of signatures counting the retries of a job
+const RetryAttemptHeader = "retry_attempt"
+
+// RetryAttempt returns the number of retries of a job
+func RetryAttempt(signature *tasks.Signature) int {
+	// headers are decoded from json when the job is consumed
+	switch v := signature.Headers[RetryAttemptHeader].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return int(n)
+	}
+	return 0
+}
+
+// SetRetryAttempt sets the number of retries of a job
+func SetRetryAttempt(signature *tasks.Signature, attempt int) {
+	if signature.Headers == nil {
+		signature.Headers = make(tasks.Headers)
+	}
+	signature.Headers[RetryAttemptHeader] = attempt
+}
+
+// Job is a background job which fails after all of its retries
+type Job struct {
+	Signature *tasks.Signature `json:"signature"`
+	Error     string           `json:"error"`
+	Attempts  int              `json:"attempts"`
+	FailedAt  time.Time        `json:"failed_at"`
+}
+
+// Requeue returns the signature to run the job again with a full retry budget
+func (j *Job) Requeue() *tasks.Signature {
+	signature := j.Signature
+	signature.ETA = nil
+	SetRetryAttempt(signature, 0)
+	return signature
+}
+
+// Queue keeps dead-letter jobs in redis by their task uuids
+type Queue struct {
+	pool *redis.Pool
+}
+
+// NewQueue creates a queue with a pool of redis connections
+func NewQueue(pool *redis.Pool) *Queue {
+	return &Queue{pool: pool}
+}
+
+// Add adds a job to the queue. A job of the same task uuid is replaced.
+func (q *Queue) Add(job Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	conn := q.pool.Get()
+	defer conn.Close()
+
+	_, err = conn.Do("HSET", jobsKey, job.Signature.UUID, data)
+	return err
+}
+
+// List returns all jobs in the queue in the order of their failing time
+func (q *Queue) List() ([]Job, error) {
+	conn := q.pool.Get()
+	defer conn.Close()
+
+	values, err := redis.ByteSlices(conn.Do("HVALS", jobsKey))
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(values))
+	for _, v := range values {
+		var job Job
+		if err := json.Unmarshal(v, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].FailedAt.Before(jobs[j].FailedAt)
+	})
+	return jobs, nil
+}
+
+// Get returns a job by its task uuid, or nil if it is not in the queue
+func (q *Queue) Get(uuid string) (*Job, error) {
+	conn := q.pool.Get()
+	defer conn.Close()
+
+	data, err := redis.Bytes(conn.Do("HGET", jobsKey, uuid))
+	if err == redis.ErrNil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Remove removes a job by its task uuid
+func (q *Queue) Remove(uuid string) error {
+	conn := q.pool.Get()
+	defer conn.Close()
+
+	_, err := conn.Do("HDEL", jobsKey, uuid)
+	return err
+}
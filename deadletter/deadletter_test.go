@@ -0,0 +1,37 @@
+package deadletter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAttempt(t *testing.T) {
+	signature := &tasks.Signature{Name: "analyze_posts"}
+	assert.Equal(t, 0, RetryAttempt(signature))
+
+	SetRetryAttempt(signature, 2)
+	assert.Equal(t, 2, RetryAttempt(signature))
+
+	// the attempt is kept when the signature is sent to the broker
+	data, err := json.Marshal(signature)
+	assert.NoError(t, err)
+
+	var decoded tasks.Signature
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, 2, RetryAttempt(&decoded))
+}
+
+func TestRequeue(t *testing.T) {
+	eta := time.Now()
+	signature := &tasks.Signature{Name: "analyze_posts", ETA: &eta}
+	SetRetryAttempt(signature, 3)
+
+	job := Job{Signature: signature, Attempts: 4}
+	requeued := job.Requeue()
+	assert.Nil(t, requeued.ETA)
+	assert.Equal(t, 0, RetryAttempt(requeued))
+}
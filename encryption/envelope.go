@@ -0,0 +1,336 @@
+// Package encryption encrypts archives and the data derived from them at rest.
+//
+// Files are envelope-encrypted: the content is encrypted by a random data key with AES-256-GCM,
+// and the data key is wrapped by nacl box for each recipient, which are the account owning the file
+// and the server itself. Accounts decrypt their files with their encryption keys and the public
+// encryption key of the server published by the api. Clients may upload archives encrypted in the
+// same way, with their keys as the sender and the server as a recipient.
+//
+// An encrypted file is laid out as
+//
+//	magic (6 bytes) | version (1 byte) | public key of the sender (32 bytes) | number of recipients (1 byte)
+//	recipients: public key (32 bytes) | size of the wrapped key (2 bytes) | wrapped key
+//	chunks: up to 64 KiB of content sealed by the data key
+//
+// The nonce of a chunk is its index followed by a flag of the last chunk, so reordered or truncated
+// chunks are detected. The header is the additional data of every chunk.
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/bitmark-inc/bitmark-sdk-go/account"
+)
+
+const (
+	version = 1
+
+	dataKeySize   = 32
+	publicKeySize = 32
+
+	// chunkSize is the size of the content of a chunk
+	chunkSize = 64 * 1024
+
+	// tagSize is the size of the authentication tag sealed with a chunk
+	tagSize = 16
+)
+
+var magic = []byte("SPRENC")
+
+var (
+	ErrNoKey             = errors.New("no key to decrypt the encrypted data")
+	ErrNotRecipient      = errors.New("the data is not encrypted for the key")
+	ErrInvalidHeader     = errors.New("invalid header of encrypted data")
+	ErrUnknownVersion    = errors.New("unknown version of encrypted data")
+	ErrDecryption        = errors.New("fail to decrypt data")
+	ErrTruncated         = errors.New("encrypted data is truncated")
+	ErrTooManyRecipients = errors.New("too many recipients")
+	ErrInvalidPublicKey  = errors.New("invalid public encryption key")
+)
+
+// Envelope encrypts data for accounts and the server, and decrypts data with the key of the server
+type Envelope struct {
+	key account.EncrKey
+}
+
+// NewEnvelope creates an envelope with the encryption key of the server
+func NewEnvelope(key account.EncrKey) *Envelope {
+	return &Envelope{key: key}
+}
+
+// PublicKey returns the public encryption key of the server
+func (e *Envelope) PublicKey() []byte {
+	return e.key.PublicKeyBytes()
+}
+
+// ValidatePublicKey returns ErrInvalidPublicKey if key is not a public encryption key
+func ValidatePublicKey(key []byte) error {
+	if len(key) != publicKeySize {
+		return ErrInvalidPublicKey
+	}
+	return nil
+}
+
+// Encrypt returns a writer encrypting data written to w for the server and the recipients,
+// which are the public encryption keys of accounts. It fails with ErrInvalidPublicKey
+// if any of the recipients is not a valid key, so no data is left unreadable by its owner.
+// The writer must be closed to write the last chunk of the data.
+func (e *Envelope) Encrypt(w io.Writer, recipients ...[]byte) (io.WriteCloser, error) {
+	keys := [][]byte{e.key.PublicKeyBytes()}
+	for _, r := range recipients {
+		if err := ValidatePublicKey(r); err != nil {
+			return nil, err
+		}
+		if containsKey(keys, r) {
+			continue
+		}
+		keys = append(keys, r)
+	}
+	if len(keys) > 255 {
+		return nil, ErrTooManyRecipients
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	header := &bytes.Buffer{}
+	header.Write(magic)
+	header.WriteByte(version)
+	header.Write(e.key.PublicKeyBytes())
+	header.WriteByte(byte(len(keys)))
+	for _, k := range keys {
+		wrapped, err := e.key.Encrypt(dataKey, k)
+		if err != nil {
+			return nil, err
+		}
+		header.Write(k)
+		binary.Write(header, binary.BigEndian, uint16(len(wrapped)))
+		header.Write(wrapped)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return &writer{
+		w:      w,
+		aead:   aead,
+		header: header.Bytes(),
+		buf:    make([]byte, 0, chunkSize),
+	}, nil
+}
+
+// Decrypt returns a reader of the data decrypted from r. Data not encrypted is read as it is,
+// so files stored before they were encrypted can still be read. Decrypt on a nil envelope
+// only reads data not encrypted.
+func (e *Envelope) Decrypt(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, chunkSize+tagSize)
+
+	prefix, err := br.Peek(len(magic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if !bytes.Equal(prefix, magic) {
+		return br, nil
+	}
+
+	if e == nil {
+		return nil, ErrNoKey
+	}
+
+	header := &bytes.Buffer{}
+	hr := io.TeeReader(br, header)
+
+	fixed := make([]byte, len(magic)+1+publicKeySize+1)
+	if _, err := io.ReadFull(hr, fixed); err != nil {
+		return nil, ErrInvalidHeader
+	}
+	if fixed[len(magic)] != version {
+		return nil, ErrUnknownVersion
+	}
+	sender := fixed[len(magic)+1 : len(magic)+1+publicKeySize]
+	count := int(fixed[len(fixed)-1])
+
+	var dataKey []byte
+	for i := 0; i < count; i++ {
+		key := make([]byte, publicKeySize)
+		if _, err := io.ReadFull(hr, key); err != nil {
+			return nil, ErrInvalidHeader
+		}
+		var size uint16
+		if err := binary.Read(hr, binary.BigEndian, &size); err != nil {
+			return nil, ErrInvalidHeader
+		}
+		wrapped := make([]byte, size)
+		if _, err := io.ReadFull(hr, wrapped); err != nil {
+			return nil, ErrInvalidHeader
+		}
+
+		if dataKey == nil && bytes.Equal(key, e.key.PublicKeyBytes()) {
+			dataKey, err = e.key.Decrypt(wrapped, sender)
+			if err != nil {
+				return nil, ErrDecryption
+			}
+		}
+	}
+	if dataKey == nil {
+		return nil, ErrNotRecipient
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reader{
+		r:      br,
+		aead:   aead,
+		header: header.Bytes(),
+		buf:    make([]byte, chunkSize+tagSize),
+	}, nil
+}
+
+func containsKey(keys [][]byte, key []byte) bool {
+	for _, k := range keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of the chunk with the index
+func chunkNonce(index uint64, last bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce, index)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+type writer struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	header []byte
+
+	// buf keeps the content of the current chunk, which is sealed when more data is written
+	// or the writer is closed, so the last chunk is always known
+	buf    []byte
+	index  uint64
+	closed bool
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("write to closed writer")
+	}
+
+	written := 0
+	for len(p) > 0 {
+		if len(w.buf) == chunkSize {
+			if err := w.seal(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.buf[len(w.buf):chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close writes the last chunk. The underlying writer is not closed.
+func (w *writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.seal(true)
+}
+
+func (w *writer) seal(last bool) error {
+	sealed := w.aead.Seal(nil, chunkNonce(w.index, last), w.buf, w.header)
+	if _, err := w.w.Write(sealed); err != nil {
+		return err
+	}
+	w.index++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+type reader struct {
+	r      *bufio.Reader
+	aead   cipher.AEAD
+	header []byte
+
+	buf   []byte
+	plain []byte
+	index uint64
+	last  bool
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.last {
+			return 0, io.EOF
+		}
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// open reads and decrypts the next chunk
+func (r *reader) open() error {
+	n, err := io.ReadFull(r.r, r.buf)
+	switch err {
+	case nil:
+		// a full chunk is the last one if nothing follows it
+		if _, err := r.r.Peek(1); err == io.EOF {
+			r.last = true
+		} else if err != nil {
+			return err
+		}
+	case io.ErrUnexpectedEOF:
+		r.last = true
+	case io.EOF:
+		return ErrTruncated
+	default:
+		return err
+	}
+
+	plain, err := r.aead.Open(r.buf[:0], chunkNonce(r.index, r.last), r.buf[:n], r.header)
+	if err != nil {
+		return ErrDecryption
+	}
+	r.plain = plain
+	r.index++
+	return nil
+}
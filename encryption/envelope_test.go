@@ -0,0 +1,111 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/bitmark-inc/bitmark-sdk-go/account"
+	"github.com/stretchr/testify/assert"
+)
+
+func newKey(t *testing.T, seed byte) account.EncrKey {
+	key, err := account.NewEncrKey(bytes.Repeat([]byte{seed}, 32))
+	assert.NoError(t, err)
+	return key
+}
+
+func encrypt(t *testing.T, e *Envelope, data []byte, recipients ...[]byte) []byte {
+	buf := &bytes.Buffer{}
+	w, err := e.Encrypt(buf, recipients...)
+	assert.NoError(t, err)
+	_, err = w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decrypt(e *Envelope, data []byte) ([]byte, error) {
+	r, err := e.Decrypt(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	server := NewEnvelope(newKey(t, 1))
+	user := newKey(t, 2)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3*chunkSize + 100} {
+		data := make([]byte, size)
+		io.ReadFull(rand.Reader, data)
+
+		encrypted := encrypt(t, server, data, user.PublicKeyBytes())
+		assert.NotEqual(t, data, encrypted)
+
+		// the server decrypts the data with its own key
+		decrypted, err := decrypt(server, encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decrypted, "size %d", size)
+
+		// the account decrypts the data with its key
+		decrypted, err = decrypt(NewEnvelope(user), encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decrypted, "size %d", size)
+
+		// others can not decrypt the data
+		_, err = decrypt(NewEnvelope(newKey(t, 3)), encrypted)
+		assert.Equal(t, ErrNotRecipient, err)
+	}
+}
+
+func TestDecryptPlaintext(t *testing.T) {
+	data := []byte("PK\x03\x04 not encrypted")
+
+	decrypted, err := decrypt(NewEnvelope(newKey(t, 1)), data)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	var e *Envelope
+	decrypted, err = decrypt(e, data)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	_, err = decrypt(e, encrypt(t, NewEnvelope(newKey(t, 1)), data))
+	assert.Equal(t, ErrNoKey, err)
+}
+
+func TestDecryptTampered(t *testing.T) {
+	server := NewEnvelope(newKey(t, 1))
+	data := bytes.Repeat([]byte("spring"), chunkSize)
+	encrypted := encrypt(t, server, data)
+	headerSize := len(encrypted) - len(data) - 6*tagSize
+
+	// a modified chunk
+	tampered := append([]byte{}, encrypted...)
+	tampered[headerSize+10] ^= 1
+	_, err := decrypt(server, tampered)
+	assert.Equal(t, ErrDecryption, err)
+
+	// the last chunk is removed
+	_, err = decrypt(server, encrypted[:len(encrypted)-(chunkSize+tagSize)])
+	assert.Equal(t, ErrDecryption, err)
+
+	// nothing follows the header
+	_, err = decrypt(server, encrypted[:headerSize])
+	assert.Equal(t, ErrTruncated, err)
+}
+
+func TestEncryptInvalidRecipient(t *testing.T) {
+	server := NewEnvelope(newKey(t, 1))
+
+	for _, key := range [][]byte{nil, {}, bytes.Repeat([]byte{2}, 31)} {
+		buf := &bytes.Buffer{}
+		_, err := server.Encrypt(buf, newKey(t, 2).PublicKeyBytes(), key)
+		assert.Equal(t, ErrInvalidPublicKey, err)
+		assert.Zero(t, buf.Len())
+	}
+}
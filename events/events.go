@@ -0,0 +1,228 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+)
+
+// Types of events
+const (
+	TypeArchive = "archive"
+	TypeExport  = "export"
+)
+
+const channelPrefix = "spring:events:"
+
+// Event is a state transition of an archive or an export job of an account
+type Event struct {
+	Type          string `json:"type"`
+	AccountNumber string `json:"-"`
+
+	// ArchiveID is the id of a facebook archive for archive events
+	ArchiveID int64 `json:"archive_id,omitempty"`
+
+	// ExportID is the id of a spring archive for export events
+	ExportID string `json:"export_id,omitempty"`
+
+	Status    string    `json:"status"`
+	Task      string    `json:"task,omitempty"`
+	State     string    `json:"state,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// subscriberBuffer is the number of events kept for a subscriber not receiving them yet
+const subscriberBuffer = 16
+
+// Broker publishes events and subscribes events of accounts through redis pub/sub.
+// All subscribers of a process share one connection subscribing the events of all accounts,
+// and the events are fanned out to the subscribers of their accounts in memory.
+type Broker struct {
+	pool *redis.Pool
+
+	// conn returns a connection for the subscription of the broker
+	conn func() redis.Conn
+
+	mu          sync.Mutex
+	psc         *redis.PubSubConn
+	subscribers map[string]map[chan Event]struct{}
+	closed      bool
+}
+
+// NewBroker creates a broker with a pool of redis connections. The subscription is started
+// by the first subscriber, so processes only publishing events hold no connection for it.
+func NewBroker(pool *redis.Pool) *Broker {
+	return &Broker{
+		pool:        pool,
+		conn:        pool.Get,
+		subscribers: make(map[string]map[chan Event]struct{}),
+	}
+}
+
+// Close stops the subscription of the broker, and the channels of all subscribers are closed
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	if b.psc == nil {
+		return nil
+	}
+
+	// the receiving goroutine closes the connection when the subscription is stopped
+	return b.psc.PUnsubscribe()
+}
+
+// Publish publishes an event to the subscribers of its account
+func (b *Broker) Publish(e Event) error {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	conn := b.pool.Get()
+	defer conn.Close()
+
+	_, err = conn.Do("PUBLISH", channel(e.AccountNumber), data)
+	return err
+}
+
+// Subscribe returns a channel of the events of an account published from now on.
+// The channel is closed when the context is done or the subscription is broken.
+// Events are dropped for a subscriber falling behind, instead of blocking the others.
+func (b *Broker) Subscribe(ctx context.Context, accountNumber string) (<-chan Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, errors.New("broker is closed")
+	}
+
+	if b.psc == nil {
+		psc := &redis.PubSubConn{Conn: b.conn()}
+		if err := psc.PSubscribe(channelPrefix + "*"); err != nil {
+			psc.Close()
+			return nil, err
+		}
+		b.psc = psc
+		go b.receive(psc)
+	}
+
+	events := make(chan Event, subscriberBuffer)
+	if _, ok := b.subscribers[accountNumber]; !ok {
+		b.subscribers[accountNumber] = make(map[chan Event]struct{})
+	}
+	b.subscribers[accountNumber][events] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(accountNumber, events)
+	}()
+
+	return events, nil
+}
+
+// unsubscribe removes a subscriber and closes its channel if it is not closed yet
+func (b *Broker) unsubscribe(accountNumber string, events chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscribers := b.subscribers[accountNumber]
+	if _, ok := subscribers[events]; !ok {
+		return
+	}
+
+	delete(subscribers, events)
+	if len(subscribers) == 0 {
+		delete(b.subscribers, accountNumber)
+	}
+	close(events)
+}
+
+// receive fans events of the subscription out to the subscribers until the subscription
+// is stopped or broken. The subscribers are all closed then, and the next subscriber starts
+// a new subscription.
+func (b *Broker) receive(psc *redis.PubSubConn) {
+	logEntity := log.WithField("prefix", "events")
+
+	defer func() {
+		psc.Close()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.psc = nil
+		for accountNumber, subscribers := range b.subscribers {
+			for events := range subscribers {
+				close(events)
+			}
+			delete(b.subscribers, accountNumber)
+		}
+	}()
+
+	for {
+		// the connection is not timed out while waiting for events
+		switch v := psc.ReceiveWithTimeout(0).(type) {
+		case redis.Message:
+			e, err := decodeMessage(v)
+			if err != nil {
+				logEntity.WithError(err).Warn("ignore invalid event")
+				continue
+			}
+			b.dispatch(e)
+		case redis.Subscription:
+			if v.Count == 0 {
+				return
+			}
+		case error:
+			b.mu.Lock()
+			closed := b.closed
+			b.mu.Unlock()
+
+			if !closed {
+				logEntity.WithError(v).Error("subscription is broken")
+			}
+			return
+		}
+	}
+}
+
+// dispatch sends an event to the subscribers of its account
+func (b *Broker) dispatch(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for events := range b.subscribers[e.AccountNumber] {
+		select {
+		case events <- e:
+		default:
+			log.WithField("prefix", "events").
+				WithField("account_number", e.AccountNumber).
+				Warn("drop the event for a subscriber falling behind")
+		}
+	}
+}
+
+func channel(accountNumber string) string {
+	return channelPrefix + accountNumber
+}
+
+func decodeMessage(m redis.Message) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(m.Data, &e); err != nil {
+		return e, err
+	}
+	e.AccountNumber = strings.TrimPrefix(m.Channel, channelPrefix)
+	return e, nil
+}
@@ -0,0 +1,296 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeMessage(t *testing.T) {
+	e := Event{
+		Type:          TypeArchive,
+		AccountNumber: "account-1",
+		ArchiveID:     10,
+		Status:        "processed",
+		Task:          "notification_finish_parsing",
+		State:         "SUCCESS",
+		Timestamp:     time.Unix(1580000000, 0).UTC(),
+	}
+
+	data, err := json.Marshal(e)
+	assert.NoError(t, err)
+
+	// the account number is carried by the channel only
+	assert.NotContains(t, string(data), "account-1")
+
+	decoded, err := decodeMessage(redis.Message{
+		Channel: channel("account-1"),
+		Data:    data,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, e, decoded)
+
+	_, err = decodeMessage(redis.Message{
+		Channel: channel("account-1"),
+		Data:    []byte("invalid"),
+	})
+	assert.Error(t, err)
+}
+
+// fakeRedis is a redis server supporting PUBLISH and PSUBSCRIBE with patterns of prefixes
+type fakeRedis struct {
+	mu          sync.Mutex
+	subscribers map[*fakeConn]string
+	dials       int
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{subscribers: make(map[*fakeConn]string)}
+}
+
+func (r *fakeRedis) conn() redis.Conn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dials++
+	return &fakeConn{
+		redis:   r,
+		replies: make(chan interface{}, 100),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (r *fakeRedis) pool() *redis.Pool {
+	return &redis.Pool{Dial: func() (redis.Conn, error) { return r.conn(), nil }}
+}
+
+// breakSubscriptions closes the connections of all subscribers
+func (r *fakeRedis) breakSubscriptions() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for c := range r.subscribers {
+		c.Close()
+		delete(r.subscribers, c)
+	}
+}
+
+type fakeConn struct {
+	redis     *fakeRedis
+	replies   chan interface{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *fakeConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *fakeConn) Err() error {
+	return nil
+}
+
+func (c *fakeConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	switch cmd {
+	case "":
+		return nil, nil
+	case "PUBLISH":
+		c.redis.mu.Lock()
+		defer c.redis.mu.Unlock()
+
+		ch := args[0].(string)
+		var count int64
+		for subscriber, pattern := range c.redis.subscribers {
+			if strings.HasPrefix(ch, strings.TrimSuffix(pattern, "*")) {
+				subscriber.replies <- []interface{}{[]byte("pmessage"), []byte(pattern), []byte(ch), args[1]}
+				count++
+			}
+		}
+		return count, nil
+	}
+	return nil, fmt.Errorf("unsupported command %s", cmd)
+}
+
+func (c *fakeConn) DoWithTimeout(timeout time.Duration, cmd string, args ...interface{}) (interface{}, error) {
+	return c.Do(cmd, args...)
+}
+
+func (c *fakeConn) Send(cmd string, args ...interface{}) error {
+	c.redis.mu.Lock()
+	defer c.redis.mu.Unlock()
+
+	switch cmd {
+	case "PSUBSCRIBE":
+		pattern := args[0].(string)
+		c.redis.subscribers[c] = pattern
+		c.replies <- []interface{}{[]byte("psubscribe"), []byte(pattern), int64(1)}
+	case "PUNSUBSCRIBE":
+		pattern := c.redis.subscribers[c]
+		delete(c.redis.subscribers, c)
+		c.replies <- []interface{}{[]byte("punsubscribe"), []byte(pattern), int64(0)}
+	default:
+		return fmt.Errorf("unsupported command %s", cmd)
+	}
+	return nil
+}
+
+func (c *fakeConn) Flush() error {
+	return nil
+}
+
+func (c *fakeConn) Receive() (interface{}, error) {
+	select {
+	case reply := <-c.replies:
+		return reply, nil
+	case <-c.closed:
+		return nil, errors.New("connection closed")
+	}
+}
+
+func (c *fakeConn) ReceiveWithTimeout(timeout time.Duration) (interface{}, error) {
+	return c.Receive()
+}
+
+func newTestBroker(r *fakeRedis) *Broker {
+	b := NewBroker(r.pool())
+	b.conn = r.conn
+	return b
+}
+
+func receiveEvent(t *testing.T, events <-chan Event) (Event, bool) {
+	select {
+	case e, ok := <-events:
+		return e, ok
+	case <-time.After(time.Second):
+		t.Fatal("no event is received")
+		return Event{}, false
+	}
+}
+
+func assertNoEvent(t *testing.T, events <-chan Event) {
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event %v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	r := newFakeRedis()
+	b := newTestBroker(r)
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events1, err := b.Subscribe(ctx, "account-1")
+	assert.NoError(t, err)
+	events1Again, err := b.Subscribe(ctx, "account-1")
+	assert.NoError(t, err)
+	events2, err := b.Subscribe(ctx, "account-2")
+	assert.NoError(t, err)
+
+	// all subscribers share one subscription
+	assert.Equal(t, 1, r.dials)
+
+	assert.NoError(t, b.Publish(Event{
+		Type:          TypeArchive,
+		AccountNumber: "account-1",
+		ArchiveID:     10,
+		Status:        "processed",
+	}))
+
+	// the event is fanned out to the subscribers of its account only
+	for _, events := range []<-chan Event{events1, events1Again} {
+		e, ok := receiveEvent(t, events)
+		if assert.True(t, ok) {
+			assert.Equal(t, "account-1", e.AccountNumber)
+			assert.Equal(t, int64(10), e.ArchiveID)
+			assert.False(t, e.Timestamp.IsZero())
+		}
+	}
+	assertNoEvent(t, events2)
+
+	assert.NoError(t, b.Publish(Event{Type: TypeExport, AccountNumber: "account-2", ExportID: "export-1"}))
+	e, ok := receiveEvent(t, events2)
+	if assert.True(t, ok) {
+		assert.Equal(t, "export-1", e.ExportID)
+	}
+	assertNoEvent(t, events1)
+}
+
+func TestSubscribeContextDone(t *testing.T) {
+	r := newFakeRedis()
+	b := newTestBroker(r)
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := b.Subscribe(ctx, "account-1")
+	assert.NoError(t, err)
+
+	otherEvents, err := b.Subscribe(context.Background(), "account-1")
+	assert.NoError(t, err)
+
+	// the stream is closed when its context is cancelled
+	cancel()
+	_, ok := receiveEvent(t, events)
+	assert.False(t, ok)
+
+	// the other subscribers still receive events
+	assert.NoError(t, b.Publish(Event{Type: TypeArchive, AccountNumber: "account-1"}))
+	_, ok = receiveEvent(t, otherEvents)
+	assert.True(t, ok)
+}
+
+func TestSubscriptionBroken(t *testing.T) {
+	r := newFakeRedis()
+	b := newTestBroker(r)
+	defer b.Close()
+
+	events, err := b.Subscribe(context.Background(), "account-1")
+	assert.NoError(t, err)
+
+	// subscribers are closed when the subscription is broken
+	r.breakSubscriptions()
+	_, ok := receiveEvent(t, events)
+	assert.False(t, ok)
+
+	// the next subscriber starts a new subscription
+	assert.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.psc == nil
+	}, time.Second, 10*time.Millisecond)
+
+	events, err = b.Subscribe(context.Background(), "account-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, r.dials)
+
+	assert.NoError(t, b.Publish(Event{Type: TypeArchive, AccountNumber: "account-1"}))
+	_, ok = receiveEvent(t, events)
+	assert.True(t, ok)
+}
+
+func TestBrokerClose(t *testing.T) {
+	r := newFakeRedis()
+	b := newTestBroker(r)
+
+	events, err := b.Subscribe(context.Background(), "account-1")
+	assert.NoError(t, err)
+
+	assert.NoError(t, b.Close())
+	_, ok := receiveEvent(t, events)
+	assert.False(t, ok)
+
+	_, err = b.Subscribe(context.Background(), "account-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package keyring
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// Config is the config of a key, with its times in RFC 3339
+type Config struct {
+	ID         string `mapstructure:"id"`
+	Keyfile    string `mapstructure:"keyfile"`
+	Password   string `mapstructure:"password"`
+	ActiveFrom string `mapstructure:"active_from"`
+	ExpireAt   string `mapstructure:"expire_at"`
+}
+
+// Load loads the keys of configs from their PEM files and creates a keyring of them
+func Load(configs []Config) (*Keyring, error) {
+	keys := make([]*Key, 0, len(configs))
+	for _, c := range configs {
+		data, err := ioutil.ReadFile(c.Keyfile)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEMWithPassword(data, c.Password)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %s", c.Keyfile, err)
+		}
+
+		key := &Key{ID: c.ID, PrivateKey: privateKey}
+		if key.ActiveFrom, err = parseTime(c.ActiveFrom); err != nil {
+			return nil, fmt.Errorf("key %s: invalid active_from: %s", c.Keyfile, err)
+		}
+		if key.ExpireAt, err = parseTime(c.ExpireAt); err != nil {
+			return nil, fmt.Errorf("key %s: invalid expire_at: %s", c.Keyfile, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return New(keys...)
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
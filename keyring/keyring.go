@@ -0,0 +1,204 @@
+// Package keyring keeps the RSA keys signing and verifying the access tokens of the API.
+//
+// Every key has an id, which is set as the kid header of the tokens it signs, and a time
+// it becomes active from. Tokens are signed with the latest active key, while every key
+// not expired yet verifies the tokens it signed, so sessions outlive a rotation of keys.
+// The public keys are published as a JSON Web Key Set for other services to verify tokens.
+package keyring
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+var (
+	ErrNoKey          = errors.New("no signing key")
+	ErrNoActiveKey    = errors.New("no active signing key")
+	ErrUnknownKey     = errors.New("unknown signing key")
+	ErrKeyExpired     = errors.New("signing key expired")
+	ErrDuplicateKeyID = errors.New("duplicate key id")
+)
+
+// Key is a key signing tokens
+type Key struct {
+	// ID is the kid of the key, the JWK thumbprint of the public key if it is empty
+	ID         string
+	PrivateKey *rsa.PrivateKey
+
+	// ActiveFrom is when the key starts signing tokens. A zero time is active since ever.
+	ActiveFrom time.Time
+
+	// ExpireAt is when the key stops verifying tokens. A zero time never expires.
+	ExpireAt time.Time
+}
+
+func (k *Key) expired(now time.Time) bool {
+	return !k.ExpireAt.IsZero() && !now.Before(k.ExpireAt)
+}
+
+// Sign signs claims with the key and sets the id of the key as the kid header
+func (k *Key) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = k.ID
+
+	return token.SignedString(k.PrivateKey)
+}
+
+// Keyring keeps the keys signing tokens ordered by the time they become active
+type Keyring struct {
+	keys  []*Key
+	first *Key
+	now   func() time.Time
+}
+
+// New creates a keyring of keys
+func New(keys ...*Key) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKey
+	}
+
+	ids := make(map[string]bool, len(keys))
+	sorted := make([]*Key, 0, len(keys))
+	for _, k := range keys {
+		if k.PrivateKey == nil {
+			return nil, fmt.Errorf("key %s has no private key", k.ID)
+		}
+
+		key := *k
+		if key.ID == "" {
+			key.ID = Thumbprint(&key.PrivateKey.PublicKey)
+		}
+		if ids[key.ID] {
+			return nil, ErrDuplicateKeyID
+		}
+		ids[key.ID] = true
+		sorted = append(sorted, &key)
+	}
+
+	first := sorted[0]
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActiveFrom.Before(sorted[j].ActiveFrom)
+	})
+
+	return &Keyring{keys: sorted, first: first, now: time.Now}, nil
+}
+
+// FirstKey returns the first key given to the keyring, whether it is active or not.
+// It does not change when keys are rotated by appending new keys.
+func (k *Keyring) FirstKey() *Key {
+	return k.first
+}
+
+// SigningKey returns the latest active key which is not expired
+func (k *Keyring) SigningKey() (*Key, error) {
+	now := k.now()
+	for i := len(k.keys) - 1; i >= 0; i-- {
+		key := k.keys[i]
+		if key.ActiveFrom.After(now) || key.expired(now) {
+			continue
+		}
+		return key, nil
+	}
+
+	return nil, ErrNoActiveKey
+}
+
+// Keyfunc returns the public key verifying a token by its kid header.
+// Tokens without kid are signed before the keys are rotated, so they are verified with the first key,
+// which is the key configured before rotation.
+func (k *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if k.first.expired(k.now()) {
+			return nil, ErrKeyExpired
+		}
+		return &k.first.PrivateKey.PublicKey, nil
+	}
+
+	for _, key := range k.keys {
+		if key.ID != kid {
+			continue
+		}
+		if key.expired(k.now()) {
+			return nil, ErrKeyExpired
+		}
+		return &key.PrivateKey.PublicKey, nil
+	}
+
+	return nil, ErrUnknownKey
+}
+
+// JSONWebKey is the public key of a key in the format of RFC 7517
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// JSONWebKeySet is a set of public keys
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys which are not expired, including the ones not active yet,
+// so they are known by other services before they sign any token
+func (k *Keyring) JWKS() JSONWebKeySet {
+	now := k.now()
+
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(k.keys))}
+	for _, key := range k.keys {
+		if key.expired(now) {
+			continue
+		}
+
+		n, e := publicKeyParams(&key.PrivateKey.PublicKey)
+		set.Keys = append(set.Keys, JSONWebKey{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			KeyID:     key.ID,
+			N:         n,
+			E:         e,
+		})
+	}
+
+	return set
+}
+
+// Thumbprint returns the JWK thumbprint of a public key in RFC 7638
+func Thumbprint(pub *rsa.PublicKey) string {
+	n, e := publicKeyParams(pub)
+
+	// the members are required to be in lexicographic order without any whitespace
+	b, _ := json.Marshal(struct {
+		E       string `json:"e"`
+		KeyType string `json:"kty"`
+		N       string `json:"n"`
+	}{e, "RSA", n})
+
+	digest := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// publicKeyParams returns the base64url encoded modulus and exponent of a public key
+func publicKeyParams(pub *rsa.PublicKey) (string, string) {
+	n := base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	return n, e
+}
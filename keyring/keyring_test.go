@@ -0,0 +1,167 @@
+package keyring
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPrivateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestKeyring(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	oldKey := &Key{ID: "old", PrivateKey: newPrivateKey(t), ExpireAt: now.Add(time.Hour)}
+	currentKey := &Key{ID: "current", PrivateKey: newPrivateKey(t), ActiveFrom: now.Add(-time.Minute)}
+	nextKey := &Key{ID: "next", PrivateKey: newPrivateKey(t), ActiveFrom: now.Add(24 * time.Hour)}
+
+	k, err := New(nextKey, oldKey, currentKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+	k.now = func() time.Time { return now }
+
+	// the first key given is kept regardless of the order of activation
+	assert.Equal(t, "next", k.FirstKey().ID)
+
+	// the latest active key signs tokens
+	signing, err := k.SigningKey()
+	assert.NoError(t, err)
+	assert.Equal(t, "current", signing.ID)
+
+	tokenString, err := signing.Sign(jwt.StandardClaims{Subject: "account"})
+	assert.NoError(t, err)
+	token, err := jwt.Parse(tokenString, k.Keyfunc)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "current", token.Header["kid"])
+	}
+
+	// tokens of the previous key are verified until the key expires
+	oldTokenString, err := oldKey.Sign(jwt.StandardClaims{Subject: "account"})
+	assert.NoError(t, err)
+	_, err = jwt.Parse(oldTokenString, k.Keyfunc)
+	assert.NoError(t, err)
+
+	// tokens without kid are verified with the first key instead of the signing key
+	noKidTokenString, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{}).SignedString(nextKey.PrivateKey)
+	assert.NoError(t, err)
+	_, err = jwt.Parse(noKidTokenString, k.Keyfunc)
+	assert.NoError(t, err)
+	currentNoKidTokenString, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{}).SignedString(currentKey.PrivateKey)
+	assert.NoError(t, err)
+	_, err = jwt.Parse(currentNoKidTokenString, k.Keyfunc)
+	assert.Error(t, err)
+
+	// tokens of unknown keys and other methods are rejected
+	unknownTokenString, err := (&Key{ID: "unknown", PrivateKey: newPrivateKey(t)}).Sign(jwt.StandardClaims{})
+	assert.NoError(t, err)
+	_, err = jwt.Parse(unknownTokenString, k.Keyfunc)
+	assert.Error(t, err)
+
+	hmacTokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+	_, err = jwt.Parse(hmacTokenString, k.Keyfunc)
+	assert.Error(t, err)
+
+	// all keys not expired are published, including the next one
+	jwks := k.JWKS()
+	if assert.Len(t, jwks.Keys, 3) {
+		assert.Equal(t, "old", jwks.Keys[0].KeyID)
+		assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+		assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+		assert.Equal(t, "AQAB", jwks.Keys[0].E)
+
+		n, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0].N)
+		assert.NoError(t, err)
+		assert.Equal(t, oldKey.PrivateKey.N, new(big.Int).SetBytes(n))
+	}
+
+	// the old key expires and the next key is rotated in
+	now = now.Add(24 * time.Hour)
+	signing, err = k.SigningKey()
+	assert.NoError(t, err)
+	assert.Equal(t, "next", signing.ID)
+
+	_, err = jwt.Parse(oldTokenString, k.Keyfunc)
+	assert.Error(t, err)
+	_, err = jwt.Parse(tokenString, k.Keyfunc)
+	assert.NoError(t, err)
+	assert.Len(t, k.JWKS().Keys, 2)
+}
+
+func TestKeyfuncWithoutKidAfterRotation(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// the key of jwt.keyfile signs tokens without kid before any rotation
+	originalKey := &Key{PrivateKey: newPrivateKey(t)}
+	noKidTokenString, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{Subject: "account"}).SignedString(originalKey.PrivateKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rotatedKey := &Key{ID: "rotated", PrivateKey: newPrivateKey(t), ActiveFrom: now.Add(-time.Minute)}
+	k, err := New(originalKey, rotatedKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+	k.now = func() time.Time { return now }
+
+	signing, err := k.SigningKey()
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated", signing.ID)
+
+	// sessions from before the rotation stay valid
+	_, err = jwt.Parse(noKidTokenString, k.Keyfunc)
+	assert.NoError(t, err)
+
+	// until the original key expires
+	k.first.ExpireAt = now.Add(-time.Second)
+	_, err = jwt.Parse(noKidTokenString, k.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New()
+	assert.Equal(t, ErrNoKey, err)
+
+	privateKey := newPrivateKey(t)
+	k, err := New(&Key{PrivateKey: privateKey})
+	if assert.NoError(t, err) {
+		signing, err := k.SigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, Thumbprint(&privateKey.PublicKey), signing.ID)
+	}
+
+	_, err = New(&Key{ID: "a", PrivateKey: privateKey}, &Key{ID: "a", PrivateKey: newPrivateKey(t)})
+	assert.Equal(t, ErrDuplicateKeyID, err)
+
+	// no key is active yet
+	k, err = New(&Key{PrivateKey: privateKey, ActiveFrom: time.Now().Add(time.Hour)})
+	if assert.NoError(t, err) {
+		_, err = k.SigningKey()
+		assert.Equal(t, ErrNoActiveKey, err)
+	}
+}
+
+func TestThumbprint(t *testing.T) {
+	// the example key of RFC 7638
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", Thumbprint(pub))
+}
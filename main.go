@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,7 +15,6 @@ import (
 	"github.com/RichardKnop/machinery/v1"
 	machinerycnf "github.com/RichardKnop/machinery/v1/config"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/dgrijalva/jwt-go"
 	"github.com/getsentry/sentry-go"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
@@ -27,7 +25,12 @@ import (
 	bitmarksdk "github.com/bitmark-inc/bitmark-sdk-go"
 	"github.com/bitmark-inc/bitmark-sdk-go/account"
 	"github.com/bitmark-inc/spring-app-api/api"
+	"github.com/bitmark-inc/spring-app-api/deadletter"
+	"github.com/bitmark-inc/spring-app-api/events"
+	"github.com/bitmark-inc/spring-app-api/keyring"
 	"github.com/bitmark-inc/spring-app-api/logmodule"
+	"github.com/bitmark-inc/spring-app-api/pipeline"
+	"github.com/bitmark-inc/spring-app-api/redisutil"
 	"github.com/bitmark-inc/spring-app-api/store"
 	"github.com/bitmark-inc/spring-app-api/store/dynamodb"
 	"github.com/bitmark-inc/spring-app-api/store/postgres"
@@ -78,6 +81,8 @@ func loadConfig(file string) {
 
 func main() {
 	var configFile string
+	var apiKeyName, apiKeyScopes string
+	var apiKeyExpiry time.Duration
 
 	initialCtx, cancelInitialization := context.WithCancel(context.Background())
 
@@ -115,6 +120,9 @@ func main() {
 	}()
 
 	flag.StringVar(&configFile, "c", "./config.yaml", "[optional] path of configuration file")
+	flag.StringVar(&apiKeyName, "create-apikey", "", "[optional] create an api key with the name, print the key and exit")
+	flag.StringVar(&apiKeyScopes, "apikey-scopes", "", "[optional] comma separated scopes of the created api key: "+strings.Join(api.APIKeyScopes, ","))
+	flag.DurationVar(&apiKeyExpiry, "apikey-expire", 0, "[optional] duration until the created api key expires, never if it is 0")
 	flag.Parse()
 
 	loadConfig(configFile)
@@ -163,16 +171,22 @@ func main() {
 	}
 	log.WithField("prefix", "init").Info("Initilized aws sdk")
 
-	// Load JWT private key
-	jwtSecretByte, err := ioutil.ReadFile(viper.GetString("jwt.keyfile"))
-	if err != nil {
+	// Load JWT signing keys, or the single key of jwt.keyfile if no key is listed
+	var jwtKeyConfigs []keyring.Config
+	if err := viper.UnmarshalKey("jwt.keys", &jwtKeyConfigs); err != nil {
 		log.Panic(err)
 	}
-	jwtPrivateKey, err := jwt.ParseRSAPrivateKeyFromPEMWithPassword(jwtSecretByte, viper.GetString("jwt.password"))
+	if len(jwtKeyConfigs) == 0 {
+		jwtKeyConfigs = []keyring.Config{{
+			Keyfile:  viper.GetString("jwt.keyfile"),
+			Password: viper.GetString("jwt.password"),
+		}}
+	}
+	jwtKeys, err := keyring.Load(jwtKeyConfigs)
 	if err != nil {
 		log.Panic(err)
 	}
-	log.WithField("prefix", "init").Info("Loaded global jwt key")
+	log.WithField("prefix", "init").Info("Loaded jwt keys")
 
 	// Init db
 	pgstore, err := postgres.NewPGStore(initialCtx)
@@ -182,21 +196,59 @@ func main() {
 	s = pgstore
 	log.WithField("prefix", "init").Info("Initilized db store")
 
+	if apiKeyName != "" {
+		var scopes []string
+		if apiKeyScopes != "" {
+			scopes = strings.Split(apiKeyScopes, ",")
+		}
+		var expireAt *time.Time
+		if apiKeyExpiry > 0 {
+			t := time.Now().Add(apiKeyExpiry)
+			expireAt = &t
+		}
+
+		key, _, err := api.CreateAPIKey(initialCtx, s, apiKeyName, scopes, expireAt)
+		if err != nil {
+			log.Panic(err)
+		}
+		fmt.Println(key)
+		return
+	}
+
 	// Init redis
 	var cnf = &machinerycnf.Config{
 		Broker:        viper.GetString("redis.conn"),
-		DefaultQueue:  "fbm_background",
+		DefaultQueue:  pipeline.QueueDefault,
 		ResultBackend: viper.GetString("redis.conn"),
 	}
 	machineryServer, err := machinery.NewServer(cnf)
 	if err != nil {
 		log.Panic(err)
 	}
-
-	dynamodbStore, err := dynamodb.NewDynamoDBStore(awsConf, viper.GetString("aws.dynamodb.table"))
+	backgroundEnqueuer := pipeline.NewRouter(machineryServer)
+	redisPool, err := redisutil.NewPool(viper.GetString("redis.conn"))
 	if err != nil {
 		log.Panic(err)
 	}
+	eventBroker := events.NewBroker(redisPool)
+	deadLetters := deadletter.NewQueue(redisPool)
+	workflows := pipeline.NewRunner(machineryServer, redisPool)
+
+	// Init fb data store
+	var fbDataStore store.FBDataStore
+	switch viper.GetString("fbdata.store") {
+	case "postgres":
+		fbDataStore = pgstore
+	case "", "dynamodb":
+		dynamodbStore, err := dynamodb.NewDynamoDBStore(awsConf, viper.GetString("aws.dynamodb.table"))
+		if err != nil {
+			log.Panic(err)
+		}
+		fbDataStore = dynamodbStore
+	default:
+		log.Panic("unsupported fbdata store: ", viper.GetString("fbdata.store"))
+	}
+	log.WithField("prefix", "init").Info("Initilized fb data store")
 
 	ormDB, err := gorm.Open("postgres", viper.GetString("orm.conn"))
 	if err != nil {
@@ -205,12 +257,15 @@ func main() {
 
 	// Init http server
 	server = api.NewServer(s,
-		dynamodbStore,
+		fbDataStore,
 		ormDB,
-		jwtPrivateKey,
+		jwtKeys,
 		awsConf,
 		globalAccount,
-		machineryServer)
+		backgroundEnqueuer,
+		eventBroker,
+		deadLetters,
+		workflows)
 	log.WithField("prefix", "init").Info("Initilized http server")
 
 	// Remove initial context
@@ -0,0 +1,105 @@
+package pipeline
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// accountLockExpiration is how long a lock is kept if it is not refreshed, like when its worker dies
+	accountLockExpiration = 5 * time.Minute
+	accountLockRefresh    = time.Minute
+)
+
+var (
+	// the lock is held by an archive, and shared by the jobs of the archive
+	lockScript = redis.NewScript(1, `
+local owner = redis.call('HGET', KEYS[1], 'archive')
+if owner and owner ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'archive', ARGV[1])
+redis.call('HINCRBY', KEYS[1], 'holders', 1)
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return 1
+`)
+
+	unlockScript = redis.NewScript(1, `
+if redis.call('HGET', KEYS[1], 'archive') ~= ARGV[1] then
+	return 0
+end
+if redis.call('HINCRBY', KEYS[1], 'holders', -1) <= 0 then
+	redis.call('DEL', KEYS[1])
+end
+return 1
+`)
+
+	refreshScript = redis.NewScript(1, `
+if redis.call('HGET', KEYS[1], 'archive') ~= ARGV[1] then
+	return 0
+end
+return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+`)
+)
+
+// AccountLocker locks accounts in redis, so that archives of an account are not processed at the same time
+type AccountLocker struct {
+	pool *redis.Pool
+}
+
+// NewAccountLocker creates a locker with a pool of redis connections
+func NewAccountLocker(pool *redis.Pool) *AccountLocker {
+	return &AccountLocker{pool: pool}
+}
+
+// Lock locks an account for an archive, and returns a func to unlock it.
+// Jobs of the same archive share the lock, and ok is false if the account is locked for another archive.
+// The lock is refreshed until it is unlocked.
+func (l *AccountLocker) Lock(accountNumber string, archiveID int64) (unlock func(), ok bool, err error) {
+	key := "spring:account_lock:" + accountNumber
+	owner := strconv.FormatInt(archiveID, 10)
+	ttl := int64(accountLockExpiration / time.Millisecond)
+
+	conn := l.pool.Get()
+	locked, err := redis.Bool(lockScript.Do(conn, key, owner, ttl))
+	conn.Close()
+	if err != nil || !locked {
+		return nil, false, err
+	}
+
+	logEntity := log.WithField("prefix", "account_lock").
+		WithField("account_number", accountNumber).
+		WithField("archive_id", archiveID)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(accountLockRefresh)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				conn := l.pool.Get()
+				if _, err := refreshScript.Do(conn, key, owner, ttl); err != nil {
+					logEntity.WithError(err).Warn("fail to refresh the lock")
+				}
+				conn.Close()
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+
+		conn := l.pool.Get()
+		defer conn.Close()
+		if _, err := unlockScript.Do(conn, key, owner); err != nil {
+			logEntity.WithError(err).Warn("fail to unlock")
+		}
+	}, true, nil
+}
@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"github.com/RichardKnop/machinery/v1/backends/result"
+	"github.com/RichardKnop/machinery/v1/tasks"
+)
+
+// Queues of jobs. Jobs of a queue are consumed by the workers of the queue only,
+// so heavy jobs do not hold up quick jobs of other queues.
+const (
+	QueueIngest      = "fbm_ingest"
+	QueueAnalysis    = "fbm_analysis"
+	QueueExport      = "fbm_export"
+	QueueMaintenance = "fbm_maintenance"
+
+	// QueueDefault is the queue of jobs not routed to any queue, like jobs sent before queues are introduced
+	QueueDefault = "fbm_background"
+)
+
+// Queues are the names of the queues by their keys in the config
+var Queues = map[string]string{
+	"ingest":      QueueIngest,
+	"analysis":    QueueAnalysis,
+	"export":      QueueExport,
+	"maintenance": QueueMaintenance,
+}
+
+// Jobs out of archive workflows
+const (
+	JobPrepareDataExport  = "prepare_data_export"
+	JobDeleteUserData     = "delete_user_data"
+	JobRemoveArchiveData  = "remove_archive_data"
+	JobIssueExportBitmark = "issue_export_bitmark"
+
+	// periodic jobs
+	JobPeriodicArchiveCheck      = "periodic_archive_check"
+	JobExpireDataExports         = "expire_data_exports"
+	JobReconcileDeletingAccounts = "reconcile_deleting_accounts"
+	JobPurgeExpiredTokens        = "purge_expired_tokens"
+)
+
+// jobQueues are the queues of jobs by their names
+var jobQueues = map[string]string{
+	JobDownloadArchive: QueueIngest,
+	JobParseArchive:    QueueIngest,
+
+	JobAnalyzePosts:        QueueAnalysis,
+	JobAnalyzeReactions:    QueueAnalysis,
+	JobAnalyzeComments:     QueueAnalysis,
+	JobAnalyzeSentiments:   QueueAnalysis,
+	JobFinishArchive:       QueueAnalysis,
+	JobExtractTimeMetadata: QueueAnalysis,
+
+	JobPrepareDataExport: QueueExport,
+
+	JobGenerateHashContent: QueueMaintenance,
+	JobNotificationFinish:  QueueMaintenance,
+	JobDeleteUserData:      QueueMaintenance,
+	JobRemoveArchiveData:   QueueMaintenance,
+	JobIssueArchiveBitmark: QueueMaintenance,
+	JobIssueExportBitmark:  QueueMaintenance,
+
+	JobPeriodicArchiveCheck:      QueueMaintenance,
+	JobExpireDataExports:         QueueMaintenance,
+	JobReconcileDeletingAccounts: QueueMaintenance,
+	JobPurgeExpiredTokens:        QueueMaintenance,
+}
+
+// QueueOf returns the queue of a job
+func QueueOf(job string) string {
+	if q, ok := jobQueues[job]; ok {
+		return q
+	}
+	return QueueDefault
+}
+
+// Router sends jobs to their queues
+type Router struct {
+	sender Sender
+}
+
+// NewRouter creates a router sending jobs with a sender, like a machinery server
+func NewRouter(sender Sender) *Router {
+	return &Router{sender: sender}
+}
+
+// SendTask sends a job to its queue
+func (r *Router) SendTask(signature *tasks.Signature) (*result.AsyncResult, error) {
+	signature.RoutingKey = QueueOf(signature.Name)
+	return r.sender.SendTask(signature)
+}
@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueOf(t *testing.T) {
+	assert.Equal(t, QueueIngest, QueueOf(JobParseArchive))
+	assert.Equal(t, QueueAnalysis, QueueOf(JobAnalyzePosts))
+	assert.Equal(t, QueueExport, QueueOf(JobPrepareDataExport))
+	assert.Equal(t, QueueMaintenance, QueueOf(JobNotificationFinish))
+	assert.Equal(t, QueueDefault, QueueOf("unknown_job"))
+
+	// all of the jobs of workflows are routed to queues
+	for _, w := range workflows {
+		for _, step := range w.Steps {
+			for _, job := range step {
+				assert.NotEqual(t, QueueDefault, QueueOf(job), job)
+			}
+		}
+	}
+}
+
+func TestRouter(t *testing.T) {
+	sender := &testSender{}
+	r := NewRouter(sender)
+
+	_, err := r.SendTask(&tasks.Signature{Name: JobDeleteUserData})
+	assert.NoError(t, err)
+	_, err = r.SendTask(&tasks.Signature{Name: JobRemoveArchiveData, RoutingKey: QueueDefault})
+	assert.NoError(t, err)
+
+	assert.Equal(t, QueueMaintenance, sender.sent[0].RoutingKey)
+	assert.Equal(t, QueueMaintenance, sender.sent[1].RoutingKey)
+}
@@ -0,0 +1,166 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/backends/result"
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+)
+
+// Headers of the signatures of workflow jobs
+const (
+	WorkflowIDHeader   = "workflow_id"
+	workflowHeader     = "workflow"
+	workflowStepHeader = "workflow_step"
+	workflowArgsHeader = "workflow_args"
+)
+
+// stepExpiration is how long the progress of a step is kept
+const stepExpiration = 7 * 24 * time.Hour
+
+// Sender sends jobs to the background, like a machinery server
+type Sender interface {
+	SendTask(signature *tasks.Signature) (*result.AsyncResult, error)
+}
+
+// stepCounter counts the jobs of steps which are not done yet
+type stepCounter interface {
+	set(key string, count int) error
+	done(key string) (int, error)
+}
+
+// Runner runs workflows by sending the jobs of their steps to the background
+type Runner struct {
+	sender  Sender
+	counter stepCounter
+}
+
+// NewRunner creates a runner which keeps the progress of workflows in redis
+func NewRunner(sender Sender, pool *redis.Pool) *Runner {
+	return &Runner{
+		sender:  sender,
+		counter: &redisStepCounter{pool: pool},
+	}
+}
+
+// Start starts a workflow and returns its id
+func (r *Runner) Start(w Workflow, args ArchiveArgs) (string, error) {
+	if _, ok := workflows[w.Name]; !ok {
+		return "", fmt.Errorf("unknown workflow: %s", w.Name)
+	}
+
+	id := uuid.New().String()
+	if err := r.sendStep(w, id, 0, args); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Advance records a job of a workflow as succeeded, and starts the next step of the workflow
+// if all of the jobs of the step succeed. Jobs not belonging to workflows are ignored.
+func (r *Runner) Advance(signature *tasks.Signature) error {
+	id, _ := signature.Headers[WorkflowIDHeader].(string)
+	if id == "" {
+		return nil
+	}
+
+	name, _ := signature.Headers[workflowHeader].(string)
+	w, ok := workflows[name]
+	if !ok {
+		return fmt.Errorf("unknown workflow: %s", name)
+	}
+
+	stepHeader, _ := signature.Headers[workflowStepHeader].(string)
+	step, err := strconv.Atoi(stepHeader)
+	if err != nil {
+		return fmt.Errorf("invalid step of workflow %s: %s", id, stepHeader)
+	}
+
+	var args ArchiveArgs
+	argsHeader, _ := signature.Headers[workflowArgsHeader].(string)
+	if err := json.Unmarshal([]byte(argsHeader), &args); err != nil {
+		return err
+	}
+
+	remaining, err := r.counter.done(stepKey(id, step))
+	if err != nil {
+		return err
+	}
+
+	// the step is either not done yet, or done already when a job is run again
+	if remaining != 0 || step+1 >= len(w.Steps) {
+		return nil
+	}
+
+	return r.sendStep(w, id, step+1, args)
+}
+
+// sendStep sends all of the jobs of a step of a workflow
+func (r *Runner) sendStep(w Workflow, id string, step int, args ArchiveArgs) error {
+	encodedArgs, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	jobs := w.Steps[step]
+	if err := r.counter.set(stepKey(id, step), len(jobs)); err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if _, err := r.sender.SendTask(&tasks.Signature{
+			Name:       job,
+			RoutingKey: QueueOf(job),
+			Args:       args.Args(job),
+			Headers: tasks.Headers{
+				WorkflowIDHeader:   id,
+				workflowHeader:     w.Name,
+				workflowStepHeader: strconv.Itoa(step),
+				workflowArgsHeader: string(encodedArgs),
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stepKey(id string, step int) string {
+	return fmt.Sprintf("spring:workflow:%s:%d", id, step)
+}
+
+type redisStepCounter struct {
+	pool *redis.Pool
+}
+
+func (c *redisStepCounter) set(key string, count int) error {
+	conn := c.pool.Get()
+	defer conn.Close()
+
+	_, err := conn.Do("SET", key, count, "EX", int(stepExpiration.Seconds()))
+	return err
+}
+
+// doneScript decreases the count of a step atomically, so the count never goes below zero
+// when a job is run again after its step is done or the step is expired
+var doneScript = redis.NewScript(1, `
+local count = tonumber(redis.call('GET', KEYS[1]))
+if not count or count <= 0 then
+	return -1
+end
+return redis.call('DECR', KEYS[1])
+`)
+
+// done decreases the count of a step and returns the count of the remaining jobs.
+// The count is negative if the step is not found or done already.
+func (c *redisStepCounter) done(key string) (int, error) {
+	conn := c.pool.Get()
+	defer conn.Close()
+
+	return redis.Int(doneScript.Do(conn, key))
+}
@@ -0,0 +1,124 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/RichardKnop/machinery/v1/backends/result"
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSender struct {
+	sent []*tasks.Signature
+}
+
+func (s *testSender) SendTask(signature *tasks.Signature) (*result.AsyncResult, error) {
+	// signatures are encoded when they are sent to the broker
+	data, err := json.Marshal(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var sent tasks.Signature
+	if err := json.Unmarshal(data, &sent); err != nil {
+		return nil, err
+	}
+	s.sent = append(s.sent, &sent)
+	return nil, nil
+}
+
+func (s *testSender) take() []string {
+	names := make([]string, 0, len(s.sent))
+	for _, signature := range s.sent {
+		names = append(names, signature.Name)
+	}
+	return names
+}
+
+type testStepCounter map[string]int
+
+func (c testStepCounter) set(key string, count int) error {
+	c[key] = count
+	return nil
+}
+
+func (c testStepCounter) done(key string) (int, error) {
+	count, ok := c[key]
+	if !ok || count <= 0 {
+		return -1, nil
+	}
+	c[key] = count - 1
+	return count - 1, nil
+}
+
+func TestRunner(t *testing.T) {
+	sender := &testSender{}
+	r := &Runner{sender: sender, counter: testStepCounter{}}
+
+	id, err := r.Start(AnalyzeArchive, ArchiveArgs{AccountNumber: "account", ArchiveID: 10})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, []string{JobAnalyzePosts, JobAnalyzeReactions, JobAnalyzeComments}, sender.take())
+
+	analyses := sender.sent
+	for _, signature := range analyses {
+		assert.Equal(t, id, signature.Headers[WorkflowIDHeader])
+		assert.Equal(t, QueueAnalysis, signature.RoutingKey)
+		assert.Equal(t, []tasks.Arg{
+			{Type: "string", Value: "account"},
+			// numbers are decoded from json when jobs are consumed
+			{Type: "int64", Value: float64(10)},
+		}, signature.Args)
+	}
+
+	// the next step starts when all of the jobs of the step succeed
+	sender.sent = nil
+	assert.NoError(t, r.Advance(analyses[0]))
+	assert.NoError(t, r.Advance(analyses[2]))
+	assert.Empty(t, sender.sent)
+
+	assert.NoError(t, r.Advance(analyses[1]))
+	assert.Equal(t, []string{JobFinishArchive}, sender.take())
+
+	// a job run again does not start the next step again
+	finish := sender.sent[0]
+	sender.sent = nil
+	assert.NoError(t, r.Advance(analyses[1]))
+	assert.Empty(t, sender.sent)
+
+	assert.NoError(t, r.Advance(finish))
+	assert.Equal(t, []string{JobExtractTimeMetadata, JobAnalyzeSentiments, JobNotificationFinish}, sender.take())
+
+	// the workflow ends after the last step
+	last := sender.sent
+	sender.sent = nil
+	for _, signature := range last {
+		assert.NoError(t, r.Advance(signature))
+	}
+	assert.Empty(t, sender.sent)
+
+	// jobs out of workflows are ignored
+	assert.NoError(t, r.Advance(&tasks.Signature{Name: JobAnalyzePosts}))
+	assert.Empty(t, sender.sent)
+}
+
+func TestArchiveArgs(t *testing.T) {
+	args := ArchiveArgs{
+		AccountNumber: "account",
+		ArchiveID:     10,
+		ArchiveType:   "facebook",
+		S3Key:         "account/facebook/archives/10/archive.zip",
+	}
+
+	assert.Equal(t, []tasks.Arg{
+		{Type: "string", Value: "facebook"},
+		{Type: "string", Value: "account"},
+		{Type: "int64", Value: int64(10)},
+	}, args.Args(JobParseArchive))
+
+	assert.Equal(t, []tasks.Arg{
+		{Type: "string", Value: "account/facebook/archives/10/archive.zip"},
+		{Type: "int64", Value: int64(10)},
+	}, args.Args(JobGenerateHashContent))
+}
@@ -0,0 +1,123 @@
+package pipeline
+
+import (
+	"github.com/RichardKnop/machinery/v1/tasks"
+)
+
+// Jobs of archive workflows
+const (
+	JobDownloadArchive     = "download_archive"
+	JobParseArchive        = "parse_archive"
+	JobGenerateHashContent = "generate_hash_content"
+	JobAnalyzePosts        = "analyze_posts"
+	JobAnalyzeReactions    = "analyze_reactions"
+	JobAnalyzeComments     = "analyze_comments"
+	JobAnalyzeSentiments   = "analyze_sentiments"
+	JobFinishArchive       = "finish_archive"
+	JobExtractTimeMetadata = "extract_time_metadata"
+	JobNotificationFinish  = "notification_finish_parsing"
+	JobIssueArchiveBitmark = "issue_archive_bitmark"
+)
+
+// ArchiveArgs are the arguments shared by the jobs of an archive workflow
+type ArchiveArgs struct {
+	AccountNumber string `json:"account_number"`
+	ArchiveID     int64  `json:"archive_id"`
+	ArchiveType   string `json:"archive_type,omitempty"`
+	FileURL       string `json:"file_url,omitempty"`
+	RawCookie     string `json:"raw_cookie,omitempty"`
+	S3Key         string `json:"s3_key,omitempty"`
+}
+
+// jobArgs builds the arguments of jobs in the order of the parameters of their tasks.
+// Jobs not listed take the account number and the archive id.
+var jobArgs = map[string]func(a ArchiveArgs) []tasks.Arg{
+	JobDownloadArchive: func(a ArchiveArgs) []tasks.Arg {
+		return []tasks.Arg{
+			{Type: "string", Value: a.FileURL},
+			{Type: "string", Value: a.ArchiveType},
+			{Type: "string", Value: a.RawCookie},
+			{Type: "string", Value: a.AccountNumber},
+			{Type: "int64", Value: a.ArchiveID},
+		}
+	},
+	JobParseArchive: func(a ArchiveArgs) []tasks.Arg {
+		return []tasks.Arg{
+			{Type: "string", Value: a.ArchiveType},
+			{Type: "string", Value: a.AccountNumber},
+			{Type: "int64", Value: a.ArchiveID},
+		}
+	},
+	JobGenerateHashContent: func(a ArchiveArgs) []tasks.Arg {
+		return []tasks.Arg{
+			{Type: "string", Value: a.S3Key},
+			{Type: "int64", Value: a.ArchiveID},
+		}
+	},
+}
+
+// Args returns the arguments of a job
+func (a ArchiveArgs) Args(job string) []tasks.Arg {
+	if f, ok := jobArgs[job]; ok {
+		return f(a)
+	}
+
+	return []tasks.Arg{
+		{Type: "string", Value: a.AccountNumber},
+		{Type: "int64", Value: a.ArchiveID},
+	}
+}
+
+// Step is a set of jobs of a workflow running in parallel
+type Step []string
+
+// Workflow is a sequence of steps of jobs.
+// A step starts when all of the jobs of the previous step succeed.
+type Workflow struct {
+	Name  string
+	Steps []Step
+}
+
+var (
+	// DownloadArchive downloads an archive from a link and imports it
+	DownloadArchive = Workflow{
+		Name: "download_archive",
+		Steps: []Step{
+			{JobDownloadArchive},
+			{JobParseArchive},
+			{JobAnalyzePosts, JobAnalyzeReactions, JobAnalyzeComments},
+			{JobFinishArchive},
+			{JobExtractTimeMetadata, JobAnalyzeSentiments, JobNotificationFinish},
+			{JobIssueArchiveBitmark},
+		},
+	}
+
+	// ImportArchive imports an archive uploaded to s3
+	ImportArchive = Workflow{
+		Name: "import_archive",
+		Steps: []Step{
+			{JobParseArchive},
+			{JobAnalyzePosts, JobAnalyzeReactions, JobAnalyzeComments},
+			{JobFinishArchive},
+			{JobExtractTimeMetadata, JobAnalyzeSentiments, JobNotificationFinish, JobGenerateHashContent},
+			{JobIssueArchiveBitmark},
+		},
+	}
+
+	// AnalyzeArchive computes the stats of the data of an imported archive again
+	AnalyzeArchive = Workflow{
+		Name: "analyze_archive",
+		Steps: []Step{
+			{JobAnalyzePosts, JobAnalyzeReactions, JobAnalyzeComments},
+			{JobFinishArchive},
+			{JobExtractTimeMetadata, JobAnalyzeSentiments, JobNotificationFinish},
+		},
+	}
+)
+
+// workflows are all of the workflows by their names
+var workflows = map[string]Workflow{
+	DownloadArchive.Name: DownloadArchive,
+	ImportArchive.Name:   ImportArchive,
+	AnalyzeArchive.Name:  AnalyzeArchive,
+}
@@ -0,0 +1,851 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: comment.proto
+
+/*
+	Package protomodel is a generated protocol buffer package.
+
+	It is generated from these files:
+		comment.proto
+
+	It has these top-level messages:
+		Comment
+		CommentsResponse
+*/
+package protomodel
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import _ "github.com/gogo/protobuf/gogoproto"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Comment struct {
+	CommentId string       `protobuf:"bytes,1,opt,name=comment_id,proto3" json:"comment_id"`
+	Timestamp int64        `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp"`
+	Author    string       `protobuf:"bytes,3,opt,name=author,proto3" json:"author"`
+	Comment   string       `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment"`
+	Type      string       `protobuf:"bytes,5,opt,name=type,proto3" json:"type"`
+	Url       string       `protobuf:"bytes,6,opt,name=url,proto3" json:"url"`
+	MediaData []*MediaData `protobuf:"bytes,7,rep,name=mediaData" json:"mediaData"`
+	Source    string       `protobuf:"bytes,8,opt,name=source,proto3" json:"source"`
+}
+
+func (m *Comment) Reset()                    { *m = Comment{} }
+func (m *Comment) String() string            { return proto.CompactTextString(m) }
+func (*Comment) ProtoMessage()               {}
+func (*Comment) Descriptor() ([]byte, []int) { return fileDescriptorComment, []int{0} }
+
+func (m *Comment) GetCommentId() string {
+	if m != nil {
+		return m.CommentId
+	}
+	return ""
+}
+
+func (m *Comment) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Comment) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Comment) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *Comment) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Comment) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Comment) GetMediaData() []*MediaData {
+	if m != nil {
+		return m.MediaData
+	}
+	return nil
+}
+
+func (m *Comment) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type CommentsResponse struct {
+	Result     []*Comment `protobuf:"bytes,1,rep,name=result" json:"result"`
+	NextCursor string     `protobuf:"bytes,2,opt,name=next_cursor,proto3" json:"next_cursor"`
+}
+
+func (m *CommentsResponse) Reset()                    { *m = CommentsResponse{} }
+func (m *CommentsResponse) String() string            { return proto.CompactTextString(m) }
+func (*CommentsResponse) ProtoMessage()               {}
+func (*CommentsResponse) Descriptor() ([]byte, []int) { return fileDescriptorComment, []int{1} }
+
+func (m *CommentsResponse) GetResult() []*Comment {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *CommentsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Comment)(nil), "Comment")
+	proto.RegisterType((*CommentsResponse)(nil), "CommentsResponse")
+}
+func (m *Comment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Comment) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.CommentId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintComment(dAtA, i, uint64(len(m.CommentId)))
+		i += copy(dAtA[i:], m.CommentId)
+	}
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintComment(dAtA, i, uint64(m.Timestamp))
+	}
+	if len(m.Author) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintComment(dAtA, i, uint64(len(m.Author)))
+		i += copy(dAtA[i:], m.Author)
+	}
+	if len(m.Comment) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintComment(dAtA, i, uint64(len(m.Comment)))
+		i += copy(dAtA[i:], m.Comment)
+	}
+	if len(m.Type) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintComment(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	if len(m.Url) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintComment(dAtA, i, uint64(len(m.Url)))
+		i += copy(dAtA[i:], m.Url)
+	}
+	if len(m.MediaData) > 0 {
+		for _, msg := range m.MediaData {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintComment(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Source) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintComment(dAtA, i, uint64(len(m.Source)))
+		i += copy(dAtA[i:], m.Source)
+	}
+	return i, nil
+}
+
+func (m *CommentsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommentsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for _, msg := range m.Result {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintComment(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.NextCursor) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintComment(dAtA, i, uint64(len(m.NextCursor)))
+		i += copy(dAtA[i:], m.NextCursor)
+	}
+	return i, nil
+}
+
+func encodeFixed64Comment(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Comment(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintComment(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Comment) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.CommentId)
+	if l > 0 {
+		n += 1 + l + sovComment(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovComment(uint64(m.Timestamp))
+	}
+	l = len(m.Author)
+	if l > 0 {
+		n += 1 + l + sovComment(uint64(l))
+	}
+	l = len(m.Comment)
+	if l > 0 {
+		n += 1 + l + sovComment(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovComment(uint64(l))
+	}
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovComment(uint64(l))
+	}
+	if len(m.MediaData) > 0 {
+		for _, e := range m.MediaData {
+			l = e.Size()
+			n += 1 + l + sovComment(uint64(l))
+		}
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovComment(uint64(l))
+	}
+	return n
+}
+
+func (m *CommentsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for _, e := range m.Result {
+			l = e.Size()
+			n += 1 + l + sovComment(uint64(l))
+		}
+	}
+	l = len(m.NextCursor)
+	if l > 0 {
+		n += 1 + l + sovComment(uint64(l))
+	}
+	return n
+}
+
+func sovComment(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozComment(x uint64) (n int) {
+	return sovComment(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Comment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowComment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Comment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Comment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommentId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CommentId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Author", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Author = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Comment", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Comment = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Url = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MediaData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MediaData = append(m.MediaData, &MediaData{})
+			if err := m.MediaData[len(m.MediaData)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipComment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthComment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommentsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowComment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommentsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommentsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Result = append(m.Result, &Comment{})
+			if err := m.Result[len(m.Result)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthComment
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextCursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipComment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthComment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipComment(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowComment
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowComment
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthComment
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowComment
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipComment(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthComment = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowComment   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() { proto.RegisterFile("comment.proto", fileDescriptorComment) }
+
+var fileDescriptorComment = []byte{
+	// 337 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x51, 0xcd, 0x4a, 0xf3, 0x40,
+	0x14, 0xfd, 0xd2, 0xf4, 0x4b, 0x9a, 0x5b, 0xaa, 0x65, 0x56, 0xa3, 0x48, 0x52, 0x0a, 0x42, 0x41,
+	0x4d, 0x51, 0x17, 0xee, 0xa3, 0x5b, 0x37, 0xf3, 0x02, 0x25, 0x4d, 0xc7, 0x36, 0xd0, 0x74, 0xc2,
+	0xfc, 0x80, 0xbe, 0x89, 0x6f, 0xa4, 0x4b, 0x9f, 0x60, 0x90, 0xba, 0x9b, 0xa7, 0x90, 0x4e, 0xa6,
+	0x26, 0x9b, 0x7b, 0xcf, 0x39, 0xf7, 0xe4, 0xe4, 0x72, 0x07, 0x46, 0x05, 0xab, 0x2a, 0xba, 0x93,
+	0x69, 0xcd, 0x99, 0x64, 0xe7, 0x37, 0xeb, 0x52, 0x6e, 0xd4, 0x32, 0x2d, 0x58, 0x35, 0x5f, 0xb3,
+	0x35, 0x9b, 0x5b, 0x79, 0xa9, 0x5e, 0x2c, 0xb3, 0xc4, 0x22, 0x67, 0x87, 0x9a, 0x09, 0xf7, 0xe9,
+	0xf4, 0xa3, 0x07, 0xe1, 0x63, 0x13, 0x86, 0x52, 0x00, 0x97, 0xbb, 0x28, 0x57, 0xd8, 0x9b, 0x78,
+	0xb3, 0x28, 0x3b, 0x31, 0x3a, 0xe9, 0xa8, 0xa4, 0x83, 0xd1, 0x15, 0x44, 0xb2, 0xac, 0xa8, 0x90,
+	0x79, 0x55, 0xe3, 0xde, 0xc4, 0x9b, 0xf9, 0xd9, 0xc8, 0xe8, 0xa4, 0x15, 0x49, 0x0b, 0xd1, 0x14,
+	0x82, 0x5c, 0xc9, 0x0d, 0xe3, 0xd8, 0xb7, 0xc1, 0x60, 0x74, 0xe2, 0x14, 0xe2, 0x3a, 0xba, 0x84,
+	0xd0, 0xc5, 0xe3, 0xbe, 0x35, 0x0d, 0x8d, 0x4e, 0x8e, 0x12, 0x39, 0x02, 0x74, 0x01, 0x7d, 0xf9,
+	0x56, 0x53, 0xfc, 0xdf, 0x7a, 0x06, 0x46, 0x27, 0x96, 0x13, 0x5b, 0xd1, 0x19, 0xf8, 0x8a, 0x6f,
+	0x71, 0x60, 0x87, 0xa1, 0xd1, 0xc9, 0x81, 0x92, 0x43, 0x41, 0x0f, 0x10, 0x55, 0x74, 0x55, 0xe6,
+	0x4f, 0xb9, 0xcc, 0x71, 0x38, 0xf1, 0x67, 0xc3, 0x3b, 0x48, 0x9f, 0x8f, 0x4a, 0xb3, 0xfc, 0x9f,
+	0x81, 0xb4, 0xf0, 0xb0, 0xbc, 0x60, 0x8a, 0x17, 0x14, 0x0f, 0xda, 0xe5, 0x1b, 0x85, 0xb8, 0x3e,
+	0x15, 0x30, 0x76, 0x87, 0x14, 0x84, 0x8a, 0x9a, 0xed, 0x04, 0x45, 0xd7, 0x10, 0x70, 0x2a, 0xd4,
+	0x56, 0x62, 0xcf, 0xfe, 0x6d, 0x90, 0x3a, 0x4b, 0x93, 0xd0, 0xcc, 0x88, 0xeb, 0xe8, 0x16, 0x86,
+	0x3b, 0xfa, 0x2a, 0x17, 0x85, 0xe2, 0x82, 0x71, 0x7b, 0xd1, 0x28, 0x3b, 0x35, 0x3a, 0xe9, 0xca,
+	0xa4, 0x4b, 0xb2, 0xf1, 0xe7, 0x3e, 0xf6, 0xbe, 0xf6, 0xb1, 0xf7, 0xbd, 0x8f, 0xbd, 0xf7, 0x9f,
+	0xf8, 0xdf, 0x32, 0xb0, 0xef, 0x7a, 0xff, 0x3b, 0x00, 0x86, 0x5b, 0xe8, 0x4b, 0x23, 0x02, 0x00,
+	0x00,
+}
@@ -0,0 +1,1322 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: friend.proto
+
+/*
+	Package protomodel is a generated protocol buffer package.
+
+	It is generated from these files:
+		friend.proto
+
+	It has these top-level messages:
+		Friend
+		FriendsResponse
+		PeriodCount
+		FriendDetail
+		FriendDetailResponse
+*/
+package protomodel
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import _ "github.com/gogo/protobuf/gogoproto"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Friend struct {
+	Id                 string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Name               string `protobuf:"bytes,2,opt,name=name,proto3" json:"name"`
+	FriendedAt         int64  `protobuf:"varint,3,opt,name=friended_at,proto3" json:"friended_at"`
+	TagCount           int64  `protobuf:"varint,4,opt,name=tag_count,proto3" json:"tag_count"`
+	FirstInteractionAt int64  `protobuf:"varint,5,opt,name=first_interaction_at,proto3" json:"first_interaction_at"`
+	LastInteractionAt  int64  `protobuf:"varint,6,opt,name=last_interaction_at,proto3" json:"last_interaction_at"`
+}
+
+func (m *Friend) Reset()                    { *m = Friend{} }
+func (m *Friend) String() string            { return proto.CompactTextString(m) }
+func (*Friend) ProtoMessage()               {}
+func (*Friend) Descriptor() ([]byte, []int) { return fileDescriptorFriend, []int{0} }
+
+func (m *Friend) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Friend) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Friend) GetFriendedAt() int64 {
+	if m != nil {
+		return m.FriendedAt
+	}
+	return 0
+}
+
+func (m *Friend) GetTagCount() int64 {
+	if m != nil {
+		return m.TagCount
+	}
+	return 0
+}
+
+func (m *Friend) GetFirstInteractionAt() int64 {
+	if m != nil {
+		return m.FirstInteractionAt
+	}
+	return 0
+}
+
+func (m *Friend) GetLastInteractionAt() int64 {
+	if m != nil {
+		return m.LastInteractionAt
+	}
+	return 0
+}
+
+type FriendsResponse struct {
+	Result []*Friend `protobuf:"bytes,1,rep,name=result" json:"result"`
+}
+
+func (m *FriendsResponse) Reset()                    { *m = FriendsResponse{} }
+func (m *FriendsResponse) String() string            { return proto.CompactTextString(m) }
+func (*FriendsResponse) ProtoMessage()               {}
+func (*FriendsResponse) Descriptor() ([]byte, []int) { return fileDescriptorFriend, []int{1} }
+
+func (m *FriendsResponse) GetResult() []*Friend {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type PeriodCount struct {
+	PeriodStartedAt int64 `protobuf:"varint,1,opt,name=period_started_at,proto3" json:"period_started_at"`
+	Count           int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count"`
+}
+
+func (m *PeriodCount) Reset()                    { *m = PeriodCount{} }
+func (m *PeriodCount) String() string            { return proto.CompactTextString(m) }
+func (*PeriodCount) ProtoMessage()               {}
+func (*PeriodCount) Descriptor() ([]byte, []int) { return fileDescriptorFriend, []int{2} }
+
+func (m *PeriodCount) GetPeriodStartedAt() int64 {
+	if m != nil {
+		return m.PeriodStartedAt
+	}
+	return 0
+}
+
+func (m *PeriodCount) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type FriendDetail struct {
+	Friend     *Friend        `protobuf:"bytes,1,opt,name=friend" json:"friend"`
+	WeeklyTags []*PeriodCount `protobuf:"bytes,2,rep,name=weekly_tags" json:"weekly_tags"`
+	YearlyTags []*PeriodCount `protobuf:"bytes,3,rep,name=yearly_tags" json:"yearly_tags"`
+	Posts      []*Post        `protobuf:"bytes,4,rep,name=posts" json:"posts"`
+}
+
+func (m *FriendDetail) Reset()                    { *m = FriendDetail{} }
+func (m *FriendDetail) String() string            { return proto.CompactTextString(m) }
+func (*FriendDetail) ProtoMessage()               {}
+func (*FriendDetail) Descriptor() ([]byte, []int) { return fileDescriptorFriend, []int{3} }
+
+func (m *FriendDetail) GetFriend() *Friend {
+	if m != nil {
+		return m.Friend
+	}
+	return nil
+}
+
+func (m *FriendDetail) GetWeeklyTags() []*PeriodCount {
+	if m != nil {
+		return m.WeeklyTags
+	}
+	return nil
+}
+
+func (m *FriendDetail) GetYearlyTags() []*PeriodCount {
+	if m != nil {
+		return m.YearlyTags
+	}
+	return nil
+}
+
+func (m *FriendDetail) GetPosts() []*Post {
+	if m != nil {
+		return m.Posts
+	}
+	return nil
+}
+
+type FriendDetailResponse struct {
+	Result     *FriendDetail `protobuf:"bytes,1,opt,name=result" json:"result"`
+	NextCursor string        `protobuf:"bytes,2,opt,name=next_cursor,proto3" json:"next_cursor"`
+}
+
+func (m *FriendDetailResponse) Reset()                    { *m = FriendDetailResponse{} }
+func (m *FriendDetailResponse) String() string            { return proto.CompactTextString(m) }
+func (*FriendDetailResponse) ProtoMessage()               {}
+func (*FriendDetailResponse) Descriptor() ([]byte, []int) { return fileDescriptorFriend, []int{4} }
+
+func (m *FriendDetailResponse) GetResult() *FriendDetail {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *FriendDetailResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Friend)(nil), "Friend")
+	proto.RegisterType((*FriendsResponse)(nil), "FriendsResponse")
+	proto.RegisterType((*PeriodCount)(nil), "PeriodCount")
+	proto.RegisterType((*FriendDetail)(nil), "FriendDetail")
+	proto.RegisterType((*FriendDetailResponse)(nil), "FriendDetailResponse")
+}
+func (m *Friend) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Friend) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.FriendedAt != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(m.FriendedAt))
+	}
+	if m.TagCount != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(m.TagCount))
+	}
+	if m.FirstInteractionAt != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(m.FirstInteractionAt))
+	}
+	if m.LastInteractionAt != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(m.LastInteractionAt))
+	}
+	return i, nil
+}
+
+func (m *FriendsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FriendsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for _, msg := range m.Result {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintFriend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PeriodCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeriodCount) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.PeriodStartedAt != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(m.PeriodStartedAt))
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(m.Count))
+	}
+	return i, nil
+}
+
+func (m *FriendDetail) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FriendDetail) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Friend != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(m.Friend.Size()))
+		n1, err := m.Friend.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if len(m.WeeklyTags) > 0 {
+		for _, msg := range m.WeeklyTags {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintFriend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.YearlyTags) > 0 {
+		for _, msg := range m.YearlyTags {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintFriend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Posts) > 0 {
+		for _, msg := range m.Posts {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintFriend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *FriendDetailResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FriendDetailResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Result != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(m.Result.Size()))
+		n2, err := m.Result.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if len(m.NextCursor) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFriend(dAtA, i, uint64(len(m.NextCursor)))
+		i += copy(dAtA[i:], m.NextCursor)
+	}
+	return i, nil
+}
+
+func encodeFixed64Friend(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Friend(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintFriend(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Friend) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFriend(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFriend(uint64(l))
+	}
+	if m.FriendedAt != 0 {
+		n += 1 + sovFriend(uint64(m.FriendedAt))
+	}
+	if m.TagCount != 0 {
+		n += 1 + sovFriend(uint64(m.TagCount))
+	}
+	if m.FirstInteractionAt != 0 {
+		n += 1 + sovFriend(uint64(m.FirstInteractionAt))
+	}
+	if m.LastInteractionAt != 0 {
+		n += 1 + sovFriend(uint64(m.LastInteractionAt))
+	}
+	return n
+}
+
+func (m *FriendsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for _, e := range m.Result {
+			l = e.Size()
+			n += 1 + l + sovFriend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PeriodCount) Size() (n int) {
+	var l int
+	_ = l
+	if m.PeriodStartedAt != 0 {
+		n += 1 + sovFriend(uint64(m.PeriodStartedAt))
+	}
+	if m.Count != 0 {
+		n += 1 + sovFriend(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *FriendDetail) Size() (n int) {
+	var l int
+	_ = l
+	if m.Friend != nil {
+		l = m.Friend.Size()
+		n += 1 + l + sovFriend(uint64(l))
+	}
+	if len(m.WeeklyTags) > 0 {
+		for _, e := range m.WeeklyTags {
+			l = e.Size()
+			n += 1 + l + sovFriend(uint64(l))
+		}
+	}
+	if len(m.YearlyTags) > 0 {
+		for _, e := range m.YearlyTags {
+			l = e.Size()
+			n += 1 + l + sovFriend(uint64(l))
+		}
+	}
+	if len(m.Posts) > 0 {
+		for _, e := range m.Posts {
+			l = e.Size()
+			n += 1 + l + sovFriend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *FriendDetailResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Result != nil {
+		l = m.Result.Size()
+		n += 1 + l + sovFriend(uint64(l))
+	}
+	l = len(m.NextCursor)
+	if l > 0 {
+		n += 1 + l + sovFriend(uint64(l))
+	}
+	return n
+}
+
+func sovFriend(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozFriend(x uint64) (n int) {
+	return sovFriend(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Friend) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFriend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Friend: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Friend: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FriendedAt", wireType)
+			}
+			m.FriendedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FriendedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TagCount", wireType)
+			}
+			m.TagCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TagCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstInteractionAt", wireType)
+			}
+			m.FirstInteractionAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstInteractionAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastInteractionAt", wireType)
+			}
+			m.LastInteractionAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastInteractionAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFriend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFriend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FriendsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFriend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FriendsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FriendsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Result = append(m.Result, &Friend{})
+			if err := m.Result[len(m.Result)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFriend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFriend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeriodCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFriend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeriodCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeriodCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeriodStartedAt", wireType)
+			}
+			m.PeriodStartedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PeriodStartedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFriend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFriend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FriendDetail) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFriend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FriendDetail: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FriendDetail: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Friend", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Friend == nil {
+				m.Friend = &Friend{}
+			}
+			if err := m.Friend.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WeeklyTags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WeeklyTags = append(m.WeeklyTags, &PeriodCount{})
+			if err := m.WeeklyTags[len(m.WeeklyTags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field YearlyTags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.YearlyTags = append(m.YearlyTags, &PeriodCount{})
+			if err := m.YearlyTags[len(m.YearlyTags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Posts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Posts = append(m.Posts, &Post{})
+			if err := m.Posts[len(m.Posts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFriend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFriend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FriendDetailResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFriend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FriendDetailResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FriendDetailResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Result == nil {
+				m.Result = &FriendDetail{}
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFriend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextCursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFriend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFriend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipFriend(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowFriend
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFriend
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthFriend
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowFriend
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipFriend(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthFriend = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowFriend   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() { proto.RegisterFile("friend.proto", fileDescriptorFriend) }
+
+var fileDescriptorFriend = []byte{
+	// 472 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xdd, 0x8a, 0xd3, 0x40,
+	0x14, 0x36, 0x69, 0x1b, 0xed, 0x69, 0x97, 0xd5, 0x71, 0xd5, 0x20, 0xd2, 0x29, 0xb9, 0x90, 0xc2,
+	0x62, 0x96, 0xd5, 0x7b, 0x85, 0xae, 0x08, 0x82, 0x17, 0xcb, 0xbc, 0x40, 0x48, 0x9b, 0x69, 0x1c,
+	0xec, 0x66, 0xca, 0xcc, 0x04, 0x5d, 0xf0, 0x41, 0x7c, 0x13, 0x5f, 0xc1, 0x4b, 0x9f, 0x20, 0x48,
+	0xc5, 0x9b, 0x79, 0x0a, 0xc9, 0x99, 0xe8, 0x0e, 0x36, 0xde, 0xe4, 0xcc, 0xf7, 0x9d, 0x9f, 0x9c,
+	0xef, 0x9c, 0x19, 0x98, 0x6e, 0x94, 0xe0, 0x55, 0x91, 0xee, 0x94, 0x34, 0xf2, 0xf1, 0xb3, 0x52,
+	0x98, 0xf7, 0xf5, 0x2a, 0x5d, 0xcb, 0xab, 0xb3, 0x52, 0x96, 0xf2, 0x0c, 0xe9, 0x55, 0xbd, 0x41,
+	0x84, 0x00, 0x4f, 0x5d, 0x38, 0xec, 0xa4, 0x36, 0xee, 0x9c, 0x7c, 0x0d, 0x21, 0x7a, 0x83, 0xb5,
+	0xc8, 0x43, 0x08, 0x45, 0x11, 0x07, 0xf3, 0x60, 0x31, 0x5e, 0x46, 0xb6, 0xa1, 0xa1, 0x28, 0x58,
+	0x28, 0x0a, 0xf2, 0x04, 0x86, 0x55, 0x7e, 0xc5, 0xe3, 0x10, 0x3d, 0x77, 0x6c, 0x43, 0x11, 0x33,
+	0xfc, 0x92, 0x73, 0x98, 0xb8, 0x5e, 0x78, 0x91, 0xe5, 0x26, 0x1e, 0xcc, 0x83, 0xc5, 0x60, 0x79,
+	0x6c, 0x1b, 0xea, 0xd3, 0xcc, 0x07, 0xe4, 0x14, 0xc6, 0x26, 0x2f, 0xb3, 0xb5, 0xac, 0x2b, 0x13,
+	0x0f, 0x31, 0xe1, 0xc8, 0x36, 0xf4, 0x86, 0x64, 0x37, 0x47, 0xf2, 0x0e, 0x4e, 0x36, 0x42, 0x69,
+	0x93, 0x89, 0xca, 0x70, 0x95, 0xaf, 0x8d, 0x90, 0x55, 0xfb, 0xa3, 0x11, 0xe6, 0xc5, 0xb6, 0xa1,
+	0xbd, 0x7e, 0xd6, 0xcb, 0x92, 0xb7, 0x70, 0x7f, 0x9b, 0x1f, 0x16, 0x8b, 0xb0, 0xd8, 0x23, 0xdb,
+	0xd0, 0x3e, 0x37, 0xeb, 0x23, 0x93, 0x97, 0x70, 0xec, 0x06, 0xa7, 0x19, 0xd7, 0x3b, 0x59, 0x69,
+	0x4e, 0x4e, 0x21, 0x52, 0x5c, 0xd7, 0x5b, 0x13, 0x07, 0xf3, 0xc1, 0x62, 0xf2, 0xfc, 0x76, 0xea,
+	0x22, 0x96, 0x60, 0x1b, 0xda, 0xb9, 0x58, 0x67, 0x13, 0x0d, 0x93, 0x4b, 0xae, 0x84, 0x2c, 0x2e,
+	0x50, 0xe7, 0x05, 0xdc, 0xdb, 0x21, 0xcc, 0xb4, 0xc9, 0x95, 0x71, 0xd3, 0x0c, 0xb0, 0xaf, 0x07,
+	0xb6, 0xa1, 0x87, 0x4e, 0x76, 0x48, 0x11, 0x0a, 0x23, 0x37, 0xd5, 0x10, 0x13, 0xc7, 0xb6, 0xa1,
+	0x8e, 0x60, 0xce, 0x24, 0xbf, 0x02, 0x98, 0xba, 0x9e, 0x5e, 0x73, 0x93, 0x8b, 0x6d, 0xdb, 0xb2,
+	0x5b, 0x0d, 0xfe, 0xeb, 0xdf, 0x96, 0x9d, 0x8b, 0x75, 0x96, 0xbc, 0x82, 0xc9, 0x47, 0xce, 0x3f,
+	0x6c, 0xaf, 0x33, 0x93, 0x97, 0x3a, 0x0e, 0x51, 0xe4, 0x34, 0xf5, 0x64, 0xb8, 0xcd, 0x7b, 0x41,
+	0xcc, 0x07, 0x6d, 0x81, 0x6b, 0x9e, 0xab, 0x3f, 0x05, 0x06, 0xff, 0x2b, 0xe0, 0x05, 0x31, 0x1f,
+	0x90, 0xa7, 0x30, 0x6a, 0x2f, 0xaf, 0x8e, 0x87, 0x98, 0x3a, 0x4a, 0x2f, 0xa5, 0x36, 0x4e, 0x27,
+	0xf2, 0xcc, 0x99, 0xe4, 0x33, 0x9c, 0xf8, 0x32, 0xff, 0x6e, 0xe8, 0xdc, 0xdb, 0x50, 0x2b, 0xf7,
+	0x28, 0xf5, 0xc3, 0xfa, 0xf6, 0xd4, 0x5e, 0xf0, 0x8a, 0x7f, 0x32, 0xd9, 0xba, 0x56, 0x5a, 0xaa,
+	0xee, 0x15, 0x60, 0x97, 0x1e, 0xcd, 0x7c, 0xb0, 0xbc, 0xfb, 0x6d, 0x3f, 0x0b, 0xbe, 0xef, 0x67,
+	0xc1, 0x8f, 0xfd, 0x2c, 0xf8, 0xf2, 0x73, 0x76, 0x6b, 0x15, 0xe1, 0x6b, 0x7b, 0xf1, 0x7b, 0x00,
+	0x19, 0x55, 0x0f, 0xad, 0xb8, 0x03, 0x00, 0x00,
+}
@@ -0,0 +1,1171 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: place.proto
+
+/*
+	Package protomodel is a generated protocol buffer package.
+
+	It is generated from these files:
+		place.proto
+
+	It has these top-level messages:
+		Place
+		PlacesResponse
+		PlaceCluster
+		PlaceClustersResponse
+*/
+package protomodel
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import _ "github.com/gogo/protobuf/gogoproto"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Place struct {
+	Location       *Location `protobuf:"bytes,1,opt,name=location" json:"location"`
+	VisitCount     int64     `protobuf:"varint,2,opt,name=visit_count,proto3" json:"visit_count"`
+	FirstVisitedAt int64     `protobuf:"varint,3,opt,name=first_visited_at,proto3" json:"first_visited_at"`
+	LastVisitedAt  int64     `protobuf:"varint,4,opt,name=last_visited_at,proto3" json:"last_visited_at"`
+}
+
+func (m *Place) Reset()                    { *m = Place{} }
+func (m *Place) String() string            { return proto.CompactTextString(m) }
+func (*Place) ProtoMessage()               {}
+func (*Place) Descriptor() ([]byte, []int) { return fileDescriptorPlace, []int{0} }
+
+func (m *Place) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *Place) GetVisitCount() int64 {
+	if m != nil {
+		return m.VisitCount
+	}
+	return 0
+}
+
+func (m *Place) GetFirstVisitedAt() int64 {
+	if m != nil {
+		return m.FirstVisitedAt
+	}
+	return 0
+}
+
+func (m *Place) GetLastVisitedAt() int64 {
+	if m != nil {
+		return m.LastVisitedAt
+	}
+	return 0
+}
+
+type PlacesResponse struct {
+	Result []*Place `protobuf:"bytes,1,rep,name=result" json:"result"`
+}
+
+func (m *PlacesResponse) Reset()                    { *m = PlacesResponse{} }
+func (m *PlacesResponse) String() string            { return proto.CompactTextString(m) }
+func (*PlacesResponse) ProtoMessage()               {}
+func (*PlacesResponse) Descriptor() ([]byte, []int) { return fileDescriptorPlace, []int{1} }
+
+func (m *PlacesResponse) GetResult() []*Place {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type PlaceCluster struct {
+	Name           string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	Coordinate     *Coordinate `protobuf:"bytes,2,opt,name=coordinate" json:"coordinate"`
+	SouthWest      *Coordinate `protobuf:"bytes,3,opt,name=south_west" json:"south_west"`
+	NorthEast      *Coordinate `protobuf:"bytes,4,opt,name=north_east" json:"north_east"`
+	PlaceCount     int64       `protobuf:"varint,5,opt,name=place_count,proto3" json:"place_count"`
+	VisitCount     int64       `protobuf:"varint,6,opt,name=visit_count,proto3" json:"visit_count"`
+	FirstVisitedAt int64       `protobuf:"varint,7,opt,name=first_visited_at,proto3" json:"first_visited_at"`
+	LastVisitedAt  int64       `protobuf:"varint,8,opt,name=last_visited_at,proto3" json:"last_visited_at"`
+}
+
+func (m *PlaceCluster) Reset()                    { *m = PlaceCluster{} }
+func (m *PlaceCluster) String() string            { return proto.CompactTextString(m) }
+func (*PlaceCluster) ProtoMessage()               {}
+func (*PlaceCluster) Descriptor() ([]byte, []int) { return fileDescriptorPlace, []int{2} }
+
+func (m *PlaceCluster) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PlaceCluster) GetCoordinate() *Coordinate {
+	if m != nil {
+		return m.Coordinate
+	}
+	return nil
+}
+
+func (m *PlaceCluster) GetSouthWest() *Coordinate {
+	if m != nil {
+		return m.SouthWest
+	}
+	return nil
+}
+
+func (m *PlaceCluster) GetNorthEast() *Coordinate {
+	if m != nil {
+		return m.NorthEast
+	}
+	return nil
+}
+
+func (m *PlaceCluster) GetPlaceCount() int64 {
+	if m != nil {
+		return m.PlaceCount
+	}
+	return 0
+}
+
+func (m *PlaceCluster) GetVisitCount() int64 {
+	if m != nil {
+		return m.VisitCount
+	}
+	return 0
+}
+
+func (m *PlaceCluster) GetFirstVisitedAt() int64 {
+	if m != nil {
+		return m.FirstVisitedAt
+	}
+	return 0
+}
+
+func (m *PlaceCluster) GetLastVisitedAt() int64 {
+	if m != nil {
+		return m.LastVisitedAt
+	}
+	return 0
+}
+
+type PlaceClustersResponse struct {
+	Result []*PlaceCluster `protobuf:"bytes,1,rep,name=result" json:"result"`
+}
+
+func (m *PlaceClustersResponse) Reset()                    { *m = PlaceClustersResponse{} }
+func (m *PlaceClustersResponse) String() string            { return proto.CompactTextString(m) }
+func (*PlaceClustersResponse) ProtoMessage()               {}
+func (*PlaceClustersResponse) Descriptor() ([]byte, []int) { return fileDescriptorPlace, []int{3} }
+
+func (m *PlaceClustersResponse) GetResult() []*PlaceCluster {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Place)(nil), "Place")
+	proto.RegisterType((*PlacesResponse)(nil), "PlacesResponse")
+	proto.RegisterType((*PlaceCluster)(nil), "PlaceCluster")
+	proto.RegisterType((*PlaceClustersResponse)(nil), "PlaceClustersResponse")
+}
+func (m *Place) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Place) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Location != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.Location.Size()))
+		n1, err := m.Location.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if m.VisitCount != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.VisitCount))
+	}
+	if m.FirstVisitedAt != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.FirstVisitedAt))
+	}
+	if m.LastVisitedAt != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.LastVisitedAt))
+	}
+	return i, nil
+}
+
+func (m *PlacesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for _, msg := range m.Result {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPlace(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PlaceCluster) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlaceCluster) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Coordinate != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.Coordinate.Size()))
+		n2, err := m.Coordinate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.SouthWest != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.SouthWest.Size()))
+		n3, err := m.SouthWest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.NorthEast != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.NorthEast.Size()))
+		n4, err := m.NorthEast.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.PlaceCount != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.PlaceCount))
+	}
+	if m.VisitCount != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.VisitCount))
+	}
+	if m.FirstVisitedAt != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.FirstVisitedAt))
+	}
+	if m.LastVisitedAt != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPlace(dAtA, i, uint64(m.LastVisitedAt))
+	}
+	return i, nil
+}
+
+func (m *PlaceClustersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlaceClustersResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for _, msg := range m.Result {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPlace(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func encodeFixed64Place(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Place(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintPlace(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Place) Size() (n int) {
+	var l int
+	_ = l
+	if m.Location != nil {
+		l = m.Location.Size()
+		n += 1 + l + sovPlace(uint64(l))
+	}
+	if m.VisitCount != 0 {
+		n += 1 + sovPlace(uint64(m.VisitCount))
+	}
+	if m.FirstVisitedAt != 0 {
+		n += 1 + sovPlace(uint64(m.FirstVisitedAt))
+	}
+	if m.LastVisitedAt != 0 {
+		n += 1 + sovPlace(uint64(m.LastVisitedAt))
+	}
+	return n
+}
+
+func (m *PlacesResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for _, e := range m.Result {
+			l = e.Size()
+			n += 1 + l + sovPlace(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PlaceCluster) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPlace(uint64(l))
+	}
+	if m.Coordinate != nil {
+		l = m.Coordinate.Size()
+		n += 1 + l + sovPlace(uint64(l))
+	}
+	if m.SouthWest != nil {
+		l = m.SouthWest.Size()
+		n += 1 + l + sovPlace(uint64(l))
+	}
+	if m.NorthEast != nil {
+		l = m.NorthEast.Size()
+		n += 1 + l + sovPlace(uint64(l))
+	}
+	if m.PlaceCount != 0 {
+		n += 1 + sovPlace(uint64(m.PlaceCount))
+	}
+	if m.VisitCount != 0 {
+		n += 1 + sovPlace(uint64(m.VisitCount))
+	}
+	if m.FirstVisitedAt != 0 {
+		n += 1 + sovPlace(uint64(m.FirstVisitedAt))
+	}
+	if m.LastVisitedAt != 0 {
+		n += 1 + sovPlace(uint64(m.LastVisitedAt))
+	}
+	return n
+}
+
+func (m *PlaceClustersResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for _, e := range m.Result {
+			l = e.Size()
+			n += 1 + l + sovPlace(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovPlace(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozPlace(x uint64) (n int) {
+	return sovPlace(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Place) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Place: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Place: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Location", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Location == nil {
+				m.Location = &Location{}
+			}
+			if err := m.Location.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VisitCount", wireType)
+			}
+			m.VisitCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VisitCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstVisitedAt", wireType)
+			}
+			m.FirstVisitedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstVisitedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastVisitedAt", wireType)
+			}
+			m.LastVisitedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastVisitedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Result = append(m.Result, &Place{})
+			if err := m.Result[len(m.Result)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlaceCluster) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlaceCluster: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlaceCluster: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Coordinate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Coordinate == nil {
+				m.Coordinate = &Coordinate{}
+			}
+			if err := m.Coordinate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SouthWest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SouthWest == nil {
+				m.SouthWest = &Coordinate{}
+			}
+			if err := m.SouthWest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NorthEast", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NorthEast == nil {
+				m.NorthEast = &Coordinate{}
+			}
+			if err := m.NorthEast.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlaceCount", wireType)
+			}
+			m.PlaceCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PlaceCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VisitCount", wireType)
+			}
+			m.VisitCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VisitCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstVisitedAt", wireType)
+			}
+			m.FirstVisitedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstVisitedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastVisitedAt", wireType)
+			}
+			m.LastVisitedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastVisitedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlaceClustersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlaceClustersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlaceClustersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Result = append(m.Result, &PlaceCluster{})
+			if err := m.Result[len(m.Result)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipPlace(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowPlace
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowPlace
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthPlace
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowPlace
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipPlace(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthPlace = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowPlace   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() { proto.RegisterFile("place.proto", fileDescriptorPlace) }
+
+var fileDescriptorPlace = []byte{
+	// 407 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xc1, 0x6e, 0xa2, 0x50,
+	0x14, 0x9d, 0x37, 0x28, 0xa3, 0x0f, 0x47, 0x0d, 0x33, 0x93, 0x90, 0xc9, 0x04, 0x8c, 0x2b, 0x33,
+	0xc9, 0x60, 0xd4, 0xe5, 0xb4, 0x49, 0x83, 0xbb, 0xa6, 0x8b, 0xe6, 0xfd, 0x00, 0x41, 0x7c, 0x2a,
+	0x09, 0xf2, 0x08, 0xef, 0xd1, 0xfe, 0x4a, 0x3f, 0xa9, 0xe9, 0xaa, 0x5f, 0x40, 0x1a, 0xbb, 0x63,
+	0xd5, 0x4f, 0x68, 0xb8, 0x12, 0x79, 0x45, 0x37, 0x6d, 0x37, 0x70, 0xee, 0xb9, 0xe7, 0xdc, 0x84,
+	0x7b, 0x0f, 0x58, 0x8b, 0x43, 0xcf, 0xa7, 0x76, 0x9c, 0x30, 0xc1, 0x7e, 0xff, 0x5b, 0x07, 0x62,
+	0x93, 0x2e, 0x6c, 0x9f, 0x6d, 0xc7, 0x6b, 0xb6, 0x66, 0x63, 0xa0, 0x17, 0xe9, 0x0a, 0x2a, 0x28,
+	0x00, 0x95, 0x72, 0x1c, 0x33, 0x2e, 0xf6, 0x78, 0xf8, 0x82, 0x70, 0xf3, 0xba, 0x18, 0xa5, 0xcf,
+	0x70, 0x2b, 0x64, 0xbe, 0x27, 0x02, 0x16, 0x19, 0x68, 0x80, 0x46, 0xda, 0xb4, 0x6d, 0x5f, 0x95,
+	0x84, 0xd3, 0xc9, 0x33, 0xeb, 0xd0, 0x26, 0x07, 0xa4, 0x4f, 0xb0, 0x76, 0x13, 0xf0, 0x40, 0xb8,
+	0x3e, 0x4b, 0x23, 0x61, 0x7c, 0x1d, 0xa0, 0x91, 0xe2, 0xf4, 0xf2, 0xcc, 0x92, 0x69, 0x22, 0x17,
+	0xfa, 0x05, 0xee, 0xaf, 0x82, 0x84, 0x0b, 0x17, 0x48, 0xba, 0x74, 0x3d, 0x61, 0x28, 0xe0, 0xfb,
+	0x99, 0x67, 0xd6, 0x51, 0x8f, 0x1c, 0x31, 0xfa, 0x39, 0xee, 0x85, 0xde, 0xdb, 0x01, 0x0d, 0x18,
+	0xf0, 0x23, 0xcf, 0xac, 0x7a, 0x8b, 0xd4, 0x89, 0xe1, 0x19, 0xee, 0xc2, 0x17, 0x73, 0x42, 0x79,
+	0xcc, 0x22, 0x4e, 0xf5, 0xbf, 0x58, 0x4d, 0x28, 0x4f, 0x43, 0x61, 0xa0, 0x81, 0x32, 0xd2, 0xa6,
+	0xaa, 0x0d, 0x02, 0x07, 0xe7, 0x99, 0x55, 0x76, 0x48, 0xf9, 0x1e, 0x3e, 0x28, 0xb8, 0x03, 0xdd,
+	0x79, 0x98, 0x72, 0x41, 0x13, 0xfd, 0x0f, 0x6e, 0x44, 0xde, 0x96, 0xc2, 0xce, 0xda, 0x4e, 0x2b,
+	0xcf, 0x2c, 0xa8, 0x09, 0x3c, 0xf5, 0xff, 0x18, 0xfb, 0x8c, 0x25, 0xcb, 0x20, 0xf2, 0x04, 0x85,
+	0xfd, 0x68, 0x53, 0xcd, 0x9e, 0x1f, 0x28, 0xa7, 0x9b, 0x67, 0x96, 0x24, 0x21, 0x12, 0x2e, 0xcc,
+	0x9c, 0xa5, 0x62, 0xe3, 0xde, 0x52, 0xbe, 0x5f, 0xd2, 0x29, 0x73, 0x25, 0x21, 0x12, 0x2e, 0xcc,
+	0x11, 0x4b, 0xc4, 0xc6, 0xa5, 0x1e, 0xdf, 0x2f, 0xe8, 0x94, 0xb9, 0x92, 0x10, 0x09, 0x17, 0x77,
+	0x85, 0x80, 0x95, 0x77, 0x6d, 0x56, 0x77, 0x95, 0x68, 0x22, 0x17, 0xf5, 0x28, 0xa8, 0x1f, 0x8c,
+	0xc2, 0xb7, 0xcf, 0x46, 0xa1, 0xf5, 0x8e, 0x28, 0x5c, 0xe2, 0x5f, 0xf2, 0x2d, 0xab, 0x44, 0x4c,
+	0x6a, 0x89, 0xf8, 0x6e, 0xcb, 0xba, 0x53, 0xc1, 0x70, 0xfa, 0xf7, 0x3b, 0x13, 0x3d, 0xee, 0x4c,
+	0xf4, 0xb4, 0x33, 0xd1, 0xdd, 0xb3, 0xf9, 0x65, 0xa1, 0xc2, 0x2f, 0x36, 0x7b, 0x1d, 0x00, 0x82,
+	0x43, 0x02, 0x2a, 0xac, 0x03, 0x00, 0x00,
+}
@@ -173,6 +173,7 @@ type Post struct {
 	Location  *Location    `protobuf:"bytes,7,opt,name=location" json:"location"`
 	MediaData []*MediaData `protobuf:"bytes,8,rep,name=mediaData" json:"mediaData"`
 	Tags      []*Tag       `protobuf:"bytes,9,rep,name=tags" json:"tags"`
+	Source    string       `protobuf:"bytes,10,opt,name=source,proto3" json:"source"`
 }
 
 func (m *Post) Reset()                    { *m = Post{} }
@@ -243,8 +244,16 @@ func (m *Post) GetTags() []*Tag {
 	return nil
 }
 
+func (m *Post) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 type PostsResponse struct {
-	Result []*Post `protobuf:"bytes,1,rep,name=result" json:"result"`
+	Result     []*Post `protobuf:"bytes,1,rep,name=result" json:"result"`
+	NextCursor string  `protobuf:"bytes,2,opt,name=next_cursor,proto3" json:"next_cursor"`
 }
 
 func (m *PostsResponse) Reset()                    { *m = PostsResponse{} }
@@ -259,6 +268,13 @@ func (m *PostsResponse) GetResult() []*Post {
 	return nil
 }
 
+func (m *PostsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Coordinate)(nil), "Coordinate")
 	proto.RegisterType((*Location)(nil), "Location")
@@ -496,6 +512,12 @@ func (m *Post) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Source) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPost(dAtA, i, uint64(len(m.Source)))
+		i += copy(dAtA[i:], m.Source)
+	}
 	return i, nil
 }
 
@@ -526,6 +548,12 @@ func (m *PostsResponse) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.NextCursor) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPost(dAtA, i, uint64(len(m.NextCursor)))
+		i += copy(dAtA[i:], m.NextCursor)
+	}
 	return i, nil
 }
 
@@ -667,6 +695,10 @@ func (m *Post) Size() (n int) {
 			n += 1 + l + sovPost(uint64(l))
 		}
 	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovPost(uint64(l))
+	}
 	return n
 }
 
@@ -679,6 +711,10 @@ func (m *PostsResponse) Size() (n int) {
 			n += 1 + l + sovPost(uint64(l))
 		}
 	}
+	l = len(m.NextCursor)
+	if l > 0 {
+		n += 1 + l + sovPost(uint64(l))
+	}
 	return n
 }
 
@@ -1503,6 +1539,35 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPost(dAtA[iNdEx:])
@@ -1584,6 +1649,35 @@ func (m *PostsResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPost
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPost
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextCursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPost(dAtA[iNdEx:])
@@ -1713,38 +1807,39 @@ var (
 func init() { proto.RegisterFile("post.proto", fileDescriptorPost) }
 
 var fileDescriptorPost = []byte{
-	// 515 bytes of a gzipped FileDescriptorProto
+	// 540 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0x4f, 0x8b, 0xd4, 0x4e,
-	0x10, 0xfd, 0x25, 0xf3, 0x37, 0x35, 0xbf, 0x15, 0xe9, 0x83, 0x44, 0x91, 0xe9, 0x21, 0x20, 0x8c,
-	0x88, 0x59, 0xd8, 0x3d, 0x08, 0xee, 0x6d, 0xf4, 0xa8, 0x20, 0xcd, 0xde, 0xa5, 0x27, 0x69, 0xb3,
-	0x0d, 0x49, 0x3a, 0xa4, 0x3b, 0x07, 0xfd, 0x24, 0x7e, 0x24, 0x8f, 0x5e, 0xc4, 0x5b, 0x90, 0xf1,
-	0x96, 0x4f, 0x21, 0xa9, 0x74, 0x3a, 0x03, 0x2e, 0x5e, 0xaa, 0x5f, 0xbd, 0xae, 0xa2, 0xeb, 0xbd,
-	0x54, 0x00, 0x2a, 0xa5, 0x4d, 0x5c, 0xd5, 0xca, 0xa8, 0x27, 0x2f, 0x33, 0x69, 0xee, 0x9a, 0x63,
-	0x9c, 0xa8, 0xe2, 0x32, 0x53, 0x99, 0xba, 0x44, 0xfa, 0xd8, 0x7c, 0xc2, 0x0c, 0x13, 0x44, 0x43,
-	0x79, 0x94, 0x00, 0xbc, 0x51, 0xaa, 0x4e, 0x65, 0xc9, 0x8d, 0x20, 0x7b, 0x58, 0xe7, 0xdc, 0x48,
-	0xd3, 0xa4, 0x22, 0xf4, 0x76, 0xde, 0xde, 0x3b, 0xfc, 0xdf, 0xb5, 0xd4, 0x71, 0xcc, 0x21, 0xf2,
-	0x02, 0x82, 0x5c, 0x95, 0xd9, 0x50, 0xea, 0x63, 0xe9, 0x45, 0xd7, 0xd2, 0x89, 0x64, 0x13, 0x8c,
-	0x7e, 0x7a, 0xb0, 0x7e, 0xa7, 0x12, 0x6e, 0xa4, 0x2a, 0xc9, 0x33, 0x58, 0xf1, 0x34, 0xad, 0x85,
-	0xd6, 0xf8, 0x44, 0x70, 0xd8, 0x74, 0x2d, 0x1d, 0x29, 0x36, 0x02, 0x72, 0x03, 0x90, 0xb8, 0xc1,
-	0xf0, 0x85, 0xcd, 0xd5, 0x26, 0x9e, 0x66, 0x3d, 0x3c, 0xe8, 0x5a, 0x7a, 0x56, 0xc2, 0xce, 0x30,
-	0x89, 0x01, 0x92, 0x5a, 0x70, 0x23, 0xd2, 0x8f, 0xdc, 0x84, 0xb3, 0x9d, 0xb7, 0x9f, 0xd9, 0x7a,
-	0xc7, 0xb2, 0x33, 0x4c, 0x9e, 0xc2, 0xbc, 0xe4, 0x85, 0x08, 0xe7, 0x38, 0xd0, 0xba, 0x6b, 0x29,
-	0xe6, 0x0c, 0x23, 0x79, 0x0c, 0xb3, 0xa6, 0xce, 0xc3, 0x05, 0x5e, 0xae, 0xba, 0x96, 0xf6, 0x29,
-	0xeb, 0x43, 0xf4, 0x05, 0x82, 0xf7, 0x22, 0x95, 0xfc, 0x2d, 0x37, 0x9c, 0x44, 0xb0, 0xd4, 0xaa,
-	0xa9, 0x13, 0x61, 0x85, 0x41, 0xd7, 0x52, 0xcb, 0x30, 0x7b, 0xf6, 0xbe, 0x99, 0xbb, 0xa6, 0x38,
-	0x96, 0x5c, 0xe6, 0xa8, 0x2a, 0x18, 0x7c, 0x73, 0x24, 0x9b, 0x60, 0x3f, 0x96, 0xf9, 0x5c, 0x09,
-	0x14, 0x60, 0xc7, 0xea, 0x73, 0x86, 0x31, 0xba, 0x81, 0xd9, 0x2d, 0xcf, 0xc8, 0x23, 0xf0, 0x65,
-	0x6a, 0x5f, 0x5c, 0x76, 0x2d, 0xf5, 0x65, 0xca, 0x7c, 0x99, 0x3a, 0x4d, 0xfe, 0x7d, 0x9a, 0xa2,
-	0x1f, 0x3e, 0xcc, 0x3f, 0x28, 0x6d, 0xfe, 0xd5, 0xde, 0x6f, 0xd5, 0x79, 0x7b, 0x9f, 0x33, 0x8c,
-	0x28, 0x43, 0x16, 0x42, 0x1b, 0x5e, 0x54, 0xd6, 0xdf, 0x41, 0xc6, 0x48, 0xb2, 0x09, 0x12, 0x0a,
-	0x0b, 0x23, 0x4d, 0x3e, 0xda, 0x1b, 0x74, 0x2d, 0x1d, 0x08, 0x36, 0x1c, 0x4e, 0xe7, 0xe2, 0x3e,
-	0x9d, 0xa3, 0xfd, 0xcb, 0xbf, 0xed, 0x27, 0xd7, 0xb0, 0xce, 0xed, 0x5e, 0x85, 0x2b, 0x5c, 0x91,
-	0x20, 0x1e, 0x17, 0xcd, 0xae, 0xae, 0xcd, 0x98, 0x43, 0xe4, 0x15, 0x04, 0xc5, 0xf8, 0xcd, 0xc2,
-	0xf5, 0x6e, 0xb6, 0xdf, 0x5c, 0x41, 0xec, 0xbe, 0xe2, 0xa0, 0xc3, 0x15, 0xb0, 0x09, 0x92, 0x08,
-	0xe6, 0x86, 0x67, 0x3a, 0x0c, 0xb0, 0x67, 0x1e, 0xdf, 0xf2, 0xcc, 0x0e, 0xcb, 0x33, 0xcd, 0x30,
-	0x46, 0xaf, 0xe1, 0xa2, 0xb7, 0x55, 0x33, 0xa1, 0x2b, 0x55, 0x6a, 0x41, 0x9e, 0xc3, 0xb2, 0x16,
-	0xba, 0xc9, 0x4d, 0xe8, 0x61, 0xdb, 0x22, 0xee, 0xef, 0x87, 0xdd, 0x18, 0x2e, 0x98, 0x3d, 0x0f,
-	0x0f, 0xbf, 0x9d, 0xb6, 0xde, 0xf7, 0xd3, 0xd6, 0xfb, 0x75, 0xda, 0x7a, 0x5f, 0x7f, 0x6f, 0xff,
-	0x3b, 0x2e, 0xf1, 0x27, 0xbd, 0xfe, 0x13, 0x00, 0x00, 0xff, 0xff, 0x07, 0x8d, 0xed, 0x3b, 0xe1,
-	0x03, 0x00, 0x00,
+	0x10, 0xfd, 0x25, 0x99, 0x7f, 0xa9, 0xf9, 0xad, 0x4a, 0x1f, 0x24, 0x8a, 0x4c, 0x0f, 0x01, 0x61,
+	0x44, 0xcc, 0xe2, 0xee, 0xc1, 0xc3, 0xde, 0x46, 0x8f, 0x0a, 0xd2, 0xec, 0x7d, 0xe9, 0x49, 0xda,
+	0x6c, 0x43, 0x92, 0x1e, 0xd2, 0x1d, 0x50, 0x3f, 0x89, 0x1f, 0xc9, 0xa3, 0x37, 0x6f, 0x41, 0xc6,
+	0x5b, 0xbe, 0x81, 0x37, 0x49, 0xa5, 0xf3, 0x07, 0x5c, 0xbd, 0x54, 0xbf, 0xf7, 0xba, 0x3a, 0x5d,
+	0xaf, 0xba, 0x02, 0x70, 0x54, 0xda, 0x44, 0xc7, 0x52, 0x19, 0xf5, 0xf8, 0x45, 0x2a, 0xcd, 0x6d,
+	0x75, 0x88, 0x62, 0x95, 0x9f, 0xa7, 0x2a, 0x55, 0xe7, 0x28, 0x1f, 0xaa, 0x0f, 0xc8, 0x90, 0x20,
+	0xea, 0xd2, 0xc3, 0x18, 0xe0, 0xb5, 0x52, 0x65, 0x22, 0x0b, 0x6e, 0x04, 0xd9, 0xc1, 0x2a, 0xe3,
+	0x46, 0x9a, 0x2a, 0x11, 0x81, 0xb3, 0x75, 0x76, 0xce, 0xfe, 0xff, 0xa6, 0xa6, 0x83, 0xc6, 0x06,
+	0x44, 0x9e, 0x83, 0x9f, 0xa9, 0x22, 0xed, 0x52, 0x5d, 0x4c, 0x3d, 0x6b, 0x6a, 0x3a, 0x8a, 0x6c,
+	0x84, 0xe1, 0x77, 0x07, 0x56, 0x6f, 0x55, 0xcc, 0x8d, 0x54, 0x05, 0x79, 0x0a, 0x4b, 0x9e, 0x24,
+	0xa5, 0xd0, 0x1a, 0xaf, 0xf0, 0xf7, 0xeb, 0xa6, 0xa6, 0xbd, 0xc4, 0x7a, 0x40, 0xae, 0x00, 0xe2,
+	0xa1, 0x30, 0xbc, 0x61, 0x7d, 0xb1, 0x8e, 0xc6, 0x5a, 0xf7, 0xf7, 0x9a, 0x9a, 0x4e, 0x52, 0xd8,
+	0x04, 0x93, 0x08, 0x20, 0x2e, 0x05, 0x37, 0x22, 0xb9, 0xe1, 0x26, 0xf0, 0xb6, 0xce, 0xce, 0xb3,
+	0xf9, 0x83, 0xca, 0x26, 0x98, 0x3c, 0x81, 0x59, 0xc1, 0x73, 0x11, 0xcc, 0xb0, 0xa0, 0x55, 0x53,
+	0x53, 0xe4, 0x0c, 0x23, 0x79, 0x04, 0x5e, 0x55, 0x66, 0xc1, 0x1c, 0x37, 0x97, 0x4d, 0x4d, 0x5b,
+	0xca, 0xda, 0x10, 0x7e, 0x06, 0xff, 0x9d, 0x48, 0x24, 0x7f, 0xc3, 0x0d, 0x27, 0x21, 0x2c, 0xb4,
+	0xaa, 0xca, 0x58, 0x58, 0x63, 0xd0, 0xd4, 0xd4, 0x2a, 0xcc, 0xae, 0x6d, 0xdf, 0xcc, 0x6d, 0x95,
+	0x1f, 0x0a, 0x2e, 0x33, 0x74, 0xe5, 0x77, 0x7d, 0x1b, 0x44, 0x36, 0xc2, 0xb6, 0x2c, 0xf3, 0xe9,
+	0x28, 0x02, 0x6f, 0x2c, 0xab, 0xe5, 0x0c, 0x63, 0x78, 0x05, 0xde, 0x35, 0x4f, 0xc9, 0x43, 0x70,
+	0x65, 0x62, 0x6f, 0x5c, 0x34, 0x35, 0x75, 0x65, 0xc2, 0x5c, 0x99, 0x0c, 0x9e, 0xdc, 0xbb, 0x3c,
+	0x85, 0xbf, 0x5c, 0x98, 0xbd, 0x57, 0xda, 0xfc, 0xeb, 0x78, 0x3b, 0x55, 0xd3, 0xe3, 0x2d, 0x67,
+	0x18, 0xd1, 0x86, 0xcc, 0x85, 0x36, 0x3c, 0x3f, 0xda, 0xfe, 0x76, 0x36, 0x7a, 0x91, 0x8d, 0x90,
+	0x50, 0x98, 0x1b, 0x69, 0xb2, 0xbe, 0xbd, 0x7e, 0x53, 0xd3, 0x4e, 0x60, 0xdd, 0x32, 0xf8, 0x9c,
+	0xdf, 0xe5, 0xb3, 0x6f, 0xff, 0xe2, 0xcf, 0xf6, 0x93, 0x4b, 0x58, 0x65, 0x76, 0xae, 0x82, 0x25,
+	0x8e, 0x88, 0x1f, 0xf5, 0x83, 0x66, 0x47, 0xd7, 0x32, 0x36, 0x20, 0xf2, 0x0a, 0xfc, 0xbc, 0x7f,
+	0xb3, 0x60, 0xb5, 0xf5, 0x76, 0xeb, 0x0b, 0x88, 0x86, 0x57, 0xec, 0x7c, 0x0c, 0x09, 0x6c, 0x84,
+	0x24, 0x84, 0x99, 0xe1, 0xa9, 0x0e, 0x7c, 0x3c, 0x33, 0x8b, 0xae, 0x79, 0x6a, 0x8b, 0xe5, 0xa9,
+	0x66, 0x18, 0x27, 0x33, 0x00, 0x7f, 0x9b, 0x81, 0x30, 0x87, 0xb3, 0xb6, 0xf5, 0x9a, 0x09, 0x7d,
+	0x54, 0x85, 0x16, 0xe4, 0x19, 0x2c, 0x4a, 0xa1, 0xab, 0xcc, 0x04, 0x0e, 0x7e, 0x7a, 0x1e, 0xb5,
+	0xfb, 0xdd, 0xd9, 0x6e, 0x83, 0xd9, 0x95, 0xbc, 0x84, 0x75, 0x21, 0x3e, 0x9a, 0x9b, 0xb8, 0x2a,
+	0xb5, 0x2a, 0xed, 0xeb, 0xdc, 0x6f, 0x6a, 0x3a, 0x95, 0xd9, 0x94, 0xec, 0x1f, 0x7c, 0x3d, 0x6d,
+	0x9c, 0x6f, 0xa7, 0x8d, 0xf3, 0xe3, 0xb4, 0x71, 0xbe, 0xfc, 0xdc, 0xfc, 0x77, 0x58, 0xe0, 0xbf,
+	0x7f, 0xf9, 0x7b, 0x00, 0x07, 0xa1, 0xac, 0xd2, 0x38, 0x04, 0x00, 0x00,
 }
@@ -37,6 +37,7 @@ type Reaction struct {
 	ReactionId string `protobuf:"bytes,3,opt,name=reaction_id,proto3" json:"reaction_id"`
 	Timestamp  int64  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp"`
 	Title      string `protobuf:"bytes,5,opt,name=title,proto3" json:"title"`
+	Source     string `protobuf:"bytes,6,opt,name=source,proto3" json:"source"`
 }
 
 func (m *Reaction) Reset()                    { *m = Reaction{} }
@@ -79,8 +80,16 @@ func (m *Reaction) GetTitle() string {
 	return ""
 }
 
+func (m *Reaction) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 type ReactionsResponse struct {
-	Result []*Reaction `protobuf:"bytes,1,rep,name=result" json:"result"`
+	Result     []*Reaction `protobuf:"bytes,1,rep,name=result" json:"result"`
+	NextCursor string      `protobuf:"bytes,2,opt,name=next_cursor,proto3" json:"next_cursor"`
 }
 
 func (m *ReactionsResponse) Reset()                    { *m = ReactionsResponse{} }
@@ -95,6 +104,13 @@ func (m *ReactionsResponse) GetResult() []*Reaction {
 	return nil
 }
 
+func (m *ReactionsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Reaction)(nil), "Reaction")
 	proto.RegisterType((*ReactionsResponse)(nil), "ReactionsResponse")
@@ -143,6 +159,12 @@ func (m *Reaction) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintReaction(dAtA, i, uint64(len(m.Title)))
 		i += copy(dAtA[i:], m.Title)
 	}
+	if len(m.Source) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintReaction(dAtA, i, uint64(len(m.Source)))
+		i += copy(dAtA[i:], m.Source)
+	}
 	return i, nil
 }
 
@@ -173,6 +195,12 @@ func (m *ReactionsResponse) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.NextCursor) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintReaction(dAtA, i, uint64(len(m.NextCursor)))
+		i += copy(dAtA[i:], m.NextCursor)
+	}
 	return i, nil
 }
 
@@ -225,6 +253,10 @@ func (m *Reaction) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovReaction(uint64(l))
 	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovReaction(uint64(l))
+	}
 	return n
 }
 
@@ -237,6 +269,10 @@ func (m *ReactionsResponse) Size() (n int) {
 			n += 1 + l + sovReaction(uint64(l))
 		}
 	}
+	l = len(m.NextCursor)
+	if l > 0 {
+		n += 1 + l + sovReaction(uint64(l))
+	}
 	return n
 }
 
@@ -417,6 +453,35 @@ func (m *Reaction) Unmarshal(dAtA []byte) error {
 			}
 			m.Title = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReaction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReaction
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipReaction(dAtA[iNdEx:])
@@ -498,6 +563,35 @@ func (m *ReactionsResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReaction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReaction
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextCursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipReaction(dAtA[iNdEx:])
@@ -627,21 +721,23 @@ var (
 func init() { proto.RegisterFile("reaction.proto", fileDescriptorReaction) }
 
 var fileDescriptorReaction = []byte{
-	// 255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0x4d, 0x4e, 0xc3, 0x30,
-	0x10, 0x85, 0x31, 0xa1, 0x55, 0x33, 0xe5, 0x37, 0x2b, 0x8b, 0x45, 0x1c, 0x75, 0x15, 0x09, 0x35,
-	0x15, 0x70, 0x03, 0x1f, 0xc1, 0x17, 0x40, 0x49, 0x30, 0xc1, 0x52, 0x53, 0x47, 0xf1, 0xe4, 0x2e,
-	0x1c, 0x89, 0x25, 0x2b, 0x96, 0x16, 0x0a, 0x3b, 0x9f, 0x02, 0xe1, 0x24, 0x4d, 0x57, 0xef, 0xbd,
-	0xcf, 0x4f, 0xf6, 0x78, 0xe0, 0xba, 0x95, 0x79, 0x89, 0x4a, 0x1f, 0xb2, 0xa6, 0xd5, 0xa8, 0xef,
-	0xb7, 0x95, 0xc2, 0xf7, 0xae, 0xc8, 0x4a, 0x5d, 0xef, 0x2a, 0x5d, 0xe9, 0x9d, 0xc7, 0x45, 0xf7,
-	0xe6, 0x93, 0x0f, 0xde, 0x0d, 0xf5, 0xcd, 0x37, 0x81, 0x95, 0x18, 0x6f, 0x88, 0x18, 0x2c, 0xf2,
-	0x12, 0x75, 0x4b, 0x49, 0x42, 0xd2, 0x90, 0x87, 0xce, 0xb2, 0x01, 0x88, 0x41, 0xa2, 0x14, 0x56,
-	0xd3, 0x73, 0xf4, 0xdc, 0x77, 0x2e, 0x9d, 0x65, 0x47, 0x26, 0x8e, 0x2e, 0x7a, 0x84, 0xf5, 0xe4,
-	0x5f, 0xd4, 0x2b, 0x0d, 0x7c, 0xf9, 0xc6, 0x59, 0x76, 0x8a, 0xc5, 0x69, 0x88, 0x1e, 0x20, 0x44,
-	0x55, 0x4b, 0x83, 0x79, 0xdd, 0xd0, 0x8b, 0x84, 0xa4, 0x01, 0xbf, 0x72, 0x96, 0xcd, 0x50, 0xcc,
-	0xf6, 0x7f, 0x54, 0x54, 0xb8, 0x97, 0x74, 0x31, 0x8f, 0xea, 0x81, 0x18, 0x64, 0xc3, 0xe1, 0x6e,
-	0xfa, 0x97, 0x11, 0xd2, 0x34, 0xfa, 0x60, 0x64, 0xb4, 0x85, 0x65, 0x2b, 0x4d, 0xb7, 0x47, 0x4a,
-	0x92, 0x20, 0x5d, 0x3f, 0x85, 0xd9, 0xd4, 0xe1, 0xe0, 0x2c, 0x1b, 0x0f, 0xc5, 0xa8, 0xfc, 0xf6,
-	0xb3, 0x8f, 0xc9, 0x57, 0x1f, 0x93, 0x9f, 0x3e, 0x26, 0x1f, 0xbf, 0xf1, 0x59, 0xb1, 0xf4, 0x5b,
-	0x7b, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xf7, 0xc1, 0xf3, 0x25, 0x76, 0x01, 0x00, 0x00,
+	// 285 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0x4d, 0x6e, 0x83, 0x30,
+	0x14, 0x84, 0xeb, 0xd0, 0xa0, 0xe0, 0xf4, 0xd7, 0x2b, 0xab, 0x0b, 0x8c, 0x58, 0x21, 0x55, 0x21,
+	0x6a, 0x7b, 0x03, 0x8e, 0xe0, 0x0b, 0x44, 0x40, 0x5d, 0x8a, 0x14, 0x30, 0xf2, 0x8f, 0xd4, 0xa3,
+	0xf4, 0x48, 0x5d, 0xf6, 0x04, 0xa8, 0xa2, 0x3b, 0x1f, 0xa1, 0xab, 0x2a, 0x36, 0x04, 0x56, 0x33,
+	0xf3, 0x31, 0x7a, 0xbc, 0x27, 0xc3, 0x1b, 0xc1, 0xf2, 0x52, 0xd5, 0xbc, 0x4d, 0x3b, 0xc1, 0x15,
+	0x7f, 0xd8, 0x55, 0xb5, 0x7a, 0xd7, 0x45, 0x5a, 0xf2, 0x66, 0x5f, 0xf1, 0x8a, 0xef, 0x2d, 0x2e,
+	0xf4, 0x9b, 0x4d, 0x36, 0x58, 0xe7, 0xea, 0xf1, 0x1f, 0x80, 0x1b, 0x3a, 0x4e, 0x40, 0x04, 0xae,
+	0xf3, 0x52, 0x71, 0x81, 0x41, 0x04, 0x92, 0x20, 0x0b, 0x4c, 0x4f, 0x1c, 0xa0, 0x4e, 0x50, 0x02,
+	0x37, 0xd3, 0xef, 0xf0, 0xca, 0x76, 0xae, 0x4c, 0x4f, 0xce, 0x8c, 0x9e, 0x1d, 0x7a, 0x82, 0xdb,
+	0xc9, 0x1f, 0xea, 0x57, 0xec, 0xd9, 0xf2, 0xad, 0xe9, 0xc9, 0x12, 0xd3, 0x65, 0x40, 0x8f, 0x30,
+	0x50, 0x75, 0xc3, 0xa4, 0xca, 0x9b, 0x0e, 0x5f, 0x46, 0x20, 0xf1, 0xb2, 0x6b, 0xd3, 0x93, 0x19,
+	0xd2, 0xd9, 0x9e, 0x56, 0x55, 0xb5, 0x3a, 0x32, 0xbc, 0x9e, 0x57, 0xb5, 0x80, 0x3a, 0x41, 0x31,
+	0xf4, 0x25, 0xd7, 0xa2, 0x64, 0xd8, 0xb7, 0x0d, 0x68, 0x7a, 0x32, 0x12, 0x3a, 0x6a, 0xac, 0xe1,
+	0xfd, 0x74, 0xbb, 0xa4, 0x4c, 0x76, 0xbc, 0x95, 0x0c, 0xed, 0xa0, 0x2f, 0x98, 0xd4, 0x47, 0x85,
+	0x41, 0xe4, 0x25, 0xdb, 0xe7, 0x20, 0x9d, 0x3a, 0x6e, 0x86, 0xfb, 0x48, 0x47, 0x3d, 0x1d, 0xda,
+	0xb2, 0x0f, 0x75, 0x28, 0xb5, 0x90, 0x5c, 0xe0, 0xd5, 0x7c, 0xe8, 0x02, 0xd3, 0x65, 0xc8, 0xee,
+	0xbe, 0x86, 0x10, 0x7c, 0x0f, 0x21, 0xf8, 0x19, 0x42, 0xf0, 0xf9, 0x1b, 0x5e, 0x14, 0xbe, 0x7d,
+	0x8c, 0x97, 0xff, 0x01, 0x00, 0x67, 0x39, 0x7a, 0x4e, 0xcd, 0x01, 0x00, 0x00,
 }
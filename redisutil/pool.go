@@ -0,0 +1,19 @@
+package redisutil
+
+import (
+	"github.com/RichardKnop/machinery/v1"
+	"github.com/RichardKnop/machinery/v1/common"
+	"github.com/gomodule/redigo/redis"
+)
+
+// NewPool creates a pool of redis connections with a connection string in the format of machinery,
+// which is redis://[password@]host[:port][/db]. A process creates one pool and shares it with
+// all of its components keeping states in redis.
+func NewPool(conn string) (*redis.Pool, error) {
+	host, password, db, err := machinery.ParseRedisURL(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&common.RedisConnector{}).NewPool("", host, password, db, nil, nil), nil
+}
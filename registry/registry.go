@@ -0,0 +1,105 @@
+// Package registry registers archives and exports of users as property records on the Bitmark blockchain.
+//
+// The content hash of a file is registered as an asset by the global account of the server,
+// and a bitmark of the asset is issued and transferred to the account owning the file.
+package registry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bitmark-inc/bitmark-sdk-go/account"
+	"github.com/bitmark-inc/bitmark-sdk-go/asset"
+	"github.com/bitmark-inc/bitmark-sdk-go/bitmark"
+)
+
+// fingerprintTypeSHA3512 is the type prefix of fingerprints of SHA3-512 hashes, the same as the one of the sdk
+const fingerprintTypeSHA3512 = 1
+
+var ErrEmptyContentHash = errors.New("content hash is empty")
+
+// Registrar registers files with the global account of the server
+type Registrar struct {
+	account account.Account
+}
+
+// NewRegistrar creates a registrar with the global account of the server
+func NewRegistrar(a account.Account) *Registrar {
+	return &Registrar{account: a}
+}
+
+// Register registers the hex encoded SHA3-512 content hash of a file as an asset and issues a bitmark
+// of the asset to the owner, and returns the id of the bitmark.
+// Registering the same content again returns the bitmark issued before, so a failed registration
+// can be retried without issuing another bitmark.
+func (r *Registrar) Register(name string, metadata map[string]string, contentHash, owner string) (string, error) {
+	if contentHash == "" {
+		return "", ErrEmptyContentHash
+	}
+
+	params, err := asset.NewRegistrationParams(name, metadata)
+	if err != nil {
+		return "", err
+	}
+	params.Fingerprint = fmt.Sprintf("%02d%s", fingerprintTypeSHA3512, contentHash)
+	if err := params.Sign(r.account); err != nil {
+		return "", err
+	}
+
+	assetID, err := asset.Register(params)
+	if err != nil {
+		return "", err
+	}
+
+	issued, _, err := bitmark.List(bitmark.NewQueryParamsBuilder().
+		ReferencedAsset(assetID).
+		IssuedBy(r.account.AccountNumber()).
+		Limit(1))
+	if err != nil {
+		return "", err
+	}
+
+	var bitmarkID, latestTxID string
+	if len(issued) > 0 {
+		if issued[0].Owner == owner {
+			return issued[0].ID, nil
+		}
+		bitmarkID, latestTxID = issued[0].ID, issued[0].LatestTxID
+	} else {
+		issuance, err := bitmark.NewIssuanceParams(assetID, 1)
+		if err != nil {
+			return "", err
+		}
+		if err := issuance.Sign(r.account); err != nil {
+			return "", err
+		}
+		bitmarkIDs, err := bitmark.Issue(issuance)
+		if err != nil {
+			return "", err
+		}
+		if len(bitmarkIDs) == 0 {
+			return "", errors.New("no bitmark is issued")
+		}
+
+		// the id of a bitmark is the id of its issue
+		bitmarkID, latestTxID = bitmarkIDs[0], bitmarkIDs[0]
+	}
+
+	if owner == r.account.AccountNumber() {
+		return bitmarkID, nil
+	}
+
+	transfer, err := bitmark.NewTransferParams(owner)
+	if err != nil {
+		return "", err
+	}
+	transfer.FromLatestTx(latestTxID)
+	if err := transfer.Sign(r.account); err != nil {
+		return "", err
+	}
+	if _, err := bitmark.Transfer(transfer); err != nil {
+		return "", err
+	}
+
+	return bitmarkID, nil
+}
@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	bitmarksdk "github.com/bitmark-inc/bitmark-sdk-go"
+	"github.com/bitmark-inc/bitmark-sdk-go/account"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	transport := NewTransport()
+	bitmarksdk.Init(&bitmarksdk.Config{
+		Network:    bitmarksdk.Testnet,
+		HTTPClient: &http.Client{Transport: transport},
+	})
+
+	server, err := account.New()
+	assert.NoError(t, err)
+	owner, err := account.New()
+	assert.NoError(t, err)
+
+	r := NewRegistrar(server)
+	contentHash := strings.Repeat("ab", 64)
+	metadata := map[string]string{"type": "archive", "archive_id": "1"}
+
+	bitmarkID, err := r.Register("archive", metadata, contentHash, owner.AccountNumber())
+	assert.NoError(t, err)
+
+	b, ok := transport.Bitmark(bitmarkID)
+	assert.True(t, ok)
+	assert.Equal(t, server.AccountNumber(), b.Issuer)
+	assert.Equal(t, owner.AccountNumber(), b.Owner)
+	assert.NotEqual(t, bitmarkID, b.LatestTxID)
+
+	a, ok := transport.Asset(b.AssetID)
+	assert.True(t, ok)
+	assert.Equal(t, "01"+contentHash, a.Fingerprint)
+	assert.Equal(t, metadata, a.Metadata)
+	assert.Equal(t, server.AccountNumber(), a.Registrant)
+
+	// registering the same content returns the bitmark issued before
+	registeredAgain, err := r.Register("archive", metadata, contentHash, owner.AccountNumber())
+	assert.NoError(t, err)
+	assert.Equal(t, bitmarkID, registeredAgain)
+	assert.Len(t, transport.bitmarkIDs, 1)
+
+	// other content is registered as another asset
+	otherID, err := r.Register("archive", metadata, strings.Repeat("cd", 64), owner.AccountNumber())
+	assert.NoError(t, err)
+	assert.NotEqual(t, bitmarkID, otherID)
+
+	_, err = r.Register("archive", metadata, "", owner.AccountNumber())
+	assert.Equal(t, ErrEmptyContentHash, err)
+}
@@ -0,0 +1,245 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitmark-inc/bitmark-sdk-go/asset"
+	"github.com/bitmark-inc/bitmark-sdk-go/bitmark"
+	"golang.org/x/crypto/sha3"
+)
+
+// Transport is a local stand-in of the Bitmark api for the sdk, which keeps assets and bitmarks in memory.
+// It serves the requests of registering assets, issuing, transferring and querying bitmarks without
+// verifying their signatures. Set it as the transport of the http client of the sdk to use it in tests:
+//
+//	bitmarksdk.Init(&bitmarksdk.Config{
+//		Network:    bitmarksdk.Testnet,
+//		HTTPClient: &http.Client{Transport: registry.NewTransport()},
+//	})
+type Transport struct {
+	sync.Mutex
+	assets   map[string]*asset.Asset
+	bitmarks map[string]*bitmark.Bitmark
+
+	// ordered ids of bitmarks, so bitmarks are listed in the order they are issued
+	bitmarkIDs []string
+}
+
+// NewTransport creates a transport without any asset or bitmark
+func NewTransport() *Transport {
+	return &Transport{
+		assets:   make(map[string]*asset.Asset),
+		bitmarks: make(map[string]*bitmark.Bitmark),
+	}
+}
+
+// Bitmark returns a bitmark by its id
+func (t *Transport) Bitmark(id string) (*bitmark.Bitmark, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	b, ok := t.bitmarks[id]
+	if !ok {
+		return nil, false
+	}
+	copied := *b
+	return &copied, true
+}
+
+// Asset returns an asset by its id
+func (t *Transport) Asset(id string) (*asset.Asset, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	a, ok := t.assets[id]
+	if !ok {
+		return nil, false
+	}
+	copied := *a
+	return &copied, true
+}
+
+// RoundTrip serves a request of the sdk
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	var status int
+	var body interface{}
+	switch {
+	case req.Method == "POST" && req.URL.Path == "/v3/register-asset":
+		status, body = t.registerAssets(req)
+	case req.Method == "POST" && req.URL.Path == "/v3/issue":
+		status, body = t.issue(req)
+	case req.Method == "POST" && req.URL.Path == "/v3/transfer":
+		status, body = t.transfer(req)
+	case req.Method == "GET" && req.URL.Path == "/v3/bitmarks":
+		status, body = t.listBitmarks(req)
+	case req.Method == "GET" && strings.HasPrefix(req.URL.Path, "/v3/bitmarks/"):
+		status, body = t.getBitmark(strings.TrimPrefix(req.URL.Path, "/v3/bitmarks/"))
+	default:
+		status, body = apiError(http.StatusNotFound, "not found")
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
+
+func apiError(status int, message string) (int, interface{}) {
+	return status, map[string]interface{}{
+		"code":    status,
+		"message": message,
+	}
+}
+
+// txID derives the id of a transaction from its content
+func txID(parts ...interface{}) string {
+	digest := sha3.Sum256([]byte(fmt.Sprint(parts...)))
+	return hex.EncodeToString(digest[:])
+}
+
+func (t *Transport) registerAssets(req *http.Request) (int, interface{}) {
+	var params struct {
+		Assets []*asset.RegistrationParams `json:"assets"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&params); err != nil || len(params.Assets) == 0 {
+		return apiError(http.StatusBadRequest, "invalid assets")
+	}
+
+	type registered struct {
+		ID        string `json:"id"`
+		Duplicate bool   `json:"duplicate"`
+	}
+	result := make([]registered, 0, len(params.Assets))
+	for _, p := range params.Assets {
+		// the id of an asset is the hash of its fingerprint, the same as the one computed by the sdk
+		digest := sha3.Sum512([]byte(p.Fingerprint))
+		id := hex.EncodeToString(digest[:])
+
+		_, duplicate := t.assets[id]
+		if !duplicate {
+			now := time.Now()
+			t.assets[id] = &asset.Asset{
+				ID:          id,
+				Name:        p.Name,
+				Metadata:    parseMetadata(p.Metadata),
+				Fingerprint: p.Fingerprint,
+				Registrant:  p.Registrant,
+				Status:      "pending",
+				CreatedAt:   &now,
+			}
+		}
+		result = append(result, registered{ID: id, Duplicate: duplicate})
+	}
+	return http.StatusOK, map[string]interface{}{"assets": result}
+}
+
+// parseMetadata parses the metadata of an asset packed by the sdk as keys and values separated by NUL
+func parseMetadata(packed string) map[string]string {
+	metadata := make(map[string]string)
+	parts := strings.Split(packed, "\u0000")
+	for i := 0; i+1 < len(parts); i += 2 {
+		metadata[parts[i]] = parts[i+1]
+	}
+	return metadata
+}
+
+func (t *Transport) issue(req *http.Request) (int, interface{}) {
+	var params bitmark.IssuanceParams
+	if err := json.NewDecoder(req.Body).Decode(&params); err != nil || len(params.Issuances) == 0 {
+		return apiError(http.StatusBadRequest, "invalid issues")
+	}
+
+	type issued struct {
+		ID string `json:"id"`
+	}
+	result := make([]issued, 0, len(params.Issuances))
+	for _, i := range params.Issuances {
+		if _, ok := t.assets[i.AssetID]; !ok {
+			return apiError(http.StatusBadRequest, "asset not found")
+		}
+
+		id := txID(i.AssetID, i.Owner, i.Nonce)
+		if _, ok := t.bitmarks[id]; ok {
+			return apiError(http.StatusForbidden, "transaction already exists")
+		}
+
+		t.bitmarks[id] = &bitmark.Bitmark{
+			ID:         id,
+			AssetID:    i.AssetID,
+			LatestTxID: id,
+			Issuer:     i.Owner,
+			Owner:      i.Owner,
+			Status:     "pending",
+			CreatedAt:  time.Now(),
+		}
+		t.bitmarkIDs = append(t.bitmarkIDs, id)
+		result = append(result, issued{ID: id})
+	}
+	return http.StatusOK, map[string]interface{}{"bitmarks": result}
+}
+
+func (t *Transport) transfer(req *http.Request) (int, interface{}) {
+	var params bitmark.TransferParams
+	if err := json.NewDecoder(req.Body).Decode(&params); err != nil || params.Transfer == nil {
+		return apiError(http.StatusBadRequest, "invalid transfer")
+	}
+
+	for _, b := range t.bitmarks {
+		if b.LatestTxID != params.Transfer.Link {
+			continue
+		}
+		id := txID(params.Transfer.Link, params.Transfer.Owner)
+		b.LatestTxID = id
+		b.Owner = params.Transfer.Owner
+		return http.StatusOK, map[string]interface{}{"txId": id}
+	}
+	return apiError(http.StatusBadRequest, "link not found")
+}
+
+func (t *Transport) getBitmark(id string) (int, interface{}) {
+	b, ok := t.bitmarks[id]
+	if !ok {
+		return apiError(http.StatusNotFound, "bitmark not found")
+	}
+	return http.StatusOK, map[string]interface{}{"bitmark": b}
+}
+
+func (t *Transport) listBitmarks(req *http.Request) (int, interface{}) {
+	query := req.URL.Query()
+
+	bitmarks := make([]*bitmark.Bitmark, 0)
+	for _, id := range t.bitmarkIDs {
+		b := t.bitmarks[id]
+		if v := query.Get("asset_id"); v != "" && b.AssetID != v {
+			continue
+		}
+		if v := query.Get("issuer"); v != "" && b.Issuer != v {
+			continue
+		}
+		if v := query.Get("owner"); v != "" && b.Owner != v {
+			continue
+		}
+		bitmarks = append(bitmarks, b)
+	}
+	return http.StatusOK, map[string]interface{}{
+		"bitmarks": bitmarks,
+		"assets":   []*asset.Asset{},
+	}
+}
@@ -36,6 +36,19 @@ func DownloadArchive(sess *session.Session, bucket, key string, file io.WriterAt
 	return nil
 }
 
+// DownloadStream reads an object from S3 in sequence, which is slower than DownloadArchive
+// but lets the data be processed while it is downloaded
+func DownloadStream(sess *session.Session, bucket, key string) (io.ReadCloser, error) {
+	output, err := s3.New(sess).GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
 func UploadDir(sess *session.Session, bucket, keyPrefix, dirpath string) error {
 	if _, err := os.Stat(dirpath); os.IsNotExist(err) {
 		return nil
@@ -45,6 +58,21 @@ func UploadDir(sess *session.Session, bucket, keyPrefix, dirpath string) error {
 	return svc.UploadWithIterator(aws.BackgroundContext(), iter)
 }
 
+// UploadStream uploads data from a reader to S3 without holding the whole data in memory
+func UploadStream(sess *session.Session, bucket, key string, data io.Reader) error {
+	svc := s3manager.NewUploader(sess, func(u *s3manager.Uploader) {
+		// the data is read in parts of 5MB with a part for each concurrent upload
+		u.Concurrency = 1
+	})
+
+	_, err := svc.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   data,
+	})
+	return err
+}
+
 // UploadArchive upload archive files to S3
 func UploadFile(sess *session.Session, data io.Reader, fileKey string, metadata map[string]*string) error {
 	logEntity := log.WithField("prefix", "s3_util")
@@ -79,6 +107,16 @@ func UploadArchive(sess *session.Session, data io.Reader, accountNumber, archive
 	return s3Key, err
 }
 
+// DeleteFile deletes a file from S3
+func DeleteFile(sess *session.Session, fileKey string) error {
+	svc := s3.New(sess)
+	_, err := svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(viper.GetString("aws.s3.bucket")),
+		Key:    aws.String(fileKey),
+	})
+	return err
+}
+
 // GetMediaPresignedURL returns the presigned link from S3 by a specific file path and a specific time
 func GetMediaPresignedURL(sess *session.Session, s3Key string, expire time.Duration) (string, error) {
 	logEntity := log.WithField("prefix", "s3_util")
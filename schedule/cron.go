@@ -0,0 +1,178 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// descriptors are the shorthands of common schedules
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a cron schedule in the format of "minute hour day-of-month month day-of-week".
+// Every field is either *, a number, a range like 1-5, or a list of them like 1,3,5,
+// and each of them may have a step like */15 or 0-30/10.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// days match either the day of month or the day of week if both of them are restricted
+	domStar, dowStar bool
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	// 7 is sunday as well as 0
+	dowBounds = bounds{0, 7}
+)
+
+// Parse parses a cron schedule
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expr, ok := descriptors[spec]; ok {
+		spec = expr
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in the schedule, found %d: %s", len(fields), spec)
+	}
+
+	var s Schedule
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return &s, nil
+}
+
+// parseField parses a field of a schedule into the bits of the matched values
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		rangeExpr, step := expr, 1
+		if i := strings.Index(expr, "/"); i >= 0 {
+			rangeExpr = expr[:i]
+
+			var err error
+			if step, err = strconv.Atoi(expr[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in the schedule: %s", expr)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangeExpr == "*":
+			low, high = b.min, b.max
+		case strings.Contains(rangeExpr, "-"):
+			values := strings.SplitN(rangeExpr, "-", 2)
+
+			var err error
+			if low, err = strconv.Atoi(values[0]); err != nil {
+				return 0, fmt.Errorf("invalid range in the schedule: %s", expr)
+			}
+			if high, err = strconv.Atoi(values[1]); err != nil {
+				return 0, fmt.Errorf("invalid range in the schedule: %s", expr)
+			}
+		default:
+			var err error
+			if low, err = strconv.Atoi(rangeExpr); err != nil {
+				return 0, fmt.Errorf("invalid value in the schedule: %s", expr)
+			}
+
+			// a value with a step like 5/15 starts from the value
+			high = low
+			if rangeExpr != expr {
+				high = b.max
+			}
+		}
+
+		if low < b.min || high > b.max || low > high {
+			return 0, fmt.Errorf("value out of range [%d, %d] in the schedule: %s", b.min, b.max, expr)
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the first time matching the schedule after a time,
+// or the zero time if no time matches in five years
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+
+	limit := t.Year() + 5
+	for t.Year() <= limit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
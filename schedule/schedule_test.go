@@ -0,0 +1,111 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, spec := range []string{
+		"* * * * *",
+		"*/15 * * * *",
+		"0 3 * * *",
+		"0,30 9-17 * * 1-5",
+		"5/20 0-12/4 1,15 * 7",
+		"@daily",
+	} {
+		_, err := Parse(spec)
+		assert.NoError(t, err, spec)
+	}
+
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@sometimes",
+	} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2020-03-02 is a monday
+	from := time.Date(2020, 3, 2, 10, 7, 30, 0, time.UTC)
+
+	for spec, next := range map[string]time.Time{
+		"* * * * *":            time.Date(2020, 3, 2, 10, 8, 0, 0, time.UTC),
+		"*/15 * * * *":         time.Date(2020, 3, 2, 10, 15, 0, 0, time.UTC),
+		"0 3 * * *":            time.Date(2020, 3, 3, 3, 0, 0, 0, time.UTC),
+		"30 10 * * 1-5":        time.Date(2020, 3, 2, 10, 30, 0, 0, time.UTC),
+		"0 0 * * 0":            time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC),
+		"0 0 * * 7":            time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * *":            time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":           time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		"5/20 * * * *":         time.Date(2020, 3, 2, 10, 25, 0, 0, time.UTC),
+		"@yearly":              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		"0 12 15 * 3":          time.Date(2020, 3, 4, 12, 0, 0, 0, time.UTC),
+		"0 0 31 4 *":           {},
+		"0 9-17/4 * * *":       time.Date(2020, 3, 2, 13, 0, 0, 0, time.UTC),
+		"10,20,40 10,11 2 3 *": time.Date(2020, 3, 2, 10, 10, 0, 0, time.UTC),
+	} {
+		s, err := Parse(spec)
+		assert.NoError(t, err, spec)
+		assert.Equal(t, next, s.Next(from), spec)
+	}
+}
+
+type testLocker map[string]bool
+
+func (l testLocker) Lock(key string, ttl time.Duration) (bool, error) {
+	if l[key] {
+		return false, nil
+	}
+	l[key] = true
+	return true, nil
+}
+
+func TestSchedulerRunDue(t *testing.T) {
+	now := time.Date(2020, 3, 2, 10, 7, 30, 0, time.UTC)
+	locker := testLocker{}
+
+	// schedulers of two workers share the locker
+	runs := 0
+	schedulers := make([]*Scheduler, 0)
+	for i := 0; i < 2; i++ {
+		s := New(locker)
+		s.now = func() time.Time { return now }
+		assert.NoError(t, s.Add("sweep", "*/15 * * * *", func() error {
+			runs++
+			return nil
+		}))
+		assert.Error(t, s.Add("invalid", "* * *", nil))
+		schedulers = append(schedulers, s)
+	}
+
+	next := schedulers[0].nextRun()
+	assert.Equal(t, time.Date(2020, 3, 2, 10, 15, 0, 0, time.UTC), next)
+
+	// jobs not due yet are not run
+	schedulers[0].runDue(next.Add(-time.Second))
+	assert.Equal(t, 0, runs)
+
+	// a run of a job is started by one of the workers
+	for _, s := range schedulers {
+		s.runDue(next)
+	}
+	assert.Equal(t, 1, runs)
+
+	for _, s := range schedulers {
+		assert.Equal(t, time.Date(2020, 3, 2, 10, 30, 0, 0, time.UTC), s.nextRun())
+	}
+}
@@ -0,0 +1,150 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+)
+
+// Locker makes sure that a run of a job is started by only one of the schedulers sharing the locker,
+// like the schedulers of all of the background workers
+type Locker interface {
+	Lock(key string, ttl time.Duration) (bool, error)
+}
+
+type entry struct {
+	name     string
+	schedule *Schedule
+	run      func() error
+	next     time.Time
+}
+
+// Scheduler runs jobs on their cron schedules
+type Scheduler struct {
+	locker  Locker
+	entries []*entry
+	now     func() time.Time
+}
+
+// New creates a scheduler running jobs with a locker
+func New(locker Locker) *Scheduler {
+	return &Scheduler{
+		locker: locker,
+		now:    time.Now,
+	}
+}
+
+// Add adds a job running on a cron schedule
+func (s *Scheduler) Add(name, spec string, run func() error) error {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("invalid schedule of job %s: %s", name, err)
+	}
+
+	s.entries = append(s.entries, &entry{
+		name:     name,
+		schedule: schedule,
+		run:      run,
+		next:     schedule.Next(s.now()),
+	})
+	return nil
+}
+
+// Run runs the jobs on their schedules until the context is done
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		next := s.nextRun()
+		if next.IsZero() {
+			<-ctx.Done()
+			return
+		}
+
+		timer := time.NewTimer(next.Sub(s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.runDue(next)
+		}
+	}
+}
+
+// nextRun returns the earliest time to run a job, or the zero time if there is no job to run
+func (s *Scheduler) nextRun() time.Time {
+	runs := make([]time.Time, 0, len(s.entries))
+	for _, e := range s.entries {
+		if !e.next.IsZero() {
+			runs = append(runs, e.next)
+		}
+	}
+	if len(runs) == 0 {
+		return time.Time{}
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Before(runs[j]) })
+	return runs[0]
+}
+
+// runDue runs the jobs scheduled at or before a time, and moves them to their next runs
+func (s *Scheduler) runDue(at time.Time) {
+	for _, e := range s.entries {
+		if e.next.IsZero() || e.next.After(at) {
+			continue
+		}
+
+		scheduled := e.next
+		e.next = e.schedule.Next(scheduled)
+
+		logEntity := log.WithField("prefix", "schedule").WithField("job", e.name).WithField("scheduled_at", scheduled)
+
+		// the lock is kept until the next run so that it is not taken again by a worker with a late clock
+		ttl := time.Hour
+		if !e.next.IsZero() {
+			ttl = e.next.Sub(scheduled)
+		}
+		locked, err := s.locker.Lock(fmt.Sprintf("%s:%d", e.name, scheduled.Unix()), ttl)
+		if err != nil {
+			logEntity.Error(err)
+			sentry.CaptureException(err)
+			continue
+		}
+		if !locked {
+			logEntity.Debug("the job is run by another worker")
+			continue
+		}
+
+		logEntity.Info("run the scheduled job")
+		if err := e.run(); err != nil {
+			logEntity.Error(err)
+			sentry.CaptureException(err)
+		}
+	}
+}
+
+// RedisLocker locks the runs of jobs in redis
+type RedisLocker struct {
+	pool *redis.Pool
+}
+
+// NewRedisLocker creates a locker with a pool of redis connections
+func NewRedisLocker(pool *redis.Pool) *RedisLocker {
+	return &RedisLocker{pool: pool}
+}
+
+// Lock takes the lock of a key if it is not taken yet
+func (l *RedisLocker) Lock(key string, ttl time.Duration) (bool, error) {
+	conn := l.pool.Get()
+	defer conn.Close()
+
+	_, err := redis.String(conn.Do("SET", "spring:schedule:"+key, 1, "NX", "PX", int64(ttl/time.Millisecond)))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	return err == nil, err
+}
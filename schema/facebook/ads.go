@@ -0,0 +1,103 @@
+package facebook
+
+import (
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// RawAdvertisers are advertisers who uploaded a contact list with the information of the user
+type RawAdvertisers struct {
+	CustomAudiences []MojibakeString `json:"custom_audiences" jsonschema:"required"`
+}
+
+func AdvertiserSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawAdvertisers{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type AdvertiserORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Name        string    `gorm:"unique_index:facebook_advertiser_owner_name_unique"`
+	ArchiveID   *int64    `gorm:"index"`
+	DataOwnerID string    `gorm:"unique_index:facebook_advertiser_owner_name_unique"`
+}
+
+func (AdvertiserORM) TableName() string {
+	return "facebook_advertiser"
+}
+
+func (r RawAdvertisers) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+
+	seen := make(map[string]bool)
+	for _, a := range r.CustomAudiences {
+		name := string(a)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		result = append(result, AdvertiserORM{
+			Name:        name,
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
+
+// RawAdsInterests are the topics facebook uses to target ads to the user
+type RawAdsInterests struct {
+	Topics []MojibakeString `json:"topics" jsonschema:"required"`
+}
+
+func AdsInterestSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawAdsInterests{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type AdsInterestORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Topic       string    `gorm:"unique_index:facebook_ads_interest_owner_topic_unique"`
+	ArchiveID   *int64    `gorm:"index"`
+	DataOwnerID string    `gorm:"unique_index:facebook_ads_interest_owner_topic_unique"`
+}
+
+func (AdsInterestORM) TableName() string {
+	return "facebook_ads_interest"
+}
+
+func (r RawAdsInterests) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+
+	seen := make(map[string]bool)
+	for _, t := range r.Topics {
+		topic := string(t)
+		if seen[topic] {
+			continue
+		}
+		seen[topic] = true
+
+		result = append(result, AdsInterestORM{
+			Topic:       topic,
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
@@ -52,6 +52,7 @@ type CommentORM struct {
 	Comment               string
 	Date                  string
 	Weekday               int
+	ArchiveID             *int64 `gorm:"index"`
 	DataOwnerID           string `gorm:"unique_index:facebook_comment_owner_timestamp_unique"`
 	MediaAttached         bool
 	ExternalContextURL    string
@@ -73,6 +74,7 @@ type CommentMediaORM struct {
 	FilenameExtension string
 	Timestamp         int64      `gorm:"unique_index:facebook_commentmedia_owner_comment_id_timestamp_unique"`
 	MediaIndex        int64      `gorm:"unique_index:facebook_commentmedia_owner_comment_id_timestamp_unique"`
+	ArchiveID         *int64     `gorm:"index"`
 	DataOwnerID       string     `gorm:"unique_index:facebook_commentmedia_owner_comment_id_timestamp_unique"`
 	Comment           CommentORM `gorm:"foreignkey:CommentID" json:"-"`
 	CommentID         uuid.UUID  `gorm:"unique_index:facebook_commentmedia_owner_comment_id_timestamp_unique"`
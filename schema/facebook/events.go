@@ -12,6 +12,7 @@ type EventORM struct {
 	Type           string    `json:"type"`
 	StartTimestamp int64     `json:"start_timestamp" gorm:"unique_index:facebook_event_owner_start_end_timestamp_unique"`
 	EndTimestamp   int64     `json:"end_timestamp" gorm:"unique_index:facebook_event_owner_start_end_timestamp_unique"`
+	ArchiveID      *int64    `gorm:"index"`
 	DataOwnerID    string    `json:"-" gorm:"unique_index:facebook_event_owner_start_end_timestamp_unique"`
 }
 
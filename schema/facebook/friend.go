@@ -33,6 +33,7 @@ type FriendORM struct {
 	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
 	FriendName  string
 	Timestamp   int64  `gorm:"unique_index:facebook_friend_owner_timestamp_unique"`
+	ArchiveID   *int64 `gorm:"index"`
 	DataOwnerID string `gorm:"unique_index:facebook_friend_owner_timestamp_unique"`
 }
 
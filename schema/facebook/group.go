@@ -0,0 +1,142 @@
+package facebook
+
+import (
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawGroupMembership struct {
+	GroupsJoined []*GroupJoined `json:"groups_joined" jsonschema:"required"`
+}
+
+type GroupJoined struct {
+	Timestamp int64          `json:"timestamp" jsonschema:"required"`
+	Title     MojibakeString `json:"title" jsonschema:"required"`
+	Data      []*GroupData   `json:"data"`
+}
+
+type GroupData struct {
+	Name MojibakeString `json:"name"`
+}
+
+func GroupMembershipSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawGroupMembership{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type GroupORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Name        string    `gorm:"unique_index:facebook_group_owner_name_unique"`
+	Timestamp   int64
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string `gorm:"unique_index:facebook_group_owner_name_unique"`
+}
+
+func (GroupORM) TableName() string {
+	return "facebook_group"
+}
+
+func (r RawGroupMembership) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+
+	seen := make(map[string]bool)
+	for _, g := range r.GroupsJoined {
+		// the name of a group is given in data, or the title otherwise
+		name := string(g.Title)
+		if len(g.Data) > 0 && g.Data[0].Name != "" {
+			name = string(g.Data[0].Name)
+		}
+
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		result = append(result, GroupORM{
+			Name:        name,
+			Timestamp:   g.Timestamp,
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
+
+type RawGroupPosts struct {
+	GroupPosts GroupPostsLog `json:"group_posts" jsonschema:"required"`
+}
+
+type GroupPostsLog struct {
+	ActivityLogData []*GroupPost `json:"activity_log_data" jsonschema:"required"`
+}
+
+type GroupPost struct {
+	Timestamp int64            `json:"timestamp" jsonschema:"required"`
+	Title     MojibakeString   `json:"title"`
+	Data      []*GroupPostData `json:"data"`
+}
+
+type GroupPostData struct {
+	Post MojibakeString `json:"post"`
+}
+
+func GroupPostsSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawGroupPosts{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type GroupPostORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Timestamp   int64     `gorm:"unique_index:facebook_group_post_owner_timestamp_unique"`
+	Date        string
+	Weekday     int
+	Title       string
+	Post        string
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string `gorm:"unique_index:facebook_group_post_owner_timestamp_unique"`
+}
+
+func (GroupPostORM) TableName() string {
+	return "facebook_group_post"
+}
+
+func (r RawGroupPosts) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, p := range r.GroupPosts.ActivityLogData {
+		t := time.Unix(p.Timestamp, 0)
+		orm := GroupPostORM{
+			Timestamp:   p.Timestamp,
+			Date:        dateOfTime(t),
+			Weekday:     weekdayOfTime(t),
+			Title:       string(p.Title),
+			DataOwnerID: owner,
+		}
+		for _, d := range p.Data {
+			if d.Post != "" {
+				orm.Post = string(d.Post)
+				break
+			}
+		}
+
+		result = append(result, orm)
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package facebook
+
+import (
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawMessageThread struct {
+	Participants       []*MessageParticipant `json:"participants" jsonschema:"required"`
+	Messages           []*Message            `json:"messages" jsonschema:"required"`
+	Title              MojibakeString        `json:"title"`
+	IsStillParticipant bool                  `json:"is_still_participant"`
+	ThreadType         string                `json:"thread_type"`
+	ThreadPath         string                `json:"thread_path" jsonschema:"required"`
+}
+
+type MessageParticipant struct {
+	Name MojibakeString `json:"name" jsonschema:"required"`
+}
+
+type Message struct {
+	SenderName  MojibakeString `json:"sender_name" jsonschema:"required"`
+	TimestampMS int64          `json:"timestamp_ms" jsonschema:"required"`
+	Content     MojibakeString `json:"content"`
+	Type        string         `json:"type"`
+}
+
+func MessageThreadSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawMessageThread{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type MessageThreadORM struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	ThreadPath         string    `gorm:"unique_index:facebook_message_thread_owner_path_unique"`
+	Title              string
+	ThreadType         string
+	IsStillParticipant bool
+	Participants       pq.StringArray `gorm:"type:text[]"`
+	ArchiveID          *int64         `gorm:"index"`
+	DataOwnerID        string         `gorm:"unique_index:facebook_message_thread_owner_path_unique"`
+}
+
+func (MessageThreadORM) TableName() string {
+	return "facebook_message_thread"
+}
+
+type MessageORM struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Thread      MessageThreadORM `gorm:"foreignkey:ThreadID" json:"-"`
+	ThreadID    uuid.UUID        `gorm:"unique_index:facebook_message_thread_sender_timestamp_unique"`
+	SenderName  string           `gorm:"unique_index:facebook_message_thread_sender_timestamp_unique"`
+	TimestampMS int64            `gorm:"unique_index:facebook_message_thread_sender_timestamp_unique"`
+	Timestamp   int64
+	Date        string
+	Weekday     int
+	Content     string
+	Type        string
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string
+}
+
+func (MessageORM) TableName() string {
+	return "facebook_message"
+}
+
+// ThreadORM returns the thread of messages. A long thread is split into
+// many files, so the thread is identified by its path.
+func (r RawMessageThread) ThreadORM(owner string) MessageThreadORM {
+	participants := make([]string, 0)
+	for _, p := range r.Participants {
+		participants = append(participants, string(p.Name))
+	}
+
+	return MessageThreadORM{
+		ThreadPath:         r.ThreadPath,
+		Title:              string(r.Title),
+		ThreadType:         r.ThreadType,
+		IsStillParticipant: r.IsStillParticipant,
+		Participants:       participants,
+		DataOwnerID:        owner,
+	}
+}
+
+func (r RawMessageThread) ORM(owner string, threadID uuid.UUID) []interface{} {
+	result := make([]interface{}, 0)
+	for _, m := range r.Messages {
+		timestamp := m.TimestampMS / 1000
+		t := time.Unix(timestamp, 0)
+		result = append(result, MessageORM{
+			ThreadID:    threadID,
+			SenderName:  string(m.SenderName),
+			TimestampMS: m.TimestampMS,
+			Timestamp:   timestamp,
+			Date:        dateOfTime(t),
+			Weekday:     weekdayOfTime(t),
+			Content:     string(m.Content),
+			Type:        m.Type,
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
@@ -5,7 +5,10 @@ import (
 	"strings"
 
 	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/schema/instagram"
+	"github.com/bitmark-inc/spring-app-api/schema/provenance"
 	"github.com/bitmark-inc/spring-app-api/schema/spring"
+	"github.com/bitmark-inc/spring-app-api/schema/twitter"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
 	"github.com/spf13/viper"
@@ -32,15 +35,32 @@ func main() {
 	}
 
 	db.AutoMigrate(
+		&facebook.AdvertiserORM{},
+		&facebook.AdsInterestORM{},
 		&facebook.CommentORM{},
 		&facebook.CommentMediaORM{},
 		&facebook.EventORM{},
 		&facebook.FriendORM{},
+		&facebook.GroupORM{},
+		&facebook.GroupPostORM{},
+		&facebook.MessageThreadORM{},
+		&facebook.MessageORM{},
+		&facebook.PageLikeORM{},
 		&facebook.PlaceORM{},
 		&facebook.PostORM{},
 		&facebook.PostMediaORM{},
 		&facebook.ReactionORM{},
+		&facebook.SearchORM{},
 		&facebook.TagORM{},
+		&instagram.PostORM{},
+		&instagram.StoryORM{},
+		&instagram.LikeORM{},
+		&instagram.CommentORM{},
+		&instagram.FollowerORM{},
+		&twitter.TweetORM{},
+		&twitter.LikeORM{},
+		&twitter.DirectMessageORM{},
+		&twitter.FollowerORM{},
 		&spring.ArchiveORM{},
 	)
 
@@ -83,6 +103,131 @@ func main() {
 	db.Model(facebook.EventORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
 	db.Model(facebook.EventORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
 
+	db.Model(facebook.MessageThreadORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(facebook.MessageThreadORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(facebook.MessageORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(facebook.MessageORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+	db.Model(facebook.MessageORM{}).RemoveForeignKey("thread_id", "facebook_message_thread(id)")
+	db.Model(facebook.MessageORM{}).AddForeignKey("thread_id", "facebook_message_thread(id)", "CASCADE", "NO ACTION")
+
+	db.Model(facebook.GroupORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(facebook.GroupORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(facebook.GroupPostORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(facebook.GroupPostORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(facebook.PageLikeORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(facebook.PageLikeORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(facebook.SearchORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(facebook.SearchORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(facebook.AdvertiserORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(facebook.AdvertiserORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(facebook.AdsInterestORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(facebook.AdsInterestORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(instagram.PostORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(instagram.PostORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(instagram.StoryORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(instagram.StoryORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(instagram.LikeORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(instagram.LikeORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(instagram.CommentORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(instagram.CommentORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(instagram.FollowerORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(instagram.FollowerORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(twitter.TweetORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(twitter.TweetORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(twitter.LikeORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(twitter.LikeORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(twitter.DirectMessageORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(twitter.DirectMessageORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Model(twitter.FollowerORM{}).RemoveForeignKey("data_owner_id", "account(account_number)")
+	db.Model(twitter.FollowerORM{}).AddForeignKey("data_owner_id", "account(account_number)", "CASCADE", "NO ACTION")
+
+	db.Exec(`ALTER TYPE archive_status ADD VALUE IF NOT EXISTS 'removed'`)
+	db.Exec(`ALTER TABLE fbarchive ADD COLUMN IF NOT EXISTS merge_summary JSONB`)
+	db.Exec(`ALTER TABLE fbarchive ADD COLUMN IF NOT EXISTS workflow_id TEXT DEFAULT ''`)
+	db.Exec(`ALTER TABLE fbarchive ADD COLUMN IF NOT EXISTS bitmark_id TEXT DEFAULT ''`)
+	// fb stats stored in postgres when fbdata.store is postgres. Stats are upserted by
+	// the primary key (key, timestamp), which indexes them as well.
+	db.Exec(`CREATE TABLE IF NOT EXISTS fbstat (
+		key TEXT NOT NULL,
+		timestamp BIGINT NOT NULL,
+		data BYTEA NOT NULL,
+		PRIMARY KEY (key, timestamp)
+	)`)
+	db.Exec(`DROP INDEX IF EXISTS fbstat_key_timestamp`)
+	db.Exec(`CREATE TABLE IF NOT EXISTS fbarchive_stage (
+		archive_id INTEGER NOT NULL REFERENCES fbarchive(id) ON DELETE CASCADE,
+		name TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'running',
+		item_count BIGINT NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		started_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+		finished_at TIMESTAMP WITH TIME ZONE,
+		PRIMARY KEY (archive_id, name)
+	)`)
+	db.Exec(`CREATE TABLE IF NOT EXISTS token (
+		id TEXT NOT NULL PRIMARY KEY,
+		account_number TEXT NOT NULL REFERENCES account(account_number) ON DELETE CASCADE,
+		info JSONB NOT NULL DEFAULT '{}'::json,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+		expired_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+	)`)
+	db.Exec(`CREATE INDEX IF NOT EXISTS token_account_number ON token (account_number)`)
+	db.Exec(`CREATE TABLE IF NOT EXISTS revoked_token (
+		account_number TEXT NOT NULL,
+		id TEXT NOT NULL DEFAULT '',
+		revoked_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+		expired_at TIMESTAMP WITH TIME ZONE NOT NULL,
+		PRIMARY KEY (account_number, id)
+	)`)
+	db.Exec(`CREATE TABLE IF NOT EXISTS api_key (
+		name TEXT NOT NULL PRIMARY KEY,
+		key_hash TEXT NOT NULL UNIQUE,
+		scopes TEXT[] NOT NULL DEFAULT '{}',
+		expired_at TIMESTAMP WITH TIME ZONE,
+		last_used_at TIMESTAMP WITH TIME ZONE,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+	)`)
+	db.Exec(`CREATE TABLE IF NOT EXISTS audit_log (
+		id BIGSERIAL PRIMARY KEY,
+		actor TEXT NOT NULL,
+		action TEXT NOT NULL,
+		params JSONB NOT NULL DEFAULT '{}',
+		status INTEGER NOT NULL DEFAULT 0,
+		remote_addr TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+	)`)
+	db.Exec(`CREATE INDEX IF NOT EXISTS audit_log_actor ON audit_log (actor)`)
+
+	// Every imported record references the archive it is imported from.
+	// Records imported before archives are tracked belong to the last archive of their owners.
+	for _, m := range provenance.Models {
+		db.Model(m).RemoveForeignKey("archive_id", "fbarchive(id)")
+		db.Model(m).AddForeignKey("archive_id", "fbarchive(id)", "CASCADE", "NO ACTION")
+
+		db.Exec(fmt.Sprintf(`UPDATE %[1]s SET archive_id = (SELECT MAX(id) FROM fbarchive WHERE fbarchive.account_number = %[1]s.data_owner_id)
+			WHERE archive_id IS NULL`, db.NewScope(m).TableName()))
+	}
+
+	// Full-text search indexes. The expressions must match the ones used by the search api.
+	db.Exec(`CREATE INDEX IF NOT EXISTS facebook_post_post_fts ON facebook_post USING GIN (to_tsvector('simple', post))`)
+	db.Exec(`CREATE INDEX IF NOT EXISTS facebook_comment_comment_fts ON facebook_comment USING GIN (to_tsvector('simple', comment))`)
+	db.Exec(`CREATE INDEX IF NOT EXISTS facebook_reaction_title_fts ON facebook_reaction USING GIN (to_tsvector('simple', title))`)
+
 	db.Model(spring.ArchiveORM{}).RemoveForeignKey("account_number", "account(account_number)")
 	db.Model(spring.ArchiveORM{}).AddForeignKey("account_number", "account(account_number)", "CASCADE", "NO ACTION")
 	db.Model(spring.ArchiveORM{}).Where(fmt.Sprintf("status != '%s' AND status != '%s'", "FAILURE", "SUCCESS")).
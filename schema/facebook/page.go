@@ -0,0 +1,61 @@
+package facebook
+
+import (
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawPageLikes struct {
+	PageLikes []*PageLike `json:"page_likes" jsonschema:"required"`
+}
+
+type PageLike struct {
+	Name      MojibakeString `json:"name" jsonschema:"required"`
+	Timestamp int64          `json:"timestamp" jsonschema:"required"`
+}
+
+func PageLikesSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawPageLikes{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type PageLikeORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Name        string    `gorm:"unique_index:facebook_page_like_owner_name_unique"`
+	Timestamp   int64
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string `gorm:"unique_index:facebook_page_like_owner_name_unique"`
+}
+
+func (PageLikeORM) TableName() string {
+	return "facebook_page_like"
+}
+
+func (r RawPageLikes) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+
+	seen := make(map[string]bool)
+	for _, p := range r.PageLikes {
+		name := string(p.Name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		result = append(result, PageLikeORM{
+			Name:        name,
+			Timestamp:   p.Timestamp,
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
@@ -3,6 +3,8 @@ package facebook
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -12,14 +14,21 @@ import (
 )
 
 var (
-	FriendsPattern        = Pattern{Name: "friends", Location: "friends", Regexp: regexp.MustCompile("^friends.json"), Schema: FriendSchemaLoader()}
-	PostsPattern          = Pattern{Name: "posts", Location: "posts", Regexp: regexp.MustCompile("your_posts(?P<index>_[0-9]+).json"), Schema: PostArraySchemaLoader()}
-	ReactionsPattern      = Pattern{Name: "reactions", Location: "likes_and_reactions", Regexp: regexp.MustCompile("posts_and_comments.json"), Schema: ReactionSchemaLoader()}
-	CommentsPattern       = Pattern{Name: "comments", Location: "comments", Regexp: regexp.MustCompile("comments.json"), Schema: CommentArraySchemaLoader()}
-	InvitedEventPattern   = Pattern{Name: "invited_events", Location: "events", Regexp: regexp.MustCompile("event_invitations.json"), Schema: InvitedEventSchemaLoader()}
-	RespondedEventPattern = Pattern{Name: "responded_events", Location: "events", Regexp: regexp.MustCompile("your_event_responses.json"), Schema: RespondedEventSchemaLoader()}
-	MediaPattern          = Pattern{Name: "media", Location: "photos_and_videos"}
-	FilesPattern          = Pattern{Name: "files", Location: "files"}
+	FriendsPattern         = Pattern{Name: "friends", Location: "friends", Regexp: regexp.MustCompile("^friends.json"), Schema: FriendSchemaLoader(), Stream: true, ArrayKey: "friends"}
+	PostsPattern           = Pattern{Name: "posts", Location: "posts", Regexp: regexp.MustCompile("your_posts(?P<index>_[0-9]+).json"), Schema: PostArraySchemaLoader(), Stream: true}
+	ReactionsPattern       = Pattern{Name: "reactions", Location: "likes_and_reactions", Regexp: regexp.MustCompile("posts_and_comments.json"), Schema: ReactionSchemaLoader(), Stream: true, ArrayKey: "reactions"}
+	CommentsPattern        = Pattern{Name: "comments", Location: "comments", Regexp: regexp.MustCompile("comments.json"), Schema: CommentArraySchemaLoader(), Stream: true, ArrayKey: "comments"}
+	InvitedEventPattern    = Pattern{Name: "invited_events", Location: "events", Regexp: regexp.MustCompile("event_invitations.json"), Schema: InvitedEventSchemaLoader(), Stream: true, ArrayKey: "events_invited"}
+	RespondedEventPattern  = Pattern{Name: "responded_events", Location: "events", Regexp: regexp.MustCompile("your_event_responses.json"), Schema: RespondedEventSchemaLoader()}
+	MessagesPattern        = Pattern{Name: "messages", Location: "messages", Regexp: regexp.MustCompile("^message_[0-9]+.json$"), Schema: MessageThreadSchemaLoader(), Recursive: true}
+	GroupMembershipPattern = Pattern{Name: "groups", Location: "groups", Regexp: regexp.MustCompile("your_group_membership_activity.json"), Schema: GroupMembershipSchemaLoader(), Stream: true, ArrayKey: "groups_joined"}
+	GroupPostsPattern      = Pattern{Name: "group_posts", Location: "groups", Regexp: regexp.MustCompile("your_posts_and_comments_in_groups.json"), Schema: GroupPostsSchemaLoader()}
+	PageLikesPattern       = Pattern{Name: "page_likes", Location: "pages", Regexp: regexp.MustCompile("^pages_you'?ve_liked.json$"), Schema: PageLikesSchemaLoader(), Stream: true, ArrayKey: "page_likes"}
+	SearchesPattern        = Pattern{Name: "searches", Location: "search_history", Regexp: regexp.MustCompile("your_search_history.json"), Schema: SearchSchemaLoader(), Stream: true, ArrayKey: "searches"}
+	AdvertisersPattern     = Pattern{Name: "advertisers", Location: "ads_and_businesses", Regexp: regexp.MustCompile("advertisers_who_uploaded_a_contact_list_with_your_information.json"), Schema: AdvertiserSchemaLoader(), Stream: true, ArrayKey: "custom_audiences"}
+	AdsInterestsPattern    = Pattern{Name: "ads_interests", Location: "ads_and_businesses", Regexp: regexp.MustCompile("ads_interests.json"), Schema: AdsInterestSchemaLoader(), Stream: true, ArrayKey: "topics"}
+	MediaPattern           = Pattern{Name: "media", Location: "photos_and_videos"}
+	FilesPattern           = Pattern{Name: "files", Location: "files"}
 )
 
 type Pattern struct {
@@ -27,6 +36,34 @@ type Pattern struct {
 	Location string
 	Regexp   *regexp.Regexp
 	Schema   *gojsonschema.Schema
+
+	// Recursive looks for files in all of the sub directories of the location,
+	// for sections like messages which keep each thread in its own directory
+	Recursive bool
+
+	// Stream decodes the items of the array of a file one by one instead of the whole file,
+	// for sections which may grow too large to be kept in memory.
+	// ArrayKey is the key of the array in the top level object, or empty if the file is an array.
+	Stream   bool
+	ArrayKey string
+}
+
+// Match checks if a file of an archive, named by its path from the root of the archive, belongs to the pattern.
+// Patterns without a regexp, like media, take all of the files in their location.
+// Files at the root of the archive are in the empty location.
+func (p *Pattern) Match(name string) bool {
+	dir := path.Dir(name)
+	if dir == "." {
+		dir = ""
+	}
+	if p.Regexp == nil || p.Recursive {
+		if dir != p.Location && !strings.HasPrefix(dir, p.Location+"/") {
+			return false
+		}
+		return p.Regexp == nil || p.Regexp.MatchString(path.Base(name))
+	}
+
+	return dir == p.Location && p.Regexp.MatchString(path.Base(name))
 }
 
 func (p *Pattern) SelectFiles(fs afero.Fs, dirname string) ([]string, error) {
@@ -40,6 +77,23 @@ func (p *Pattern) SelectFiles(fs afero.Fs, dirname string) ([]string, error) {
 		return nil, nil
 	}
 
+	if p.Recursive {
+		if err := afero.Walk(fs, dirname, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !info.IsDir() && p.Regexp.MatchString(info.Name()) {
+				targetedFiles = append(targetedFiles, path)
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to walk dir %s: %s", dirname, err)
+		}
+
+		return targetedFiles, nil
+	}
+
 	files, err := afero.ReadDir(fs, dirname)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read dir %s: %s", dirname, err)
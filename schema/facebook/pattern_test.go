@@ -44,3 +44,93 @@ func TestPostsPattern(t *testing.T) {
 	assert.Empty(t, filenames)
 	assert.NoError(t, err)
 }
+
+func TestMessagesPattern(t *testing.T) {
+	cases := map[string]testCase{
+		"/tmp/user-a/messages/inbox/alice_abc/message_1.json":          {`{"participants":[{"name":"Alice"}],"messages":[{"sender_name":"Alice","timestamp_ms":1578201080000,"content":"hi","type":"Generic"}],"title":"Alice","thread_type":"Regular","thread_path":"inbox/alice_abc"}`, true},
+		"/tmp/user-a/messages/inbox/alice_abc/message_2.json":          {`{"participants":[{"name":"Alice"}],"messages":[]}`, false},
+		"/tmp/user-a/messages/archived_threads/bob_def/message_1.json": {`{"participants":[{"name":"Bob"}],"messages":[],"thread_path":"archived_threads/bob_def"}`, true},
+		"/tmp/user-a/messages/inbox/alice_abc/photos/123.json":         {`DOESN'T MATTER`, false},
+		"/tmp/user-a/messages/stickers_used/message_1.json.jpg":        {`DOESN'T MATTER`, false},
+	}
+	fs := afero.NewMemMapFs()
+
+	// create test files and directories
+	fs.MkdirAll("/tmp", 0755)
+	for filename, item := range cases {
+		afero.WriteFile(fs, filename, []byte(item.content), 0644)
+	}
+
+	p := MessagesPattern
+	filenames, err := p.SelectFiles(fs, "/tmp/user-a/messages")
+	assert.Equal(t, []string{
+		"/tmp/user-a/messages/archived_threads/bob_def/message_1.json",
+		"/tmp/user-a/messages/inbox/alice_abc/message_1.json",
+		"/tmp/user-a/messages/inbox/alice_abc/message_2.json",
+	}, filenames)
+	assert.NoError(t, err)
+
+	for _, n := range filenames {
+		data, err := afero.ReadFile(fs, n)
+		assert.NoError(t, err)
+
+		err = p.Validate(data)
+		assert.Equal(t, cases[n].valid, err == nil)
+	}
+
+	filenames, err = p.SelectFiles(fs, "/tmp/user-b/messages")
+	assert.Empty(t, filenames)
+	assert.NoError(t, err)
+}
+
+func TestAdsAndBusinessesPatterns(t *testing.T) {
+	cases := map[string]testCase{
+		"/tmp/user-a/ads_and_businesses/ads_interests.json":                                                 {`{"topics":["Coffee","Travel"]}`, true},
+		"/tmp/user-a/ads_and_businesses/advertisers_who_uploaded_a_contact_list_with_your_information.json": {`{"custom_audiences":["Shop"]}`, true},
+		"/tmp/user-a/ads_and_businesses/advertisers_you've_interacted_with.json":                            {`DOESN'T MATTER`, false},
+	}
+	fs := afero.NewMemMapFs()
+
+	// create test files and directories
+	fs.MkdirAll("/tmp", 0755)
+	for filename, item := range cases {
+		afero.WriteFile(fs, filename, []byte(item.content), 0644)
+	}
+
+	for p, expected := range map[*Pattern]string{
+		&AdsInterestsPattern: "/tmp/user-a/ads_and_businesses/ads_interests.json",
+		&AdvertisersPattern:  "/tmp/user-a/ads_and_businesses/advertisers_who_uploaded_a_contact_list_with_your_information.json",
+	} {
+		filenames, err := p.SelectFiles(fs, "/tmp/user-a/ads_and_businesses")
+		assert.Equal(t, []string{expected}, filenames)
+		assert.NoError(t, err)
+
+		data, err := afero.ReadFile(fs, expected)
+		assert.NoError(t, err)
+		assert.NoError(t, p.Validate(data))
+		assert.Error(t, p.Validate([]byte(`{"key": "value"}`)))
+	}
+}
+
+func TestPatternMatch(t *testing.T) {
+	cases := []struct {
+		pattern Pattern
+		name    string
+		match   bool
+	}{
+		{PostsPattern, "posts/your_posts_1.json", true},
+		{PostsPattern, "posts/album/your_posts_1.json", false},
+		{PostsPattern, "comments/your_posts_1.json", false},
+		{MessagesPattern, "messages/inbox/alice_abc/message_1.json", true},
+		{MessagesPattern, "messages/inbox/alice_abc/photos/message_1.jpg", false},
+		{MessagesPattern, "messages_archived/inbox/alice_abc/message_1.json", false},
+		{MediaPattern, "photos_and_videos/album/1.jpg", true},
+		{MediaPattern, "photos_and_videos/1.jpg", true},
+		{MediaPattern, "photos_and_videos_of_you/1.jpg", false},
+		{FilesPattern, "posts/your_posts_1.json", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.match, c.pattern.Match(c.name), c.name)
+	}
+}
@@ -1,6 +1,7 @@
 package facebook
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -9,6 +10,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/bitmark-inc/spring-app-api/sentiment"
 )
 
 type PostORM struct {
@@ -28,6 +31,7 @@ type PostORM struct {
 	EventEndTimestamp     int64
 	MediaAttached         bool
 	Sentiment             string
+	ArchiveID             *int64         `gorm:"index"`
 	DataOwnerID           string         `gorm:"unique_index:facebook_post_owner_timestamp_unique"`
 	MediaItems            []PostMediaORM `gorm:"foreignkey:PostID;association_foreignkey:ID" json:"-"`
 	Places                []PlaceORM     `gorm:"foreignkey:PostID;association_foreignkey:ID" json:"-"`
@@ -54,6 +58,7 @@ type PostMediaORM struct {
 	FilenameExtension string
 	Timestamp         int64     `gorm:"unique_index:facebook_postmedia_owner_post_id_timestamp_unique"`
 	MediaIndex        int64     `gorm:"unique_index:facebook_postmedia_owner_post_id_timestamp_unique"`
+	ArchiveID         *int64    `gorm:"index"`
 	DataOwnerID       string    `gorm:"unique_index:facebook_postmedia_owner_post_id_timestamp_unique"`
 	Post              PostORM   `gorm:"foreignkey:PostID" json:"-"`
 	PostID            uuid.UUID `gorm:"unique_index:facebook_postmedia_owner_post_id_timestamp_unique"`
@@ -69,6 +74,7 @@ type PlaceORM struct {
 	Address     string
 	Latitude    float64
 	Longitude   float64
+	ArchiveID   *int64    `gorm:"index"`
 	DataOwnerID string    `gorm:"unique_index:facebook_place_owner_timestamp_unique"`
 	Post        PostORM   `gorm:"foreignkey:PostID" json:"-"`
 	PostID      uuid.UUID `gorm:"unique_index:facebook_place_owner_timestamp_unique"` // NOTE:  one place per post
@@ -80,6 +86,7 @@ func (PlaceORM) TableName() string {
 
 type TagORM struct {
 	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	ArchiveID   *int64    `gorm:"index"`
 	DataOwnerID string    `gorm:"unique_index:facebook_tag_owner_post_friend_unique"`
 	Post        PostORM   `gorm:"foreignkey:PostID" json:"-"`
 	PostID      uuid.UUID `gorm:"unique_index:facebook_tag_owner_post_friend_unique"`
@@ -96,16 +103,14 @@ type RawPosts struct {
 	Items []*RawPost
 }
 
-func (r *RawPosts) ORM(dataOwner, archiveID string, beginTime, endTime int64) ([]interface{}, []PostORM) {
+// ORM converts raw posts into rows, with the sentiment of the posts analyzed by the analyzer.
+// The sentiment of a post is left empty if the analyzer fails.
+func (r *RawPosts) ORM(dataOwner, archiveID string, analyzer sentiment.Analyzer) ([]interface{}, []PostORM) {
 	posts := make([]interface{}, 0)
 	complexPosts := make([]PostORM, 0)
 
 	for _, rp := range r.Items {
-		t := int64(rp.Timestamp)
-		if t >= beginTime && t <= endTime { // omit post within current activity range
-			continue
-		}
-		ts := time.Unix(t, 0)
+		ts := time.Unix(rp.Timestamp, 0)
 		post := PostORM{
 			Timestamp:   rp.Timestamp,
 			Date:        dateOfTime(ts),
@@ -117,7 +122,9 @@ func (r *RawPosts) ORM(dataOwner, archiveID string, beginTime, endTime int64) ([
 		for _, d := range rp.Data {
 			if d.Post != "" {
 				post.Post = string(d.Post)
-				post.Sentiment = sentiment(string(d.Post))
+				if scores, err := analyzer.Analyze(context.Background(), post.Post); err == nil {
+					post.Sentiment = sentiment.Format(scores)
+				}
 			}
 			if d.UpdateTimestamp != 0 {
 				post.UpdateTimestamp = d.UpdateTimestamp
@@ -49,6 +49,7 @@ type ReactionORM struct {
 	Title       string
 	Actor       string
 	Reaction    string
+	ArchiveID   *int64 `gorm:"index"`
 	DataOwnerID string `gorm:"unique_index:facebook_reaction_owner_timestamp_unique"`
 }
 
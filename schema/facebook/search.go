@@ -0,0 +1,70 @@
+package facebook
+
+import (
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawSearches struct {
+	Searches []*Search `json:"searches" jsonschema:"required"`
+}
+
+type Search struct {
+	Timestamp int64          `json:"timestamp" jsonschema:"required"`
+	Title     MojibakeString `json:"title"`
+	Data      []*SearchData  `json:"data"`
+}
+
+type SearchData struct {
+	Text MojibakeString `json:"text"`
+}
+
+func SearchSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawSearches{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type SearchORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Timestamp   int64     `gorm:"unique_index:facebook_search_owner_timestamp_unique"`
+	Date        string
+	Weekday     int
+	Query       string
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string `gorm:"unique_index:facebook_search_owner_timestamp_unique"`
+}
+
+func (SearchORM) TableName() string {
+	return "facebook_search"
+}
+
+func (r RawSearches) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, s := range r.Searches {
+		// searches without a query are visits of search results
+		if len(s.Data) == 0 || s.Data[0].Text == "" {
+			continue
+		}
+
+		t := time.Unix(s.Timestamp, 0)
+		result = append(result, SearchORM{
+			Timestamp:   s.Timestamp,
+			Date:        dateOfTime(t),
+			Weekday:     weekdayOfTime(t),
+			Query:       string(s.Data[0].Text),
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
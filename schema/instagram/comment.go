@@ -0,0 +1,59 @@
+package instagram
+
+import (
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// RawComments keeps comments as tuples of the time, the comment and the username of the media owner
+type RawComments struct {
+	MediaComments [][]string `json:"media_comments" jsonschema:"required"`
+}
+
+func CommentSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawComments{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type CommentORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Timestamp   int64     `gorm:"unique_index:instagram_comment_owner_timestamp_unique"`
+	Comment     string
+	MediaOwner  string
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string `gorm:"unique_index:instagram_comment_owner_timestamp_unique"`
+}
+
+func (CommentORM) TableName() string {
+	return "instagram_comment"
+}
+
+func (r RawComments) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, c := range r.MediaComments {
+		if len(c) < 2 {
+			continue
+		}
+
+		orm := CommentORM{
+			Timestamp:   Time(c[0]).Unix(),
+			Comment:     c[1],
+			DataOwnerID: owner,
+		}
+		if len(c) > 2 {
+			orm.MediaOwner = c[2]
+		}
+
+		result = append(result, orm)
+	}
+	return result
+}
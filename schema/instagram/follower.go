@@ -0,0 +1,49 @@
+package instagram
+
+import (
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// RawConnections keeps the time of each connection by usernames
+type RawConnections struct {
+	Followers map[string]Time `json:"followers" jsonschema:"required"`
+}
+
+func ConnectionSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawConnections{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type FollowerORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Username    string    `gorm:"unique_index:instagram_follower_owner_username_unique"`
+	Timestamp   int64
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string `gorm:"unique_index:instagram_follower_owner_username_unique"`
+}
+
+func (FollowerORM) TableName() string {
+	return "instagram_follower"
+}
+
+func (r RawConnections) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+	for username, t := range r.Followers {
+		result = append(result, FollowerORM{
+			Username:    username,
+			Timestamp:   t.Unix(),
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
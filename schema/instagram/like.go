@@ -0,0 +1,61 @@
+package instagram
+
+import (
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// RawLikes keeps likes as pairs of the time and the username of the owner of the liked item
+type RawLikes struct {
+	MediaLikes   [][]string `json:"media_likes" jsonschema:"required"`
+	CommentLikes [][]string `json:"comment_likes"`
+}
+
+func LikeSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawLikes{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type LikeORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Timestamp   int64     `gorm:"unique_index:instagram_like_owner_timestamp_unique"`
+	Type        string    `gorm:"unique_index:instagram_like_owner_timestamp_unique"`
+	Username    string    `gorm:"unique_index:instagram_like_owner_timestamp_unique"`
+	ArchiveID   *int64    `gorm:"index"`
+	DataOwnerID string    `gorm:"unique_index:instagram_like_owner_timestamp_unique"`
+}
+
+func (LikeORM) TableName() string {
+	return "instagram_like"
+}
+
+func (r RawLikes) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+	for likeType, likes := range map[string][][]string{
+		"media":   r.MediaLikes,
+		"comment": r.CommentLikes,
+	} {
+		for _, l := range likes {
+			if len(l) < 2 {
+				continue
+			}
+
+			result = append(result, LikeORM{
+				Timestamp:   Time(l[0]).Unix(),
+				Type:        likeType,
+				Username:    l[1],
+				DataOwnerID: owner,
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package instagram
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawMedia struct {
+	Photos  []*Media `json:"photos"`
+	Videos  []*Media `json:"videos"`
+	Stories []*Media `json:"stories"`
+}
+
+type Media struct {
+	Caption  string `json:"caption"`
+	TakenAt  Time   `json:"taken_at" jsonschema:"required"`
+	Path     string `json:"path" jsonschema:"required"`
+	Location string `json:"location"`
+}
+
+func MediaSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawMedia{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type PostORM struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Timestamp         int64
+	Caption           string
+	Location          string
+	MediaURI          string `gorm:"unique_index:instagram_post_owner_media_unique"`
+	FilenameExtension string
+	ArchiveID         *int64 `gorm:"index"`
+	DataOwnerID       string `gorm:"unique_index:instagram_post_owner_media_unique"`
+}
+
+func (PostORM) TableName() string {
+	return "instagram_post"
+}
+
+type StoryORM struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	Timestamp         int64
+	Caption           string
+	MediaURI          string `gorm:"unique_index:instagram_story_owner_media_unique"`
+	FilenameExtension string
+	ArchiveID         *int64 `gorm:"index"`
+	DataOwnerID       string `gorm:"unique_index:instagram_story_owner_media_unique"`
+}
+
+func (StoryORM) TableName() string {
+	return "instagram_story"
+}
+
+func mediaURI(dataOwner, archiveID, path string) string {
+	return fmt.Sprintf("%s/instagram/archives/%s/data/%s", dataOwner, archiveID, path)
+}
+
+// PostORM returns the photos and videos as posts
+func (r RawMedia) PostORM(dataOwner, archiveID string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, items := range [][]*Media{r.Photos, r.Videos} {
+		for _, m := range items {
+			result = append(result, PostORM{
+				Timestamp:         m.TakenAt.Unix(),
+				Caption:           m.Caption,
+				Location:          m.Location,
+				MediaURI:          mediaURI(dataOwner, archiveID, m.Path),
+				FilenameExtension: filepath.Ext(m.Path),
+				DataOwnerID:       dataOwner,
+			})
+		}
+	}
+	return result
+}
+
+func (r RawMedia) StoryORM(dataOwner, archiveID string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, m := range r.Stories {
+		result = append(result, StoryORM{
+			Timestamp:         m.TakenAt.Unix(),
+			Caption:           m.Caption,
+			MediaURI:          mediaURI(dataOwner, archiveID, m.Path),
+			FilenameExtension: filepath.Ext(m.Path),
+			DataOwnerID:       dataOwner,
+		})
+	}
+	return result
+}
@@ -0,0 +1,20 @@
+package instagram
+
+import (
+	"regexp"
+
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+)
+
+// Files of an instagram archive are at the root of the archive,
+// except the media files which are grouped by the month they are taken
+var (
+	PostsPattern      = facebook.Pattern{Name: "posts", Regexp: regexp.MustCompile("^media.json$"), Schema: MediaSchemaLoader()}
+	StoriesPattern    = facebook.Pattern{Name: "stories", Regexp: regexp.MustCompile("^media.json$"), Schema: MediaSchemaLoader(), Stream: true, ArrayKey: "stories"}
+	LikesPattern      = facebook.Pattern{Name: "likes", Regexp: regexp.MustCompile("^likes.json$"), Schema: LikeSchemaLoader()}
+	CommentsPattern   = facebook.Pattern{Name: "comments", Regexp: regexp.MustCompile("^comments.json$"), Schema: CommentSchemaLoader(), Stream: true, ArrayKey: "media_comments"}
+	FollowersPattern  = facebook.Pattern{Name: "followers", Regexp: regexp.MustCompile("^connections.json$"), Schema: ConnectionSchemaLoader()}
+	PhotosPattern     = facebook.Pattern{Name: "photos", Location: "photos"}
+	VideosPattern     = facebook.Pattern{Name: "videos", Location: "videos"}
+	StoryMediaPattern = facebook.Pattern{Name: "story_media", Location: "stories"}
+)
@@ -0,0 +1,113 @@
+package instagram
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+)
+
+func TestPostsPattern(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		valid   bool
+	}{
+		"/tmp/user-a/media.json":                  {`{"photos":[{"caption":"CAPTION","taken_at":"2019-12-01T10:00:00+00:00","path":"photos/201912/a.jpg"}],"stories":[]}`, true},
+		"/tmp/user-a/photos/201912/media.json":    {`DOESN'T MATTER`, false},
+		"/tmp/user-a/profile.json":                {`DOESN'T MATTER`, false},
+		"/tmp/user-a/media.json.bak":              {`DOESN'T MATTER`, false},
+		"/tmp/user-a/stories/201912/a_media.json": {`DOESN'T MATTER`, false},
+	}
+	fs := afero.NewMemMapFs()
+
+	// create test files and directories
+	fs.MkdirAll("/tmp", 0755)
+	for filename, item := range cases {
+		afero.WriteFile(fs, filename, []byte(item.content), 0644)
+	}
+
+	p := PostsPattern
+	filenames, err := p.SelectFiles(fs, "/tmp/user-a")
+	assert.Equal(t, []string{"/tmp/user-a/media.json"}, filenames)
+	assert.NoError(t, err)
+
+	data, err := afero.ReadFile(fs, filenames[0])
+	assert.NoError(t, err)
+	assert.NoError(t, p.Validate(data))
+
+	// media without a path is invalid
+	assert.Error(t, p.Validate([]byte(`{"photos":[{"caption":"CAPTION","taken_at":"2019-12-01T10:00:00+00:00"}]}`)))
+}
+
+func TestRawMediaORM(t *testing.T) {
+	media := RawMedia{
+		Photos: []*Media{
+			{Caption: "photo", TakenAt: "2019-12-01T10:00:00+00:00", Path: "photos/201912/a.jpg", Location: "Taipei"},
+		},
+		Videos: []*Media{
+			{Caption: "video", TakenAt: "2019-12-02T10:00:00+00:00", Path: "videos/201912/b.mp4"},
+		},
+		Stories: []*Media{
+			{TakenAt: "malformed", Path: "stories/201912/c.jpg"},
+		},
+	}
+
+	posts := media.PostORM("user-a", "1")
+	assert.Equal(t, []interface{}{
+		PostORM{
+			Timestamp:         1575194400,
+			Caption:           "photo",
+			Location:          "Taipei",
+			MediaURI:          "user-a/instagram/archives/1/data/photos/201912/a.jpg",
+			FilenameExtension: ".jpg",
+			DataOwnerID:       "user-a",
+		},
+		PostORM{
+			Timestamp:         1575280800,
+			Caption:           "video",
+			MediaURI:          "user-a/instagram/archives/1/data/videos/201912/b.mp4",
+			FilenameExtension: ".mp4",
+			DataOwnerID:       "user-a",
+		},
+	}, posts)
+
+	stories := media.StoryORM("user-a", "1")
+	assert.Equal(t, []interface{}{
+		StoryORM{
+			Timestamp:         0,
+			MediaURI:          "user-a/instagram/archives/1/data/stories/201912/c.jpg",
+			FilenameExtension: ".jpg",
+			DataOwnerID:       "user-a",
+		},
+	}, stories)
+}
+
+func TestPatternsMatch(t *testing.T) {
+	cases := map[string][]facebook.Pattern{
+		"media.json":                   {PostsPattern, StoriesPattern},
+		"likes.json":                   {LikesPattern},
+		"comments.json":                {CommentsPattern},
+		"connections.json":             {FollowersPattern},
+		"photos/201912/a.jpg":          {PhotosPattern},
+		"videos/201912/b.mp4":          {VideosPattern},
+		"stories/201912/c.jpg":         {StoryMediaPattern},
+		"photos/201912/media.json":     {PhotosPattern},
+		"profile.json":                 nil,
+		"media.json.bak":               nil,
+		"direct_messages/201912/d.jpg": nil,
+	}
+
+	patterns := []facebook.Pattern{PostsPattern, StoriesPattern, LikesPattern, CommentsPattern, FollowersPattern,
+		PhotosPattern, VideosPattern, StoryMediaPattern}
+	for name, expected := range cases {
+		var matched []facebook.Pattern
+		for _, p := range patterns {
+			if p.Match(name) {
+				matched = append(matched, p)
+			}
+		}
+		assert.Equal(t, expected, matched, name)
+	}
+}
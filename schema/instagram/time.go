@@ -0,0 +1,17 @@
+package instagram
+
+import (
+	"time"
+)
+
+// Time is the ISO 8601 time format used by instagram archives
+type Time string
+
+// Unix returns the unix time in seconds, or 0 if the time is malformed
+func (t Time) Unix() int64 {
+	parsed, err := time.Parse(time.RFC3339, string(t))
+	if err != nil {
+		return 0
+	}
+	return parsed.Unix()
+}
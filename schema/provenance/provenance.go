@@ -0,0 +1,72 @@
+// Package provenance keeps track of the archive which each imported record comes from
+package provenance
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+	"github.com/bitmark-inc/spring-app-api/schema/instagram"
+	"github.com/bitmark-inc/spring-app-api/schema/twitter"
+)
+
+// Models are the models of records imported from archives. Every model has an archive_id column
+// referencing the fbarchive which the record is imported from.
+// Models which depend on others are listed before them.
+var Models = []interface{}{
+	&facebook.TagORM{},
+	&facebook.PlaceORM{},
+	&facebook.PostMediaORM{},
+	&facebook.PostORM{},
+	&facebook.FriendORM{},
+	&facebook.ReactionORM{},
+	&facebook.CommentMediaORM{},
+	&facebook.CommentORM{},
+	&facebook.EventORM{},
+	&facebook.MessageORM{},
+	&facebook.MessageThreadORM{},
+	&facebook.GroupORM{},
+	&facebook.GroupPostORM{},
+	&facebook.PageLikeORM{},
+	&facebook.SearchORM{},
+	&facebook.AdvertiserORM{},
+	&facebook.AdsInterestORM{},
+	&instagram.PostORM{},
+	&instagram.StoryORM{},
+	&instagram.LikeORM{},
+	&instagram.CommentORM{},
+	&instagram.FollowerORM{},
+	&twitter.TweetORM{},
+	&twitter.LikeORM{},
+	&twitter.DirectMessageORM{},
+	&twitter.FollowerORM{},
+}
+
+// ClaimRecords tags the untagged records of a data owner with an archive.
+// Records are inserted without an archive and claimed by the archive at the end of its import,
+// so it must run in the same transaction as the import.
+func ClaimRecords(db *gorm.DB, dataOwner string, archiveID int64) error {
+	for _, m := range Models {
+		if err := db.Model(m).
+			Where("data_owner_id = ? AND archive_id IS NULL", dataOwner).
+			UpdateColumn("archive_id", archiveID).Error; err != nil {
+			return fmt.Errorf("failed to claim records of %s: %s", db.NewScope(m).TableName(), err)
+		}
+	}
+	return nil
+}
+
+// RemoveRecords removes the records imported from an archive and returns the number of removed records.
+// Records of other archives which depend on the removed ones, like tags of removed friends, are removed as well.
+func RemoveRecords(db *gorm.DB, archiveID int64) (int64, error) {
+	var removed int64
+	for _, m := range Models {
+		result := db.Where("archive_id = ?", archiveID).Delete(m)
+		if result.Error != nil {
+			return removed, fmt.Errorf("failed to remove records of %s: %s", db.NewScope(m).TableName(), result.Error)
+		}
+		removed += result.RowsAffected
+	}
+	return removed, nil
+}
@@ -11,12 +11,17 @@ type AccountORM struct {
 	AccountNumber string `gorm:"primary_key"`
 	Metadata      json.RawMessage
 	Deleting      bool
+	UpdatedAt     time.Time
 }
 
 func (AccountORM) TableName() string {
 	return "account"
 }
 
+// ArchiveStatusExpired is the status of a spring archive whose file is removed after its expiry.
+// Other statuses are the states of its export job.
+const ArchiveStatusExpired = "EXPIRED"
+
 // Spring app total archive
 type ArchiveORM struct {
 	ID            uuid.UUID  `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()" json:"id"`
@@ -24,6 +29,8 @@ type ArchiveORM struct {
 	Status        string     `json:"status"`
 	FileKey       string     `json:"file_key"`
 	FileSize      int64      `json:"file_size"`
+	ContentHash   string     `json:"content_hash,omitempty"`
+	BitmarkID     string     `json:"bitmark_id,omitempty"`
 	AccountNumber string     `json:"-"`
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
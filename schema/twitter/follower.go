@@ -0,0 +1,59 @@
+package twitter
+
+import (
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawFollowers []*FollowerItem
+
+type FollowerItem struct {
+	Follower *Follower `json:"follower" jsonschema:"required"`
+}
+
+type Follower struct {
+	AccountID string `json:"accountId" jsonschema:"required"`
+	UserLink  string `json:"userLink"`
+}
+
+func FollowerSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawFollowers{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type FollowerORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	AccountID   string    `gorm:"unique_index:twitter_follower_owner_account_id_unique"`
+	UserLink    string
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string `gorm:"unique_index:twitter_follower_owner_account_id_unique"`
+}
+
+func (FollowerORM) TableName() string {
+	return "twitter_follower"
+}
+
+func (r RawFollowers) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, item := range r {
+		f := item.Follower
+		if f == nil {
+			continue
+		}
+
+		result = append(result, FollowerORM{
+			AccountID:   f.AccountID,
+			UserLink:    f.UserLink,
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
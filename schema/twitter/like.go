@@ -0,0 +1,66 @@
+package twitter
+
+import (
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawLikes []*LikeItem
+
+type LikeItem struct {
+	Like *Like `json:"like" jsonschema:"required"`
+}
+
+type Like struct {
+	TweetID     string `json:"tweetId" jsonschema:"required"`
+	FullText    string `json:"fullText"`
+	ExpandedURL string `json:"expandedUrl"`
+}
+
+func LikeSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawLikes{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type LikeORM struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	TweetID     string    `gorm:"unique_index:twitter_like_owner_tweet_id_unique"`
+	Timestamp   int64
+	Text        string
+	URL         string
+	ArchiveID   *int64 `gorm:"index"`
+	DataOwnerID string `gorm:"unique_index:twitter_like_owner_tweet_id_unique"`
+}
+
+func (LikeORM) TableName() string {
+	return "twitter_like"
+}
+
+// ORM returns the likes. Archives do not keep the time of likes,
+// so the time of a like is the time of the liked tweet, which is encoded in its id.
+func (r RawLikes) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, item := range r {
+		l := item.Like
+		if l == nil {
+			continue
+		}
+
+		result = append(result, LikeORM{
+			TweetID:     l.TweetID,
+			Timestamp:   snowflakeTime(l.TweetID),
+			Text:        l.FullText,
+			URL:         l.ExpandedURL,
+			DataOwnerID: owner,
+		})
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package twitter
+
+import (
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawDirectMessages []*DirectMessageItem
+
+type DirectMessageItem struct {
+	Conversation *DirectMessageConversation `json:"dmConversation" jsonschema:"required"`
+}
+
+type DirectMessageConversation struct {
+	ConversationID string                `json:"conversationId" jsonschema:"required"`
+	Messages       []*DirectMessageEvent `json:"messages" jsonschema:"required"`
+}
+
+// DirectMessageEvent is an event of a conversation. Only created messages are kept.
+type DirectMessageEvent struct {
+	MessageCreate *DirectMessage `json:"messageCreate"`
+}
+
+type DirectMessage struct {
+	ID          string   `json:"id" jsonschema:"required"`
+	SenderID    string   `json:"senderId" jsonschema:"required"`
+	RecipientID string   `json:"recipientId"`
+	CreatedAt   string   `json:"createdAt" jsonschema:"required"`
+	MediaURLs   []string `json:"mediaUrls"`
+}
+
+func DirectMessageSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawDirectMessages{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+// DirectMessageORM keeps the metadata of a direct message, without its text
+type DirectMessageORM struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	MessageID      string    `gorm:"unique_index:twitter_direct_message_owner_message_id_unique"`
+	ConversationID string
+	SenderID       string
+	RecipientID    string
+	Timestamp      int64
+	MediaCount     int
+	ArchiveID      *int64 `gorm:"index"`
+	DataOwnerID    string `gorm:"unique_index:twitter_direct_message_owner_message_id_unique"`
+}
+
+func (DirectMessageORM) TableName() string {
+	return "twitter_direct_message"
+}
+
+func (r RawDirectMessages) ORM(owner string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, item := range r {
+		c := item.Conversation
+		if c == nil {
+			continue
+		}
+
+		for _, e := range c.Messages {
+			m := e.MessageCreate
+			if m == nil {
+				continue
+			}
+
+			var timestamp int64
+			if t, err := time.Parse(time.RFC3339, m.CreatedAt); err == nil {
+				timestamp = t.Unix()
+			}
+
+			result = append(result, DirectMessageORM{
+				MessageID:      m.ID,
+				ConversationID: c.ConversationID,
+				SenderID:       m.SenderID,
+				RecipientID:    m.RecipientID,
+				Timestamp:      timestamp,
+				MediaCount:     len(m.MediaURLs),
+				DataOwnerID:    owner,
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package twitter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bitmark-inc/spring-app-api/schema/facebook"
+)
+
+// Data files of a twitter archive are in the data directory. Large sections are split into parts.
+var (
+	TweetsPattern         = facebook.Pattern{Name: "tweets", Location: "data", Regexp: regexp.MustCompile("^tweets?(-part[0-9]+)?.js$"), Schema: TweetSchemaLoader(), Stream: true}
+	LikesPattern          = facebook.Pattern{Name: "likes", Location: "data", Regexp: regexp.MustCompile("^like(-part[0-9]+)?.js$"), Schema: LikeSchemaLoader(), Stream: true}
+	DirectMessagesPattern = facebook.Pattern{Name: "direct_messages", Location: "data", Regexp: regexp.MustCompile("^direct-messages(-part[0-9]+)?.js$"), Schema: DirectMessageSchemaLoader(), Stream: true}
+	FollowersPattern      = facebook.Pattern{Name: "followers", Location: "data", Regexp: regexp.MustCompile("^follower(-part[0-9]+)?.js$"), Schema: FollowerSchemaLoader(), Stream: true}
+
+	// media files of tweets are in the directories named after the tweets file
+	TweetMediaPattern  = facebook.Pattern{Name: "tweet_media", Location: "data/tweet_media"}
+	TweetsMediaPattern = facebook.Pattern{Name: "tweets_media", Location: "data/tweets_media"}
+)
+
+// MediaDir returns the directory of media files of a tweets file
+func MediaDir(tweetsFilename string) string {
+	if strings.HasPrefix(tweetsFilename, "tweets") {
+		return "tweets_media"
+	}
+	return "tweet_media"
+}
@@ -0,0 +1,162 @@
+package twitter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripWrapper(t *testing.T) {
+	assert.Equal(t, `[ {"like":{"tweetId":"1"}} ]`, string(StripWrapper([]byte(`window.YTD.like.part0 = [ {"like":{"tweetId":"1"}} ]`))))
+	assert.Equal(t, `[]`, string(StripWrapper([]byte("\nwindow.YTD.direct_messages.part12=[]"))))
+	assert.Equal(t, `{"a":1}`, string(StripWrapper([]byte(`{"a":1}`))))
+}
+
+func TestStripWrapperReader(t *testing.T) {
+	for content, expected := range map[string]string{
+		`window.YTD.like.part0 = [ {"like":{"tweetId":"1"}} ]`:         `[ {"like":{"tweetId":"1"}} ]`,
+		"\nwindow.YTD.direct_messages.part12=[]":                       `[]`,
+		`{"a":1}`:                                                      `{"a":1}`,
+		"window.YTD.tweet.part0 = [" + strings.Repeat(" ", 1024) + "]": "[" + strings.Repeat(" ", 1024) + "]",
+	} {
+		r, err := StripWrapperReader(strings.NewReader(content))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		data, err := ioutil.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+}
+
+func TestTweetsPattern(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		valid   bool
+	}{
+		"/tmp/user-a/data/tweet.js":             {`window.YTD.tweet.part0 = [{"tweet":{"id_str":"1","full_text":"hello","created_at":"Fri Nov 29 09:14:26 +0000 2019"}}]`, true},
+		"/tmp/user-a/data/tweet-part1.js":       {`window.YTD.tweet.part1 = []`, true},
+		"/tmp/user-a/data/tweetdeck.js":         {`DOESN'T MATTER`, false},
+		"/tmp/user-a/data/tweet_media/1-a.jpg":  {`DOESN'T MATTER`, false},
+		"/tmp/user-a/data/retweet.js":           {`DOESN'T MATTER`, false},
+		"/tmp/user-a/data/tweet.js.bak":         {`DOESN'T MATTER`, false},
+		"/tmp/user-a/data/direct-messages.js":   {`DOESN'T MATTER`, false},
+		"/tmp/user-a/data/account-creation.js":  {`DOESN'T MATTER`, false},
+		"/tmp/user-a/data/manifest-tweet.js":    {`DOESN'T MATTER`, false},
+		"/tmp/user-a/data/profile_media/a.jpg":  {`DOESN'T MATTER`, false},
+		"/tmp/user-a/data/tweets_media/2-b.mp4": {`DOESN'T MATTER`, false},
+	}
+	fs := afero.NewMemMapFs()
+
+	// create test files and directories
+	fs.MkdirAll("/tmp", 0755)
+	for filename, item := range cases {
+		afero.WriteFile(fs, filename, []byte(item.content), 0644)
+	}
+
+	p := TweetsPattern
+	filenames, err := p.SelectFiles(fs, "/tmp/user-a/data")
+	assert.Equal(t, []string{"/tmp/user-a/data/tweet-part1.js", "/tmp/user-a/data/tweet.js"}, filenames)
+	assert.NoError(t, err)
+
+	for _, filename := range filenames {
+		data, err := afero.ReadFile(fs, filename)
+		assert.NoError(t, err)
+		assert.NoError(t, p.Validate(StripWrapper(data)))
+	}
+
+	// tweets without a time are invalid
+	assert.Error(t, p.Validate([]byte(`[{"tweet":{"id_str":"1","full_text":"hello"}}]`)))
+}
+
+func TestRawTweetsORM(t *testing.T) {
+	var tweets RawTweets
+	assert.NoError(t, json.Unmarshal([]byte(`[
+		{"tweet":{"id_str":"1200000000000000000","full_text":"RT @someone: hello","created_at":"Fri Nov 29 09:14:26 +0000 2019","favorite_count":"2","retweet_count":"1"}},
+		{"tweet":{"id_str":"1200000000000000001","full_text":"look","created_at":"Fri Nov 29 10:00:00 +0000 2019",
+			"entities":{"urls":[{"expanded_url":"https://example.com"}]},
+			"extended_entities":{"media":[
+				{"media_url_https":"https://pbs.twimg.com/media/a.jpg","type":"photo"},
+				{"media_url_https":"https://pbs.twimg.com/ext_tw_video_thumb/1/pu/img/b.jpg","type":"video","video_info":{"variants":[
+					{"bitrate":"256000","content_type":"video/mp4","url":"https://video.twimg.com/ext_tw_video/1/pu/vid/low.mp4?tag=10"},
+					{"content_type":"application/x-mpegURL","url":"https://video.twimg.com/ext_tw_video/1/pu/pl/c.m3u8"},
+					{"bitrate":"2176000","content_type":"video/mp4","url":"https://video.twimg.com/ext_tw_video/1/pu/vid/high.mp4?tag=10"}
+				]}}
+			]}}},
+		{"tweet":{"id_str":"3","full_text":"malformed","created_at":"yesterday"}}
+	]`), &tweets))
+
+	assert.Equal(t, []interface{}{
+		TweetORM{
+			TweetID:       "1200000000000000000",
+			Timestamp:     1575018866,
+			Text:          "RT @someone: hello",
+			IsRetweet:     true,
+			FavoriteCount: 2,
+			RetweetCount:  1,
+			MediaURIs:     pq.StringArray{},
+			DataOwnerID:   "user-a",
+		},
+		TweetORM{
+			TweetID:   "1200000000000000001",
+			Timestamp: 1575021600,
+			Text:      "look",
+			URL:       "https://example.com",
+			MediaURIs: pq.StringArray{
+				"user-a/twitter/archives/1/data/tweet_media/1200000000000000001-a.jpg",
+				"user-a/twitter/archives/1/data/tweet_media/1200000000000000001-high.mp4",
+			},
+			DataOwnerID: "user-a",
+		},
+	}, tweets.ORM("user-a", "1", "tweet_media"))
+}
+
+func TestRawLikesORM(t *testing.T) {
+	var likes RawLikes
+	assert.NoError(t, json.Unmarshal(StripWrapper([]byte(`window.YTD.like.part0 = [
+		{"like":{"tweetId":"1200000000000000000","fullText":"hello","expandedUrl":"https://twitter.com/i/web/status/1200000000000000000"}},
+		{"like":{"tweetId":"12345","fullText":"older than snowflake"}}
+	]`)), &likes))
+
+	assert.Equal(t, []interface{}{
+		LikeORM{
+			TweetID:     "1200000000000000000",
+			Timestamp:   1574937269,
+			Text:        "hello",
+			URL:         "https://twitter.com/i/web/status/1200000000000000000",
+			DataOwnerID: "user-a",
+		},
+		LikeORM{
+			TweetID:     "12345",
+			Timestamp:   0,
+			Text:        "older than snowflake",
+			DataOwnerID: "user-a",
+		},
+	}, likes.ORM("user-a"))
+}
+
+func TestRawDirectMessagesORM(t *testing.T) {
+	var messages RawDirectMessages
+	assert.NoError(t, json.Unmarshal([]byte(`[{"dmConversation":{"conversationId":"1-2","messages":[
+		{"messageCreate":{"id":"10","senderId":"1","recipientId":"2","text":"secret","createdAt":"2019-11-29T09:14:26.000Z","mediaUrls":["https://ton.twitter.com/a.jpg"]}},
+		{"joinConversation":{"initiatingUserId":"1"}}
+	]}}]`), &messages))
+
+	assert.NoError(t, DirectMessagesPattern.Validate([]byte(`[{"dmConversation":{"conversationId":"1-2","messages":[]}}]`)))
+	assert.Equal(t, []interface{}{
+		DirectMessageORM{
+			MessageID:      "10",
+			ConversationID: "1-2",
+			SenderID:       "1",
+			RecipientID:    "2",
+			Timestamp:      1575018866,
+			MediaCount:     1,
+			DataOwnerID:    "user-a",
+		},
+	}, messages.ORM("user-a"))
+}
@@ -0,0 +1,163 @@
+package twitter
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type RawTweets []*TweetItem
+
+type TweetItem struct {
+	Tweet *Tweet `json:"tweet" jsonschema:"required"`
+}
+
+type Tweet struct {
+	ID                  string         `json:"id_str" jsonschema:"required"`
+	FullText            string         `json:"full_text" jsonschema:"required"`
+	CreatedAt           string         `json:"created_at" jsonschema:"required"`
+	FavoriteCount       string         `json:"favorite_count"`
+	RetweetCount        string         `json:"retweet_count"`
+	InReplyToScreenName string         `json:"in_reply_to_screen_name"`
+	Entities            *TweetEntities `json:"entities"`
+	ExtendedEntities    *TweetEntities `json:"extended_entities"`
+}
+
+type TweetEntities struct {
+	Media []*TweetMedia `json:"media"`
+	URLs  []*TweetURL   `json:"urls"`
+}
+
+type TweetMedia struct {
+	MediaURL  string          `json:"media_url_https"`
+	Type      string          `json:"type"`
+	VideoInfo *TweetVideoInfo `json:"video_info"`
+}
+
+type TweetVideoInfo struct {
+	Variants []*TweetVideoVariant `json:"variants"`
+}
+
+type TweetVideoVariant struct {
+	Bitrate     string `json:"bitrate"`
+	ContentType string `json:"content_type"`
+	URL         string `json:"url"`
+}
+
+type TweetURL struct {
+	ExpandedURL string `json:"expanded_url"`
+}
+
+func TweetSchemaLoader() *gojsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	s := reflector.Reflect(&RawTweets{})
+	data, _ := s.MarshalJSON()
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	schema, _ := gojsonschema.NewSchema(schemaLoader)
+	return schema
+}
+
+type TweetORM struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key" sql:"default:uuid_generate_v4()"`
+	TweetID       string    `gorm:"unique_index:twitter_tweet_owner_tweet_id_unique"`
+	Timestamp     int64
+	Text          string
+	URL           string
+	ReplyTo       string
+	IsRetweet     bool
+	FavoriteCount int64
+	RetweetCount  int64
+	MediaURIs     pq.StringArray `gorm:"type:text[]"`
+	ArchiveID     *int64         `gorm:"index"`
+	DataOwnerID   string         `gorm:"unique_index:twitter_tweet_owner_tweet_id_unique"`
+}
+
+func (TweetORM) TableName() string {
+	return "twitter_tweet"
+}
+
+// mediaFilename returns the name of the media file of a tweet in an archive.
+// Photos are named by the tweet id and the name of the photo,
+// while videos are named by the tweet id and the name of the video of the best quality.
+func mediaFilename(tweetID string, m *TweetMedia) string {
+	mediaURL := m.MediaURL
+	if m.VideoInfo != nil {
+		variants := make([]*TweetVideoVariant, 0)
+		for _, v := range m.VideoInfo.Variants {
+			if v.ContentType == "video/mp4" {
+				variants = append(variants, v)
+			}
+		}
+		sort.SliceStable(variants, func(i, j int) bool {
+			bi, _ := strconv.ParseInt(variants[i].Bitrate, 10, 64)
+			bj, _ := strconv.ParseInt(variants[j].Bitrate, 10, 64)
+			return bi > bj
+		})
+		if len(variants) > 0 {
+			mediaURL = strings.SplitN(variants[0].URL, "?", 2)[0]
+		}
+	}
+	return fmt.Sprintf("%s-%s", tweetID, path.Base(mediaURL))
+}
+
+// ORM returns the tweets. The media uris are the keys of the media files on s3,
+// which are uploaded from the media directory of the archive.
+func (r RawTweets) ORM(dataOwner, archiveID, mediaDir string) []interface{} {
+	result := make([]interface{}, 0)
+	for _, item := range r {
+		t := item.Tweet
+		if t == nil {
+			continue
+		}
+
+		createdAt, err := time.Parse(time.RubyDate, t.CreatedAt)
+		if err != nil {
+			continue
+		}
+
+		favoriteCount, _ := strconv.ParseInt(t.FavoriteCount, 10, 64)
+		retweetCount, _ := strconv.ParseInt(t.RetweetCount, 10, 64)
+
+		orm := TweetORM{
+			TweetID:       t.ID,
+			Timestamp:     createdAt.Unix(),
+			Text:          t.FullText,
+			ReplyTo:       t.InReplyToScreenName,
+			IsRetweet:     strings.HasPrefix(t.FullText, "RT @"),
+			FavoriteCount: favoriteCount,
+			RetweetCount:  retweetCount,
+			MediaURIs:     make(pq.StringArray, 0),
+			DataOwnerID:   dataOwner,
+		}
+
+		if t.Entities != nil && len(t.Entities.URLs) > 0 {
+			orm.URL = t.Entities.URLs[0].ExpandedURL
+		}
+
+		// extended entities carry all of the media of a tweet, while entities only carry the first one
+		entities := t.ExtendedEntities
+		if entities == nil {
+			entities = t.Entities
+		}
+		if entities != nil {
+			for _, m := range entities.Media {
+				orm.MediaURIs = append(orm.MediaURIs,
+					fmt.Sprintf("%s/twitter/archives/%s/data/%s/%s", dataOwner, archiveID, mediaDir, mediaFilename(t.ID, m)))
+			}
+		}
+
+		result = append(result, orm)
+	}
+	return result
+}
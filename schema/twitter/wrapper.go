@@ -0,0 +1,60 @@
+package twitter
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// The data files of a twitter archive are javascript files which assign the json data
+// to a global variable, e.g. `window.YTD.tweet.part0 = [ ... ]`
+var wrapperRegexp = regexp.MustCompile(`^\s*window\.YTD\.[A-Za-z0-9_]+\.part[0-9]+\s*=\s*`)
+
+// StripWrapper returns the json data of a data file of a twitter archive
+func StripWrapper(data []byte) []byte {
+	loc := wrapperRegexp.FindIndex(data)
+	if loc == nil {
+		return data
+	}
+	return data[loc[1]:]
+}
+
+// wrapperMaxLength is the maximum length of the wrapper looked for by StripWrapperReader
+const wrapperMaxLength = 256
+
+// StripWrapperReader returns a reader of the json data of a data file of a twitter archive,
+// so the data can be decoded without reading the whole file
+func StripWrapperReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, wrapperMaxLength)
+	head, err := br.Peek(wrapperMaxLength)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if loc := wrapperRegexp.FindIndex(head); loc != nil {
+		if _, err := br.Discard(loc[1]); err != nil {
+			return nil, err
+		}
+	}
+	return br, nil
+}
+
+const (
+	// twitterEpoch is the start time of snowflake ids in milliseconds
+	twitterEpoch int64 = 1288834974657
+
+	// firstSnowflakeID is the first tweet id generated by snowflake.
+	// The ids before it do not carry a time.
+	firstSnowflakeID int64 = 29700859247
+)
+
+// snowflakeTime returns the unix time in seconds of when a snowflake id is generated,
+// or 0 if the id is malformed or older than snowflake
+func snowflakeTime(id string) int64 {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n < firstSnowflakeID {
+		return 0
+	}
+	return ((n >> 22) + twitterEpoch) / 1000
+}
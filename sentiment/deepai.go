@@ -0,0 +1,103 @@
+package sentiment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+	"unicode"
+)
+
+const deepAIEndpoint = "https://api.deepai.org/api/sentiment-analysis"
+
+// deepAITimeout is the max duration of a request to DeepAI, which is called for every post of an archive
+const deepAITimeout = 10 * time.Second
+
+var deepAIScores = map[string]int{
+	"Verypositive": VeryPositive,
+	"Positive":     Positive,
+	"Neutral":      Neutral,
+	"Negative":     Negative,
+	"Verynegative": VeryNegative,
+}
+
+// DeepAI analyzes sentiment with the api of DeepAI
+type DeepAI struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+
+	// DeepAI only uses an english corpus for training the sentiment model,
+	// so texts containing non-ASCII characters are analyzed by the fallback
+	fallback Analyzer
+}
+
+// NewDeepAI creates an analyzer calling DeepAI with an api token.
+// Texts not in english are analyzed by a fallback analyzer.
+func NewDeepAI(token string, fallback Analyzer) *DeepAI {
+	return &DeepAI{
+		endpoint:   deepAIEndpoint,
+		token:      token,
+		httpClient: &http.Client{Timeout: deepAITimeout},
+		fallback:   fallback,
+	}
+}
+
+// Analyze returns the scores of the sentences of a text
+func (d *DeepAI) Analyze(ctx context.Context, text string) ([]int, error) {
+	if !isASCII(text) {
+		return d.fallback.Analyze(ctx, text)
+	}
+
+	payload := &bytes.Buffer{}
+	writer := multipart.NewWriter(payload)
+	writer.WriteField("text", text)
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", d.endpoint, payload)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Add("api-key", d.token)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("deepai responds with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Output []string `json:"output"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	scores := make([]int, 0, len(result.Output))
+	for _, s := range result.Output {
+		if score, ok := deepAIScores[s]; ok {
+			scores = append(scores, score)
+		}
+	}
+	return scores, nil
+}
+
+func isASCII(s string) bool {
+	for _, c := range s {
+		if c > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
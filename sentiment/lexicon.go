@@ -0,0 +1,336 @@
+package sentiment
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// negationScalar flips and dampens the valence of words following a negation, like "not good"
+	negationScalar = -0.74
+
+	// negationScope is the number of words following a negation affected by the negation
+	negationScope = 3
+
+	// normalizationAlpha normalizes the sum of the valences of a sentence into (-1, 1)
+	normalizationAlpha = 15
+)
+
+// kinds of entries in the lexicons of languages without spaces between words
+const (
+	entryWord = iota
+	entryNegation
+	entryIntensifier
+)
+
+type continuousEntry struct {
+	kind  int
+	value float64
+}
+
+// Lexicon analyzes sentiment by the valences of words in the lexicons of languages.
+// Negations flip the valences of the words following them, and intensifiers boost them.
+// Words of languages written without spaces, like chinese and japanese, are matched inside runs of their characters.
+type Lexicon struct {
+	words        map[string]float64
+	negations    map[string]bool
+	intensifiers map[string]float64
+
+	// maxPhrase is the max number of words of the phrases in the lexicon, like "tuyệt vời"
+	maxPhrase int
+
+	continuous       map[string]continuousEntry
+	negationSuffixes []string
+
+	// maxContinuous is the max number of characters of the entries of languages without spaces
+	maxContinuous int
+}
+
+// NewLexicon creates an analyzer with the lexicons of all of the built-in languages, emoji and emoticons
+func NewLexicon() *Lexicon {
+	l := &Lexicon{
+		words:        make(map[string]float64),
+		negations:    make(map[string]bool),
+		intensifiers: make(map[string]float64),
+		maxPhrase:    1,
+		continuous:   make(map[string]continuousEntry),
+	}
+
+	for _, lang := range languages {
+		l.addLanguage(lang)
+	}
+	return l
+}
+
+func (l *Lexicon) addLanguage(lang language) {
+	if lang.continuous {
+		for w, v := range lang.words {
+			l.addContinuous(w, continuousEntry{entryWord, v})
+		}
+		for _, w := range lang.negations {
+			l.addContinuous(w, continuousEntry{entryNegation, 0})
+		}
+		for w, v := range lang.intensifiers {
+			l.addContinuous(w, continuousEntry{entryIntensifier, v})
+		}
+		l.negationSuffixes = append(l.negationSuffixes, lang.negationSuffixes...)
+		return
+	}
+
+	for w, v := range lang.words {
+		w = norm.NFC.String(w)
+		l.words[w] = v
+		if n := len(strings.Fields(w)); n > l.maxPhrase {
+			l.maxPhrase = n
+		}
+	}
+	for _, w := range lang.negations {
+		l.negations[norm.NFC.String(w)] = true
+	}
+	for w, v := range lang.intensifiers {
+		l.intensifiers[norm.NFC.String(w)] = v
+	}
+}
+
+func (l *Lexicon) addContinuous(w string, e continuousEntry) {
+	w = norm.NFC.String(w)
+	l.continuous[w] = e
+	if n := len([]rune(w)); n > l.maxContinuous {
+		l.maxContinuous = n
+	}
+}
+
+// Analyze returns the scores of the sentences of a text
+func (l *Lexicon) Analyze(ctx context.Context, text string) ([]int, error) {
+	scores := make([]int, 0)
+	for _, sentence := range splitSentences(norm.NFC.String(text)) {
+		tokens := l.tokenize(sentence)
+		if len(tokens) == 0 {
+			continue
+		}
+		scores = append(scores, scoreOf(l.valence(tokens)))
+	}
+	return scores, nil
+}
+
+// splitSentences splits a text by the punctuation ending sentences in all of the languages
+func splitSentences(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		switch r {
+		case '.', '!', '?', '\n', '。', '！', '？', '…':
+			return true
+		}
+		return false
+	})
+}
+
+type token struct {
+	text string
+
+	// continuous tokens are runs of the characters of languages without spaces
+	continuous bool
+}
+
+// tokenize splits a sentence into lower cased words, emoji, emoticons and runs of characters of languages without spaces
+func (l *Lexicon) tokenize(sentence string) []token {
+	tokens := make([]token, 0)
+	for _, field := range strings.Fields(strings.ToLower(sentence)) {
+		field = strings.Replace(field, "’", "'", -1)
+
+		// emoticons are made of punctuation, like :) and :-(
+		if _, ok := l.words[field]; ok {
+			tokens = append(tokens, token{text: field})
+			continue
+		}
+
+		var current []rune
+		currentContinuous := false
+		flush := func() {
+			if len(current) > 0 {
+				tokens = append(tokens, token{text: string(current), continuous: currentContinuous})
+			}
+			current = nil
+		}
+
+		for _, r := range field {
+			switch {
+			case r == '\u200d' || r == '\ufe0f':
+				// joiners and variation selectors of emoji
+			case isContinuousScript(r):
+				if !currentContinuous {
+					flush()
+				}
+				current = append(current, r)
+				currentContinuous = true
+			case unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) || (r == '\'' && len(current) > 0):
+				if currentContinuous {
+					flush()
+				}
+				current = append(current, r)
+				currentContinuous = false
+			case unicode.Is(unicode.So, r):
+				flush()
+				tokens = append(tokens, token{text: string(r)})
+			default:
+				flush()
+			}
+		}
+		flush()
+	}
+	return tokens
+}
+
+func isContinuousScript(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// valence sums the valences of the words of a sentence
+func (l *Lexicon) valence(tokens []token) float64 {
+	total := 0.0
+	negated := 0
+	boost := 1.0
+
+	for i := 0; i < len(tokens); {
+		t := tokens[i]
+		if t.continuous {
+			total += l.continuousValence([]rune(t.text))
+			i++
+			continue
+		}
+
+		if l.negations[t.text] || strings.HasSuffix(t.text, "n't") {
+			negated = negationScope
+			i++
+			continue
+		}
+
+		if b, ok := l.intensifiers[t.text]; ok {
+			boost *= b
+			i++
+			continue
+		}
+
+		// the longest phrase starting from the word
+		matched, v := 1, 0.0
+		for n := l.maxPhrase; n >= 1; n-- {
+			if i+n > len(tokens) {
+				continue
+			}
+			if value, ok := l.words[joinTokens(tokens[i:i+n])]; ok {
+				matched, v = n, value
+				break
+			}
+		}
+
+		if v != 0 {
+			v *= boost
+			if negated > 0 {
+				v *= negationScalar
+			}
+			total += v
+		}
+		boost = 1
+		if negated > 0 {
+			negated--
+		}
+		i += matched
+	}
+
+	return total
+}
+
+func joinTokens(tokens []token) string {
+	words := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if t.continuous {
+			return ""
+		}
+		words = append(words, t.text)
+	}
+	return strings.Join(words, " ")
+}
+
+// continuousValence sums the valences of the words matched inside a run of characters of languages without spaces
+func (l *Lexicon) continuousValence(runes []rune) float64 {
+	total := 0.0
+	negated := false
+	boost := 1.0
+
+	for i := 0; i < len(runes); {
+		// the longest entry starting from the character
+		matched := 0
+		var entry continuousEntry
+		for n := l.maxContinuous; n >= 1; n-- {
+			if i+n > len(runes) {
+				continue
+			}
+			if e, ok := l.continuous[string(runes[i:i+n])]; ok {
+				matched, entry = n, e
+				break
+			}
+		}
+
+		if matched == 0 {
+			i++
+			continue
+		}
+		i += matched
+
+		switch entry.kind {
+		case entryNegation:
+			negated = true
+		case entryIntensifier:
+			boost *= entry.value
+		case entryWord:
+			v := entry.value * boost
+
+			// negations following the words, like the japanese くない
+			if n := l.negationSuffix(runes[i:]); n > 0 {
+				negated = !negated
+				i += n
+			}
+			if negated {
+				v *= negationScalar
+			}
+
+			total += v
+			negated = false
+			boost = 1
+		}
+	}
+
+	return total
+}
+
+// negationSuffix returns the length of the negation at the beginning of characters, or 0 if there is none
+func (l *Lexicon) negationSuffix(runes []rune) int {
+	s := string(runes)
+	longest := 0
+	for _, suffix := range l.negationSuffixes {
+		if n := len([]rune(suffix)); n > longest && strings.HasPrefix(s, suffix) {
+			longest = n
+		}
+	}
+	return longest
+}
+
+// scoreOf normalizes the valence of a sentence into a score
+func scoreOf(valence float64) int {
+	compound := valence / math.Sqrt(valence*valence+normalizationAlpha)
+
+	switch {
+	case compound >= 0.6:
+		return VeryPositive
+	case compound >= 0.15:
+		return Positive
+	case compound <= -0.6:
+		return VeryNegative
+	case compound <= -0.15:
+		return Negative
+	}
+	return Neutral
+}
@@ -0,0 +1,236 @@
+package sentiment
+
+// language is the lexicon of a language, with the valences of words from -3 for very negative to 3 for very positive
+type language struct {
+	words        map[string]float64
+	negations    []string
+	intensifiers map[string]float64
+
+	// continuous languages are written without spaces between words, and their words are matched inside runs of characters
+	continuous bool
+
+	// negationSuffixes negate the words they follow, like the japanese ない
+	negationSuffixes []string
+}
+
+var languages = []language{
+	english,
+	spanish,
+	french,
+	german,
+	portuguese,
+	italian,
+	indonesian,
+	vietnamese,
+	korean,
+	chinese,
+	japanese,
+	emoji,
+}
+
+var english = language{
+	words: map[string]float64{
+		"good": 1.9, "great": 3.1, "excellent": 3.2, "amazing": 2.8, "awesome": 3.1, "wonderful": 2.7,
+		"fantastic": 2.6, "perfect": 2.7, "best": 3.2, "better": 1.9, "nice": 1.8, "cool": 1.3,
+		"love": 3.2, "loved": 2.9, "loving": 2.9, "lovely": 2.8, "like": 1.5, "liked": 1.5, "enjoy": 2.2,
+		"enjoyed": 2.3, "happy": 2.7, "happiness": 2.6, "glad": 2.0, "joy": 2.8, "fun": 2.3, "funny": 1.9,
+		"beautiful": 2.9, "pretty": 2.2, "cute": 2.0, "proud": 2.1, "excited": 1.4, "exciting": 2.2,
+		"thanks": 1.9, "thank": 1.5, "thankful": 2.0, "grateful": 2.0, "blessed": 2.9, "congrats": 2.4,
+		"congratulations": 2.9, "win": 2.8, "won": 2.7, "success": 2.7, "successful": 2.8, "brilliant": 2.8,
+		"delicious": 2.7, "peace": 2.5, "hope": 1.9, "hopeful": 2.2, "fine": 0.8, "ok": 0.9, "okay": 0.9,
+		"yay": 2.4, "wow": 2.8, "lol": 1.8, "haha": 2.0, "hahaha": 2.0, "relaxed": 2.2, "safe": 1.9,
+		"friendly": 2.2, "smile": 1.5, "smiling": 2.0, "laugh": 2.6, "celebrate": 2.7,
+		"miss": -1.2, "missed": -1.2, "bad": -2.5, "worse": -2.1, "worst": -3.1, "terrible": -2.5,
+		"horrible": -2.5, "awful": -2.0, "hate": -2.7, "hated": -3.2, "sad": -2.1, "sadly": -1.8,
+		"unhappy": -1.8, "angry": -2.3, "mad": -2.2, "annoyed": -1.6, "annoying": -1.7, "upset": -1.6,
+		"cry": -2.1, "crying": -2.1, "tears": -0.9, "sick": -2.3, "hurt": -2.4, "painful": -2.4,
+		"tired": -1.9, "bored": -1.1, "boring": -1.3, "lonely": -1.8, "scared": -1.9, "afraid": -2.0,
+		"fear": -2.2, "worried": -1.2, "worry": -1.9, "stress": -1.8, "stressed": -1.4, "depressed": -2.3,
+		"disappointed": -1.9, "disappointing": -2.2, "fail": -2.5, "failed": -2.3, "failure": -2.3,
+		"lost": -1.3, "lose": -1.7, "loss": -1.3, "problem": -1.7, "wrong": -2.1, "ugly": -2.3,
+		"stupid": -2.4, "dead": -3.3, "death": -2.9, "died": -2.6, "kill": -3.7,
+		"sorry": -0.3, "unfortunately": -1.5, "broken": -2.1, "damn": -1.7, "sucks": -1.5, "fml": -2.2,
+		"rip": -1.2, "ugh": -1.8, "disgusting": -2.4, "terrified": -3.0, "miserable": -2.2, "alone": -1.0,
+	},
+	negations: []string{
+		"not", "no", "never", "none", "nobody", "nothing", "neither", "nor", "nowhere", "without",
+		"cannot", "dont", "doesnt", "didnt", "isnt", "arent", "wasnt", "werent", "wont", "cant", "couldnt",
+		"shouldnt", "wouldnt", "aint",
+	},
+	intensifiers: map[string]float64{
+		"very": 1.3, "really": 1.3, "so": 1.3, "extremely": 1.4, "super": 1.3, "totally": 1.3,
+		"absolutely": 1.3, "incredibly": 1.4, "completely": 1.3, "truly": 1.3, "too": 1.2,
+		"quite": 1.1, "slightly": 0.7, "somewhat": 0.8, "barely": 0.6, "kinda": 0.8,
+	},
+}
+
+var spanish = language{
+	words: map[string]float64{
+		"bueno": 1.9, "buena": 1.9, "buenos": 1.9, "buenas": 1.9, "bien": 1.5, "genial": 2.8, "excelente": 3.1,
+		"increíble": 2.8, "maravilloso": 2.8, "maravillosa": 2.8, "perfecto": 2.7, "perfecta": 2.7,
+		"mejor": 2.0, "bonito": 2.2, "bonita": 2.2, "hermoso": 2.8, "hermosa": 2.8, "lindo": 2.2, "linda": 2.2,
+		"amor": 3.0, "amo": 3.0, "encanta": 2.8, "feliz": 2.8, "felicidad": 2.8, "alegría": 2.8,
+		"contento": 2.2, "contenta": 2.2, "gracias": 1.9, "divertido": 2.2, "divertida": 2.2,
+		"felicidades": 2.8, "éxito": 2.7, "orgulloso": 2.1, "orgullosa": 2.1, "gusta": 1.5,
+		"malo": -2.5, "mala": -2.5, "mal": -2.0, "peor": -2.5, "terrible": -2.5, "horrible": -2.5,
+		"odio": -2.9, "triste": -2.1, "tristeza": -2.2, "enojado": -2.2, "enojada": -2.2, "enfermo": -2.2,
+		"enferma": -2.2, "dolor": -2.3, "cansado": -1.8, "cansada": -1.8, "miedo": -2.2,
+		"aburrido": -1.3, "aburrida": -1.3, "muerte": -2.9, "murió": -2.6, "problema": -1.7,
+		"asco": -2.4, "llorar": -2.1, "extraño": -1.0,
+	},
+	negations:    []string{"no", "nunca", "jamás", "nada", "nadie", "ni", "sin", "tampoco"},
+	intensifiers: map[string]float64{"muy": 1.3, "tan": 1.3, "súper": 1.3, "demasiado": 1.2, "bastante": 1.1, "poco": 0.7},
+}
+
+var french = language{
+	words: map[string]float64{
+		"bon": 1.9, "bonne": 1.9, "bien": 1.5, "super": 2.5, "génial": 2.8, "géniale": 2.8, "excellent": 3.1,
+		"magnifique": 2.9, "merveilleux": 2.8, "parfait": 2.7, "parfaite": 2.7, "meilleur": 2.0,
+		"beau": 2.2, "belle": 2.2, "joli": 2.0, "jolie": 2.0, "amour": 3.0, "aime": 2.5, "adore": 3.0,
+		"heureux": 2.8, "heureuse": 2.8, "bonheur": 2.8, "joie": 2.8, "contente": 2.2,
+		"merci": 1.9, "drôle": 1.9, "félicitations": 2.8, "bravo": 2.5, "fier": 2.1, "fière": 2.1,
+		"mauvais": -2.5, "mauvaise": -2.5, "mal": -2.0, "pire": -2.5, "terrible": -2.5, "horrible": -2.5,
+		"nul": -2.2, "déteste": -2.9, "haine": -2.9, "triste": -2.1, "tristesse": -2.2, "colère": -2.3,
+		"fâché": -2.2, "malade": -2.2, "douleur": -2.3, "fatigué": -1.8, "fatiguée": -1.8, "peur": -2.2,
+		"ennuyeux": -1.3, "mort": -2.9, "problème": -1.7, "pleurer": -2.1, "seul": -1.0, "seule": -1.0,
+	},
+	negations:    []string{"ne", "pas", "jamais", "rien", "personne", "aucun", "aucune", "sans", "ni"},
+	intensifiers: map[string]float64{"très": 1.3, "trop": 1.2, "vraiment": 1.3, "tellement": 1.3, "peu": 0.7},
+}
+
+var german = language{
+	words: map[string]float64{
+		"gut": 1.9, "gute": 1.9, "guten": 1.9, "toll": 2.8, "super": 2.5, "prima": 2.5, "klasse": 2.5,
+		"ausgezeichnet": 3.1, "wunderbar": 2.8, "wunderschön": 3.0, "perfekt": 2.7, "besser": 1.9,
+		"beste": 3.1, "schön": 2.2, "schöne": 2.2, "liebe": 3.0, "lieben": 3.0, "glücklich": 2.8,
+		"glück": 2.5, "freude": 2.8, "froh": 2.2, "danke": 1.9, "lustig": 1.9, "spaß": 2.3,
+		"glückwunsch": 2.8, "stolz": 2.1, "genial": 2.8, "schlecht": -2.5, "schlechter": -2.1,
+		"schlimm": -2.5, "furchtbar": -2.5, "schrecklich": -2.5, "hasse": -2.9, "hass": -2.9,
+		"traurig": -2.1, "wütend": -2.3, "böse": -2.2, "krank": -2.2, "schmerz": -2.3, "müde": -1.8,
+		"angst": -2.2, "langweilig": -1.3, "tot": -2.9, "tod": -2.9, "problem": -1.7, "einsam": -1.8,
+		"enttäuscht": -1.9, "weinen": -2.1,
+	},
+	negations:    []string{"nicht", "kein", "keine", "keinen", "keiner", "nie", "niemals", "nichts", "niemand", "ohne"},
+	intensifiers: map[string]float64{"sehr": 1.3, "so": 1.3, "total": 1.3, "echt": 1.3, "wirklich": 1.3, "ziemlich": 1.1, "zu": 1.2},
+}
+
+var portuguese = language{
+	words: map[string]float64{
+		"bom": 1.9, "boa": 1.9, "bem": 1.5, "ótimo": 2.8, "ótima": 2.8, "excelente": 3.1, "incrível": 2.8,
+		"maravilhoso": 2.8, "maravilhosa": 2.8, "perfeito": 2.7, "perfeita": 2.7, "melhor": 2.0,
+		"lindo": 2.5, "linda": 2.5, "bonito": 2.2, "bonita": 2.2, "amor": 3.0, "amo": 3.0, "adoro": 3.0,
+		"feliz": 2.8, "felicidade": 2.8, "alegria": 2.8, "obrigado": 1.9, "obrigada": 1.9,
+		"divertido": 2.2, "parabéns": 2.8, "sucesso": 2.7, "orgulho": 2.1,
+		"mau": -2.5, "ruim": -2.5, "mal": -2.0, "pior": -2.5, "terrível": -2.5, "horrível": -2.5,
+		"odeio": -2.9, "ódio": -2.9, "triste": -2.1, "tristeza": -2.2, "raiva": -2.3, "doente": -2.2,
+		"dor": -2.3, "cansado": -1.8, "cansada": -1.8, "medo": -2.2, "chato": -1.5, "morte": -2.9,
+		"morreu": -2.6, "problema": -1.7, "sozinho": -1.0, "sozinha": -1.0, "saudade": -0.8, "chorar": -2.1,
+	},
+	negations:    []string{"não", "nunca", "jamais", "nada", "ninguém", "nem", "sem"},
+	intensifiers: map[string]float64{"muito": 1.3, "tão": 1.3, "super": 1.3, "demais": 1.2, "bastante": 1.1, "pouco": 0.7},
+}
+
+var italian = language{
+	words: map[string]float64{
+		"buono": 1.9, "buona": 1.9, "bene": 1.5, "ottimo": 2.8, "ottima": 2.8, "eccellente": 3.1,
+		"fantastico": 2.6, "fantastica": 2.6, "meraviglioso": 2.8, "perfetto": 2.7, "perfetta": 2.7,
+		"migliore": 2.0, "bello": 2.2, "bella": 2.2, "amore": 3.0, "amo": 3.0, "adoro": 3.0, "felice": 2.8,
+		"felicità": 2.8, "gioia": 2.8, "contento": 2.2, "contenta": 2.2, "grazie": 1.9, "divertente": 2.2,
+		"auguri": 2.3, "complimenti": 2.5, "bravo": 2.5, "brava": 2.5, "orgoglioso": 2.1,
+		"cattivo": -2.5, "cattiva": -2.5, "peggio": -2.5, "peggiore": -2.5, "terribile": -2.5,
+		"orribile": -2.5, "odio": -2.9, "triste": -2.1, "tristezza": -2.2, "arrabbiato": -2.3,
+		"arrabbiata": -2.3, "malato": -2.2, "malata": -2.2, "dolore": -2.3, "stanco": -1.8, "stanca": -1.8,
+		"paura": -2.2, "noioso": -1.3, "morte": -2.9, "morto": -2.9, "problema": -1.7, "piangere": -2.1,
+	},
+	negations:    []string{"non", "mai", "niente", "nulla", "nessuno", "nessuna", "né", "senza"},
+	intensifiers: map[string]float64{"molto": 1.3, "così": 1.3, "troppo": 1.2, "davvero": 1.3, "proprio": 1.2, "poco": 0.7},
+}
+
+var indonesian = language{
+	words: map[string]float64{
+		"baik": 1.9, "bagus": 2.2, "hebat": 2.8, "keren": 2.2, "luar biasa": 3.0, "sempurna": 2.7,
+		"terbaik": 3.1, "indah": 2.5, "cantik": 2.5, "cinta": 3.0, "sayang": 2.5, "suka": 1.5,
+		"senang": 2.5, "bahagia": 2.8, "gembira": 2.8, "terima kasih": 1.9, "makasih": 1.9, "lucu": 1.9,
+		"selamat": 2.2, "sukses": 2.7, "bangga": 2.1, "enak": 2.2, "asyik": 2.2, "mantap": 2.5,
+		"buruk": -2.5, "jelek": -2.3, "terburuk": -3.1, "benci": -2.9, "sedih": -2.1, "marah": -2.3,
+		"sakit": -2.3, "lelah": -1.8, "capek": -1.8, "takut": -2.2, "bosan": -1.3, "mati": -2.9,
+		"meninggal": -2.6, "masalah": -1.7, "kecewa": -1.9, "kesal": -1.8, "menangis": -2.1, "kangen": -0.8,
+	},
+	negations:    []string{"tidak", "tak", "bukan", "belum", "jangan", "nggak", "gak", "enggak", "tanpa"},
+	intensifiers: map[string]float64{"sangat": 1.3, "sekali": 1.3, "banget": 1.3, "amat": 1.3, "terlalu": 1.2, "agak": 0.8},
+}
+
+var vietnamese = language{
+	words: map[string]float64{
+		"tốt": 1.9, "giỏi": 2.2, "tuyệt": 2.8, "tuyệt vời": 3.1, "hoàn hảo": 2.7,
+		"đẹp": 2.5, "xinh": 2.2, "yêu": 3.0, "thương": 2.5, "thích": 1.5, "vui": 2.5, "vui vẻ": 2.7,
+		"hạnh phúc": 2.9, "cảm ơn": 1.9, "cám ơn": 1.9, "chúc mừng": 2.8, "thành công": 2.7,
+		"tự hào": 2.1, "ngon": 2.2, "dễ thương": 2.4, "xuất sắc": 3.1, "tệ": -2.5, "xấu": -2.3,
+		"ghét": -2.9, "buồn": -2.1, "giận": -2.3, "tức": -2.0, "ốm": -2.2, "bệnh": -2.2, "đau": -2.3,
+		"mệt": -1.8, "sợ": -2.2, "chán": -1.5, "chết": -2.9, "vấn đề": -1.7, "thất vọng": -1.9,
+		"cô đơn": -1.8, "khóc": -2.1, "nhớ": -0.8, "tồi tệ": -2.8,
+	},
+	negations:    []string{"không", "chẳng", "chả", "chưa", "đừng", "chớ"},
+	intensifiers: map[string]float64{"rất": 1.3, "quá": 1.3, "lắm": 1.3, "cực": 1.4, "cực kỳ": 1.4, "hơi": 0.8},
+}
+
+var korean = language{
+	words: map[string]float64{
+		"좋아": 2.0, "좋아요": 2.0, "좋다": 2.0, "좋은": 1.9, "좋네요": 2.0, "최고": 3.1, "최고야": 3.1,
+		"멋지다": 2.5, "멋진": 2.5, "예쁘다": 2.5, "예쁜": 2.5, "사랑": 3.0, "사랑해": 3.2, "사랑해요": 3.2,
+		"행복": 2.8, "행복해": 2.8, "행복하다": 2.8, "기쁘다": 2.6, "기뻐요": 2.6, "감사": 1.9,
+		"감사합니다": 1.9, "고마워": 1.9, "고맙습니다": 1.9, "축하": 2.6, "축하해": 2.6, "재밌다": 2.2,
+		"재미있어요": 2.2, "나쁘다": -2.5, "나쁜": -2.5, "싫어": -2.5, "싫다": -2.5, "최악": -3.1,
+		"슬퍼": -2.1, "슬프다": -2.1, "화나": -2.3, "아파": -2.3, "아프다": -2.3, "피곤해": -1.8,
+		"무서워": -2.2, "지루해": -1.3, "외로워": -1.8, "짜증나": -2.0, "울었어": -2.1,
+	},
+	negations:    []string{"안", "못", "않아", "않다", "없어", "없다"},
+	intensifiers: map[string]float64{"너무": 1.3, "정말": 1.3, "진짜": 1.3, "아주": 1.3, "매우": 1.3, "완전": 1.3},
+}
+
+var chinese = language{
+	continuous: true,
+	words: map[string]float64{
+		"好": 1.9, "很好": 2.2, "不错": 1.8, "棒": 2.5, "太棒": 3.0, "优秀": 2.8, "優秀": 2.8, "完美": 2.7,
+		"美": 2.0, "漂亮": 2.5, "可爱": 2.2, "可愛": 2.2, "爱": 3.0, "愛": 3.0, "喜欢": 2.0, "喜歡": 2.0,
+		"开心": 2.6, "開心": 2.6, "快乐": 2.8, "快樂": 2.8, "高兴": 2.6, "高興": 2.6, "幸福": 2.9,
+		"谢谢": 1.9, "謝謝": 1.9, "感谢": 2.0, "感謝": 2.0, "恭喜": 2.8, "成功": 2.7, "骄傲": 2.1,
+		"好吃": 2.2, "哈哈": 2.0, "坏": -2.5, "壞": -2.5, "差": -2.0, "糟糕": -2.5, "讨厌": -2.7,
+		"討厭": -2.7, "恨": -2.9, "难过": -2.1, "難過": -2.1, "伤心": -2.3, "傷心": -2.3, "生气": -2.3,
+		"生氣": -2.3, "病": -2.0, "痛": -2.3, "累": -1.8, "怕": -2.0, "害怕": -2.2, "无聊": -1.3,
+		"無聊": -1.3, "死": -2.9, "问题": -1.7, "問題": -1.7, "失望": -1.9, "孤独": -1.8, "孤獨": -1.8,
+		"哭": -2.1, "烦": -1.8, "煩": -1.8,
+	},
+	negations:    []string{"不", "没", "沒", "没有", "沒有", "别", "別", "无", "無", "未"},
+	intensifiers: map[string]float64{"很": 1.3, "非常": 1.4, "太": 1.3, "真": 1.3, "超": 1.3, "特别": 1.3, "特別": 1.3, "有点": 0.8, "有點": 0.8},
+}
+
+// japanese adjectives are stems so that their negations, like 楽しくない, are matched as suffixes
+var japanese = language{
+	continuous: true,
+	words: map[string]float64{
+		"良": 1.9, "素晴らし": 3.0, "すばらし": 3.0, "最高": 3.1, "完璧": 2.7, "綺麗": 2.5, "きれい": 2.5,
+		"美し": 2.5, "可愛": 2.2, "かわい": 2.2, "好き": 2.0, "大好き": 3.0, "愛": 3.0, "嬉し": 2.8,
+		"うれし": 2.8, "楽し": 2.6, "たのし": 2.6, "幸せ": 2.9, "ありがと": 1.9, "感謝": 2.0,
+		"おめでと": 2.8, "成功": 2.7, "美味し": 2.2, "おいし": 2.2, "面白": 2.0, "悪": -2.5, "最悪": -3.1,
+		"嫌い": -2.7, "大嫌い": -3.0, "悲し": -2.1, "かなし": -2.1, "寂し": -1.8, "さびし": -1.8,
+		"怒": -2.3, "痛": -2.3, "疲れ": -1.8, "怖": -2.2, "こわ": -2.2, "つまらな": -1.5, "死": -2.9,
+		"問題": -1.7, "残念": -1.9, "泣": -2.1, "辛": -2.2, "つら": -2.2,
+	},
+	negations:        []string{},
+	intensifiers:     map[string]float64{"とても": 1.3, "すごく": 1.3, "本当に": 1.3, "超": 1.3, "めっちゃ": 1.3, "少し": 0.7},
+	negationSuffixes: []string{"くない", "くなかった", "じゃない", "ではない", "くありません", "ない", "なかった", "ません"},
+}
+
+var emoji = language{
+	words: map[string]float64{
+		":)": 2.0, ":-)": 2.0, ":d": 2.3, ":-d": 2.3, "xd": 2.0, ";)": 1.5, ";-)": 1.5, ":p": 1.4,
+		"<3": 3.0, "^^": 2.0, "^_^": 2.0, ":(": -2.2, ":-(": -2.2, ":'(": -2.5, "</3": -2.8, ":/": -1.0,
+		"-_-": -1.2, "t_t": -2.2,
+		"😀": 2.2, "😃": 2.2, "😄": 2.4, "😁": 2.2, "😆": 2.2, "😂": 2.2, "🤣": 2.2, "😊": 2.5, "🙂": 1.5,
+		"😍": 3.0, "🥰": 3.0, "😘": 2.6, "😎": 2.0, "🥳": 2.8, "👍": 2.0, "👏": 2.2, "🙏": 1.5, "🎉": 2.5,
+		"❤": 3.0, "💕": 3.0, "💖": 3.0, "💯": 2.0, "✨": 1.5, "🌟": 1.8, "😢": -2.2, "😭": -2.5, "😞": -2.0,
+		"😔": -1.8, "😟": -1.8, "😠": -2.5, "😡": -2.8, "🤬": -3.0, "😱": -2.0, "😨": -2.0, "😩": -2.0,
+		"😫": -2.0, "💔": -2.8, "👎": -2.0, "🤢": -2.2, "😤": -1.8, "🙁": -1.5,
+	},
+}
@@ -0,0 +1,58 @@
+// Package sentiment analyzes the sentiment of texts written by users, like the posts of their archives.
+package sentiment
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Scores of sentences, from very negative to very positive
+const (
+	VeryNegative = -2
+	Negative     = -1
+	Neutral      = 0
+	Positive     = 1
+	VeryPositive = 2
+)
+
+// Analyzer analyzes the sentiment of texts
+type Analyzer interface {
+	// Analyze returns the scores of the sentences of a text
+	Analyze(ctx context.Context, text string) ([]int, error)
+}
+
+// Format formats the scores of the sentences of a text in the way they are stored with the text, like "1,-1"
+func Format(scores []int) string {
+	values := make([]string, 0, len(scores))
+	for _, s := range scores {
+		values = append(values, strconv.Itoa(s))
+	}
+	return strings.Join(values, ",")
+}
+
+// ParseScores parses the scores formatted by Format. Invalid scores are ignored.
+func ParseScores(s string) []int {
+	scores := make([]int, 0)
+	for _, v := range strings.Split(s, ",") {
+		score, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || score < VeryNegative || score > VeryPositive {
+			continue
+		}
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+// Average returns the average of the scores of the sentences of a text, which is neutral if there is no sentence
+func Average(scores []int) float64 {
+	if len(scores) == 0 {
+		return Neutral
+	}
+
+	total := 0
+	for _, s := range scores {
+		total += s
+	}
+	return float64(total) / float64(len(scores))
+}
@@ -0,0 +1,76 @@
+package sentiment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexicon(t *testing.T) {
+	l := NewLexicon()
+
+	for text, scores := range map[string][]int{
+		"":                              {},
+		"I love this place":             {VeryPositive},
+		"The food was good":             {Positive},
+		"The food was not good":         {Negative},
+		"I don't like it":               {Negative},
+		"Very bad day. I hate traffic!": {VeryNegative, Negative},
+		"See you tomorrow":              {Neutral},
+		"I'm so happy 😍":                {VeryPositive},
+		"Estoy muy feliz":               {VeryPositive},
+		"No estoy contento":             {Negative},
+		"C'est magnifique":              {Positive},
+		"Je ne suis pas heureux":        {Negative},
+		"Das ist nicht gut":             {Negative},
+		"Hôm nay tôi rất vui":           {VeryPositive},
+		"Hôm qua tôi không vui":         {Negative},
+		"今天很开心":                         {VeryPositive},
+		"我不喜欢这个":                        {Negative},
+		"とても楽しかった。でも楽しくない": {VeryPositive, Negative},
+		"사랑해요":                              {VeryPositive},
+		":( missed the train":               {VeryNegative},
+		"Thanks everyone for the wishes <3": {VeryPositive},
+	} {
+		result, err := l.Analyze(context.Background(), text)
+		assert.NoError(t, err)
+		assert.Equal(t, scores, result, text)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "", Format([]int{}))
+	assert.Equal(t, "2,-1,0", Format([]int{VeryPositive, Negative, Neutral}))
+
+	assert.Equal(t, []int{VeryPositive, Negative, Neutral}, ParseScores("2,-1,0"))
+	assert.Equal(t, []int{Positive}, ParseScores("1, 5, x"))
+	assert.Equal(t, []int{}, ParseScores(""))
+
+	assert.Equal(t, 0.5, Average([]int{VeryPositive, Negative}))
+	assert.Equal(t, 0.0, Average([]int{}))
+}
+
+func TestDeepAI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("api-key"))
+		assert.Equal(t, "Nice day. Bad night.", r.FormValue("text"))
+		fmt.Fprint(w, `{"output": ["Positive", "Verynegative"]}`)
+	}))
+	defer server.Close()
+
+	d := NewDeepAI("token", NewLexicon())
+	d.endpoint = server.URL
+
+	scores, err := d.Analyze(context.Background(), "Nice day. Bad night.")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{Positive, VeryNegative}, scores)
+
+	// texts not in english are analyzed by the fallback without calling DeepAI
+	scores, err = d.Analyze(context.Background(), "今天很开心")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{VeryPositive}, scores)
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Cursor is the position of the last item of a page. It is handed to
+// clients as an opaque string so they can continue listing from there.
+type Cursor struct {
+	Timestamp int64  `json:"t"`
+	ID        string `json:"i,omitempty"`
+}
+
+// String encodes the cursor into an opaque string, a nil cursor is encoded as empty
+func (c *Cursor) String() string {
+	if c == nil {
+		return ""
+	}
+
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseCursor decodes a cursor from the string returned by Cursor.String.
+// An empty string gives a nil cursor which means starting from the first page.
+func ParseCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
@@ -160,6 +160,75 @@ func (d *DynamoDBStore) GetFBStat(ctx context.Context, key string, from, to, lim
 	return d.queryFBStatResult(input, limit)
 }
 
+// GetFBStatPage queries one page of stats starting after the cursor. The last
+// evaluated key of dynamodb is returned as the cursor of the next page.
+func (d *DynamoDBStore) GetFBStatPage(ctx context.Context, key string, from, to, limit int64, cursor *store.Cursor) ([][]byte, *store.Cursor, error) {
+	input := &dynamodb.QueryInput{
+		TableName: d.table,
+		KeyConditions: map[string]*dynamodb.Condition{
+			"key": {
+				ComparisonOperator: aws.String("EQ"),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					{
+						S: aws.String(key),
+					},
+				},
+			},
+			"timestamp": {
+				ComparisonOperator: aws.String("BETWEEN"),
+				AttributeValueList: []*dynamodb.AttributeValue{
+					{
+						N: aws.String(strconv.FormatInt(from, 10)),
+					},
+					{
+						N: aws.String(strconv.FormatInt(to, 10)),
+					},
+				},
+			},
+		},
+		Limit:            aws.Int64(limit),
+		ScanIndexForward: aws.Bool(false),
+	}
+
+	if cursor != nil {
+		input.ExclusiveStartKey = map[string]*dynamodb.AttributeValue{
+			"key": {
+				S: aws.String(key),
+			},
+			"timestamp": {
+				N: aws.String(strconv.FormatInt(cursor.Timestamp, 10)),
+			},
+		}
+	}
+
+	result, err := d.svc.Query(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []store.FbData
+
+	if err := dynamodbattribute.UnmarshalListOfMaps(result.Items, &items); err != nil {
+		return nil, nil, err
+	}
+
+	var data [][]byte
+	for _, i := range items {
+		data = append(data, i.Data)
+	}
+
+	if result.LastEvaluatedKey == nil {
+		return data, nil, nil
+	}
+
+	var lastKey store.FbData
+	if err := dynamodbattribute.UnmarshalMap(result.LastEvaluatedKey, &lastKey); err != nil {
+		return nil, nil, err
+	}
+
+	return data, &store.Cursor{Timestamp: lastKey.Timestamp}, nil
+}
+
 func (d *DynamoDBStore) GetExactFBStat(ctx context.Context, key string, in int64) ([]byte, error) {
 	input := &dynamodb.QueryInput{
 		TableName: d.table,
@@ -233,3 +302,18 @@ func (d *DynamoDBStore) RemoveFBStat(ctx context.Context, key string) error {
 
 	return nil
 }
+
+func (d *DynamoDBStore) RemoveExactFBStat(ctx context.Context, key string, in int64) error {
+	_, err := d.svc.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"key": {
+				S: aws.String(key),
+			},
+			"timestamp": {
+				N: aws.String(strconv.FormatInt(in, 10)),
+			},
+		},
+		TableName: d.table,
+	})
+	return err
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+func (m *MemoryStore) InsertAccount(ctx context.Context, accountNumber string, encPubKey []byte, metadata map[string]interface{}) (*store.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[accountNumber]; ok {
+		return nil, fmt.Errorf("account %s already exists", accountNumber)
+	}
+
+	if encPubKey == nil {
+		encPubKey = []byte{}
+	}
+
+	metadata, err := normalizeJSON(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account := &store.Account{
+		AccountNumber:       accountNumber,
+		EncryptionPublicKey: append([]byte{}, encPubKey...),
+		Metadata:            metadata,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	m.accounts[accountNumber] = account
+
+	return copyAccount(account), nil
+}
+
+func (m *MemoryStore) QueryAccount(ctx context.Context, params *store.AccountQueryParam) (*store.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	account := m.findAccount(params)
+	if account == nil {
+		return nil, nil
+	}
+
+	return copyAccount(account), nil
+}
+
+func (m *MemoryStore) UpdateAccountMetadata(ctx context.Context, params *store.AccountQueryParam, metadata map[string]interface{}) (*store.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	account := m.findAccount(params)
+	if account == nil {
+		return nil, nil
+	}
+
+	metadata, err := normalizeJSON(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range metadata {
+		account.Metadata[k] = v
+	}
+
+	return copyAccount(account), nil
+}
+
+func (m *MemoryStore) DeleteAccount(ctx context.Context, accountNumber string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.accounts, accountNumber)
+
+	// archives are removed along with their account
+	for id, a := range m.archives {
+		if a.AccountNumber == accountNumber {
+			delete(m.archives, id)
+			delete(m.stages, id)
+		}
+	}
+
+	// so are refresh tokens
+	for token, t := range m.tokens {
+		if t.AccountNumber == accountNumber {
+			delete(m.tokens, token)
+		}
+	}
+
+	return nil
+}
+
+// findAccount returns the first account matching params. The caller must hold the lock.
+func (m *MemoryStore) findAccount(params *store.AccountQueryParam) *store.Account {
+	if params.AccountNumber != nil {
+		return m.accounts[*params.AccountNumber]
+	}
+
+	for _, a := range m.accounts {
+		return a
+	}
+
+	return nil
+}
+
+func copyAccount(a *store.Account) *store.Account {
+	account := *a
+	account.EncryptionPublicKey = append([]byte{}, a.EncryptionPublicKey...)
+	account.Metadata = make(map[string]interface{}, len(a.Metadata))
+	for k, v := range a.Metadata {
+		account.Metadata[k] = v
+	}
+
+	return &account
+}
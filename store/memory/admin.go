@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// apiKey is an api key with the hash of the key
+type apiKey struct {
+	store.APIKey
+	keyHash string
+}
+
+func (m *MemoryStore) AddAPIKey(ctx context.Context, name, keyHash string, scopes []string, expireAt *time.Time) (*store.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.apiKeys[name]; ok {
+		return nil, fmt.Errorf("api key %s already exists", name)
+	}
+	for _, k := range m.apiKeys {
+		if k.keyHash == keyHash {
+			return nil, fmt.Errorf("api key %s has the same hash", k.Name)
+		}
+	}
+
+	key := &apiKey{
+		APIKey: store.APIKey{
+			Name:      name,
+			Scopes:    append([]string{}, scopes...),
+			ExpireAt:  expireAt,
+			CreatedAt: time.Now(),
+		},
+		keyHash: keyHash,
+	}
+	m.apiKeys[name] = key
+
+	return copyAPIKey(key), nil
+}
+
+func (m *MemoryStore) UseAPIKey(ctx context.Context, keyHash string) (*store.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, k := range m.apiKeys {
+		if k.keyHash == keyHash {
+			now := time.Now()
+			k.LastUsedAt = &now
+			return copyAPIKey(k), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MemoryStore) GetAPIKeys(ctx context.Context) ([]store.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]store.APIKey, 0, len(m.apiKeys))
+	for _, k := range m.apiKeys {
+		keys = append(keys, *copyAPIKey(k))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+
+	return keys, nil
+}
+
+func (m *MemoryStore) DeleteAPIKey(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.apiKeys, name)
+	return nil
+}
+
+func copyAPIKey(k *apiKey) *store.APIKey {
+	key := k.APIKey
+	key.Scopes = append([]string{}, k.Scopes...)
+	if k.ExpireAt != nil {
+		expireAt := *k.ExpireAt
+		key.ExpireAt = &expireAt
+	}
+	if k.LastUsedAt != nil {
+		lastUsedAt := *k.LastUsedAt
+		key.LastUsedAt = &lastUsedAt
+	}
+
+	return &key
+}
+
+func (m *MemoryStore) AddAuditLog(ctx context.Context, log *store.AuditLog) error {
+	params, err := normalizeJSON(log.Params)
+	if err != nil {
+		return err
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	log.ID = int64(len(m.auditLogs) + 1)
+	log.CreatedAt = time.Now()
+
+	stored := *log
+	stored.Params = params
+	m.auditLogs = append(m.auditLogs, stored)
+
+	return nil
+}
+
+func (m *MemoryStore) GetAuditLogs(ctx context.Context, params *store.AuditLogQueryParam) ([]store.AuditLog, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	logs := make([]store.AuditLog, 0)
+	for i := len(m.auditLogs) - 1; i >= 0; i-- {
+		log := m.auditLogs[i]
+		if params.Actor != nil && log.Actor != *params.Actor {
+			continue
+		}
+		if params.Before != nil && log.ID >= *params.Before {
+			continue
+		}
+
+		logs = append(logs, log)
+		if params.Limit > 0 && uint64(len(logs)) == params.Limit {
+			break
+		}
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,268 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+var archiveStatuses = map[string]bool{
+	store.FBArchiveStatusCreated:    true,
+	store.FBArchiveStatusSubmitted:  true,
+	store.FBArchiveStatusStored:     true,
+	store.FBArchiveStatusProcessing: true,
+	store.FBArchiveStatusProcessed:  true,
+	store.FBArchiveStatusInvalid:    true,
+	store.FBArchiveStatusRemoved:    true,
+}
+
+// AddFBArchive to add an archive record from an account
+func (m *MemoryStore) AddFBArchive(ctx context.Context, accountNumber string, starting, ending time.Time) (*store.FBArchive, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[accountNumber]; !ok {
+		return nil, fmt.Errorf("account %s does not exist", accountNumber)
+	}
+
+	m.lastArchiveID++
+	now := time.Now()
+	archive := &store.FBArchive{
+		ID:               m.lastArchiveID,
+		AccountNumber:    accountNumber,
+		StartingTime:     starting,
+		EndingTime:       ending,
+		ProcessingStatus: store.FBArchiveStatusCreated,
+		ProcessingError:  json.RawMessage("{}"),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	m.archives[archive.ID] = archive
+
+	return copyArchive(archive), nil
+}
+
+// UpdateFBArchiveStatus to update status for a particular fb archive record with s3 key
+func (m *MemoryStore) UpdateFBArchiveStatus(ctx context.Context, params *store.FBArchiveQueryParam, values *store.FBArchiveQueryParam) ([]store.FBArchive, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if values.Status != nil && !archiveStatuses[*values.Status] {
+		return nil, fmt.Errorf("invalid archive status: %s", *values.Status)
+	}
+
+	fbarchives := make([]store.FBArchive, 0)
+
+	for _, a := range m.filterArchives(&store.FBArchiveQueryParam{
+		ID:            params.ID,
+		AccountNumber: params.AccountNumber,
+		S3Key:         params.S3Key,
+	}) {
+		a.UpdatedAt = time.Now()
+
+		if values.S3Key != nil {
+			a.S3Key = *values.S3Key
+		}
+
+		if values.Status != nil {
+			a.ProcessingStatus = *values.Status
+		}
+
+		if values.AnalyzedID != nil {
+			a.AnalyzedTaskID = *values.AnalyzedID
+		}
+
+		if values.ContentHash != nil {
+			a.ContentHash = *values.ContentHash
+		}
+
+		if values.WorkflowID != nil {
+			a.WorkflowID = *values.WorkflowID
+		}
+
+		if values.BitmarkID != nil {
+			a.BitmarkID = *values.BitmarkID
+		}
+
+		fbarchives = append(fbarchives, *copyArchive(a))
+	}
+
+	return fbarchives, nil
+}
+
+func (m *MemoryStore) GetFBArchives(ctx context.Context, params *store.FBArchiveQueryParam) ([]store.FBArchive, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fbarchives := make([]store.FBArchive, 0)
+	for _, a := range m.filterArchives(params) {
+		fbarchives = append(fbarchives, *copyArchive(a))
+	}
+
+	return fbarchives, nil
+}
+
+func (m *MemoryStore) InvalidFBArchive(ctx context.Context, params *store.FBArchiveQueryParam) error {
+	if params.ID == nil {
+		return fmt.Errorf("archive id is required to invalid a archive")
+	}
+
+	if params.Error == nil {
+		return fmt.Errorf("message is required to invalid a archive")
+	}
+
+	b, err := json.Marshal(params.Error)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if a, ok := m.archives[*params.ID]; ok {
+		a.UpdatedAt = time.Now()
+		a.ProcessingStatus = store.FBArchiveStatusInvalid
+		a.ProcessingError = b
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteFBArchives(ctx context.Context, params *store.FBArchiveQueryParam) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, a := range m.filterArchives(&store.FBArchiveQueryParam{
+		ID:            params.ID,
+		AccountNumber: params.AccountNumber,
+		S3Key:         params.S3Key,
+		Status:        params.Status,
+	}) {
+		delete(m.archives, a.ID)
+		delete(m.stages, a.ID)
+	}
+
+	return nil
+}
+
+// StartFBArchiveStage records the start of a stage of processing an archive
+func (m *MemoryStore) StartFBArchiveStage(ctx context.Context, archiveID int64, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.archives[archiveID]; !ok {
+		return fmt.Errorf("archive %d does not exist", archiveID)
+	}
+
+	stage := &store.FBArchiveStage{
+		ArchiveID: archiveID,
+		Name:      name,
+		Status:    store.FBArchiveStageStatusRunning,
+		StartedAt: time.Now(),
+	}
+
+	stages := m.stages[archiveID]
+	for i, s := range stages {
+		if s.Name == name {
+			stages = append(stages[:i], stages[i+1:]...)
+			break
+		}
+	}
+	m.stages[archiveID] = append(stages, stage)
+
+	return nil
+}
+
+// FinishFBArchiveStage records the end of a stage of processing an archive
+func (m *MemoryStore) FinishFBArchiveStage(ctx context.Context, archiveID int64, name string, itemCount int64, stageError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.stages[archiveID] {
+		if s.Name != name {
+			continue
+		}
+
+		now := time.Now()
+		s.Status = store.FBArchiveStageStatusFinished
+		if stageError != "" {
+			s.Status = store.FBArchiveStageStatusFailed
+		}
+		s.ItemCount = itemCount
+		s.Error = stageError
+		s.FinishedAt = &now
+	}
+
+	return nil
+}
+
+// GetFBArchiveStages returns the stages of archives ordered by their archives and their starting time
+func (m *MemoryStore) GetFBArchiveStages(ctx context.Context, archiveIDs []int64) ([]store.FBArchiveStage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := append([]int64{}, archiveIDs...)
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	stages := make([]store.FBArchiveStage, 0)
+	for i, id := range ids {
+		if i > 0 && ids[i-1] == id {
+			continue
+		}
+
+		// stages of an archive are kept in the order of their starting time
+		for _, s := range m.stages[id] {
+			stage := *s
+			if s.FinishedAt != nil {
+				finishedAt := *s.FinishedAt
+				stage.FinishedAt = &finishedAt
+			}
+			stages = append(stages, stage)
+		}
+	}
+
+	return stages, nil
+}
+
+// filterArchives returns archives matching params ordered by id. The caller must hold the lock.
+func (m *MemoryStore) filterArchives(params *store.FBArchiveQueryParam) []*store.FBArchive {
+	archives := make([]*store.FBArchive, 0)
+
+	for _, a := range m.archives {
+		if params.ID != nil && a.ID != *params.ID {
+			continue
+		}
+
+		if params.S3Key != nil && a.S3Key != *params.S3Key {
+			continue
+		}
+
+		if params.AccountNumber != nil && a.AccountNumber != *params.AccountNumber {
+			continue
+		}
+
+		if params.Status != nil && a.ProcessingStatus != *params.Status {
+			continue
+		}
+
+		archives = append(archives, a)
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].ID < archives[j].ID
+	})
+
+	return archives
+}
+
+func copyArchive(a *store.FBArchive) *store.FBArchive {
+	archive := *a
+	archive.ProcessingError = append(json.RawMessage{}, a.ProcessingError...)
+	return &archive
+}
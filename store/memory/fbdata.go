@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// AddFBStat to add a FB stat
+func (m *MemoryStore) AddFBStat(ctx context.Context, key string, timestamp int64, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.putFBStat(key, timestamp, value)
+	return nil
+}
+
+// AddFBStats to add multiple FB stats
+func (m *MemoryStore) AddFBStats(ctx context.Context, data []store.FbData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range data {
+		m.putFBStat(d.Key, d.Timestamp, d.Data)
+	}
+	return nil
+}
+
+// GetFBStat returns the stats of a key between from and to, newest first.
+// A zero limit returns all of them.
+func (m *MemoryStore) GetFBStat(ctx context.Context, key string, from, to, limit int64) ([][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	timestamps := m.fbStatTimestamps(key, from, to)
+	if limit > 0 && len(timestamps) > int(limit) {
+		timestamps = timestamps[:limit]
+	}
+
+	return m.fbStatData(key, timestamps), nil
+}
+
+// GetFBStatPage returns a page of stats of a key older than the cursor, newest first
+func (m *MemoryStore) GetFBStatPage(ctx context.Context, key string, from, to, limit int64, cursor *store.Cursor) ([][]byte, *store.Cursor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cursor != nil && cursor.Timestamp-1 < to {
+		to = cursor.Timestamp - 1
+	}
+
+	timestamps := m.fbStatTimestamps(key, from, to)
+	if len(timestamps) <= int(limit) {
+		return m.fbStatData(key, timestamps), nil, nil
+	}
+
+	timestamps = timestamps[:limit]
+	return m.fbStatData(key, timestamps), &store.Cursor{Timestamp: timestamps[limit-1]}, nil
+}
+
+// GetExactFBStat returns the stat of a key at the exact timestamp
+func (m *MemoryStore) GetExactFBStat(ctx context.Context, key string, in int64) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d, ok := m.fbStats[key][in]
+	if !ok {
+		return nil, nil
+	}
+
+	return append([]byte{}, d...), nil
+}
+
+// RemoveFBStat removes all stats of a key
+func (m *MemoryStore) RemoveFBStat(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.fbStats, key)
+	return nil
+}
+
+// RemoveExactFBStat removes the stat of a key at the exact timestamp
+func (m *MemoryStore) RemoveExactFBStat(ctx context.Context, key string, in int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.fbStats[key], in)
+	return nil
+}
+
+// putFBStat stores a copy of value. The caller must hold the lock.
+func (m *MemoryStore) putFBStat(key string, timestamp int64, value []byte) {
+	stats, ok := m.fbStats[key]
+	if !ok {
+		stats = make(map[int64][]byte)
+		m.fbStats[key] = stats
+	}
+
+	stats[timestamp] = append([]byte{}, value...)
+}
+
+// fbStatTimestamps returns the timestamps of a key between from and to,
+// newest first. The caller must hold the lock.
+func (m *MemoryStore) fbStatTimestamps(key string, from, to int64) []int64 {
+	timestamps := make([]int64, 0)
+	for ts := range m.fbStats[key] {
+		if ts >= from && ts <= to {
+			timestamps = append(timestamps, ts)
+		}
+	}
+
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] > timestamps[j]
+	})
+
+	return timestamps
+}
+
+// fbStatData returns copies of the stats of a key at timestamps. The caller must hold the lock.
+func (m *MemoryStore) fbStatData(key string, timestamps []int64) [][]byte {
+	var data [][]byte
+	for _, ts := range timestamps {
+		data = append(data, append([]byte{}, m.fbStats[key][ts]...))
+	}
+
+	return data
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// MemoryStore will store everything in memory. It implements both
+// store.Store and store.FBDataStore and is meant for tests and for
+// running the services without any database.
+type MemoryStore struct {
+	store.Store
+	store.FBDataStore
+
+	mu            sync.Mutex
+	accounts      map[string]*store.Account
+	archives      map[int64]*store.FBArchive
+	lastArchiveID int64
+	stages        map[int64][]*store.FBArchiveStage
+	fbStats       map[string]map[int64][]byte
+	tokens        map[string]*store.Token
+	revocations   map[string]map[string]revocation
+	apiKeys       map[string]*apiKey
+	auditLogs     []store.AuditLog
+}
+
+// NewMemoryStore new instance of memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		accounts:    make(map[string]*store.Account),
+		archives:    make(map[int64]*store.FBArchive),
+		stages:      make(map[int64][]*store.FBArchiveStage),
+		fbStats:     make(map[string]map[int64][]byte),
+		tokens:      make(map[string]*store.Token),
+		revocations: make(map[string]map[string]revocation),
+		apiKeys:     make(map[string]*apiKey),
+	}
+}
+
+func (m *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *MemoryStore) Close(ctx context.Context) error {
+	return nil
+}
+
+// normalizeJSON passes v through a json round trip so values read back
+// from the store have the same types as if they were read from a jsonb column
+func normalizeJSON(v map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{})
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
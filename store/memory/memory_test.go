@@ -0,0 +1,15 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/bitmark-inc/spring-app-api/store/storetest"
+)
+
+func Test_Store(t *testing.T) {
+	storetest.TestStore(t, NewMemoryStore())
+}
+
+func Test_FBDataStore(t *testing.T) {
+	storetest.TestFBDataStore(t, NewMemoryStore())
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+func (m *MemoryStore) CountAccountCreation(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counted := make(map[string]bool)
+	result := map[string]int{
+		"ios":     0,
+		"android": 0,
+	}
+
+	for _, a := range m.archives {
+		if a.ProcessingStatus != store.FBArchiveStatusProcessed || counted[a.AccountNumber] {
+			continue
+		}
+
+		account, ok := m.accounts[a.AccountNumber]
+		if !ok {
+			continue
+		}
+
+		if !from.IsZero() && account.CreatedAt.Before(from) {
+			continue
+		}
+
+		if !to.IsZero() && account.CreatedAt.After(to) {
+			continue
+		}
+
+		platform, _ := account.Metadata["platform"].(string)
+		if _, ok := result[platform]; ok {
+			result[platform]++
+			counted[a.AccountNumber] = true
+		}
+	}
+
+	return result, nil
+}
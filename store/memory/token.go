@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// accountRevocationID is the id of a revocation of all tokens of an account
+const accountRevocationID = ""
+
+// revocation denies access tokens until it expires
+type revocation struct {
+	revokedAt time.Time
+	expireAt  time.Time
+}
+
+func (m *MemoryStore) AddToken(ctx context.Context, tokenHash, accountNumber string, info map[string]interface{}, expireAt time.Time) (*store.Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[accountNumber]; !ok {
+		return nil, fmt.Errorf("account %s not found", accountNumber)
+	}
+	if _, ok := m.tokens[tokenHash]; ok {
+		return nil, errors.New("token already exists")
+	}
+
+	info, err := normalizeJSON(info)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		info = map[string]interface{}{}
+	}
+
+	t := &store.Token{
+		Token:         tokenHash,
+		AccountNumber: accountNumber,
+		Info:          info,
+		CreatedAt:     time.Now(),
+		ExpireAt:      expireAt,
+	}
+	m.tokens[tokenHash] = t
+
+	copied := *t
+	return &copied, nil
+}
+
+func (m *MemoryStore) DeleteTokens(ctx context.Context, params *store.TokenQueryParam) ([]store.Token, error) {
+	if params.Token == nil && params.AccountNumber == nil {
+		return nil, errors.New("no condition to delete tokens")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tokens := make([]store.Token, 0)
+	for token, t := range m.tokens {
+		if params.Token != nil && token != *params.Token {
+			continue
+		}
+		if params.AccountNumber != nil && t.AccountNumber != *params.AccountNumber {
+			continue
+		}
+
+		tokens = append(tokens, *t)
+		delete(m.tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (m *MemoryStore) RevokeToken(ctx context.Context, accountNumber, tokenID string, expireAt time.Time) error {
+	m.revoke(accountNumber, tokenID, time.Now(), expireAt)
+	return nil
+}
+
+func (m *MemoryStore) RevokeAccountTokens(ctx context.Context, accountNumber string, revokedAt, expireAt time.Time) error {
+	m.revoke(accountNumber, accountRevocationID, revokedAt.Truncate(time.Second), expireAt)
+	return nil
+}
+
+func (m *MemoryStore) revoke(accountNumber, id string, revokedAt, expireAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.revocations[accountNumber]; !ok {
+		m.revocations[accountNumber] = make(map[string]revocation)
+	}
+	m.revocations[accountNumber][id] = revocation{revokedAt: revokedAt, expireAt: expireAt}
+}
+
+func (m *MemoryStore) IsTokenRevoked(ctx context.Context, accountNumber, tokenID string, issuedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	revocations := m.revocations[accountNumber]
+
+	if r, ok := revocations[tokenID]; ok && r.expireAt.After(now) {
+		return true, nil
+	}
+
+	if r, ok := revocations[accountRevocationID]; ok && r.expireAt.After(now) && !r.revokedAt.Before(issuedAt) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (m *MemoryStore) DeleteExpiredTokens(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted int64
+	for token, t := range m.tokens {
+		if t.ExpireAt.Before(before) {
+			delete(m.tokens, token)
+			deleted++
+		}
+	}
+
+	for accountNumber, revocations := range m.revocations {
+		for id, r := range revocations {
+			if r.expireAt.Before(before) {
+				delete(revocations, id)
+				deleted++
+			}
+		}
+		if len(revocations) == 0 {
+			delete(m.revocations, accountNumber)
+		}
+	}
+
+	return deleted, nil
+}
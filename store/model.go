@@ -17,6 +17,7 @@ type Account struct {
 
 // Token represents an token on behalf of an account
 type Token struct {
+	// Token is the hash of the token
 	Token         string
 	AccountNumber string
 	Info          map[string]interface{}
@@ -24,6 +25,37 @@ type Token struct {
 	ExpireAt      time.Time
 }
 
+// APIKey represents a named key with the scopes of the admin apis it is allowed to access.
+// Only the hash of a key is stored.
+type APIKey struct {
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	ExpireAt   *time.Time `json:"expire_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// HasScope returns whether the key is allowed to access a scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditLog represents an admin action done with an api key
+type AuditLog struct {
+	ID         int64                  `json:"id"`
+	Actor      string                 `json:"actor"`
+	Action     string                 `json:"action"`
+	Params     map[string]interface{} `json:"params"`
+	Status     int                    `json:"status"`
+	RemoteAddr string                 `json:"remote_addr"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
 // FBArchive represents a fb archive information
 type FBArchive struct {
 	ID               int64           `json:"id"`
@@ -35,10 +67,40 @@ type FBArchive struct {
 	ProcessingError  json.RawMessage `json:"error"`
 	AnalyzedTaskID   string          `json:"analyzed_task_id,omitempty"`
 	ContentHash      string          `json:"content_hash,omitempty"`
+	WorkflowID       string          `json:"workflow_id,omitempty"`
+	BitmarkID        string          `json:"bitmark_id,omitempty"`
+	MergeSummary     MergeSummary    `json:"-"`
 	CreatedAt        time.Time       `json:"created_at"`
 	UpdatedAt        time.Time       `json:"updated_at"`
 }
 
+// FBArchiveStage represents a stage of processing a fb archive
+type FBArchiveStage struct {
+	ArchiveID  int64      `json:"-"`
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	ItemCount  int64      `json:"item_count"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// MergeSummary describes how the items of an archive are merged into the items
+// imported from the previous archives of the same account, by sections like post, reaction and comment.
+// It is nil if nothing was imported before the archive.
+type MergeSummary map[string]*SectionMergeSummary
+
+// SectionMergeSummary counts the merged items of a section
+type SectionMergeSummary struct {
+	New       int `json:"new"`
+	Changed   int `json:"changed"`
+	Unchanged int `json:"unchanged"`
+	Deleted   int `json:"deleted"`
+
+	// AffectedTimestamps are the timestamps of the new, changed and deleted items
+	AffectedTimestamps []int64 `json:"affected_timestamps"`
+}
+
 // FbData represent a statistic record for Facebook data that will be push to dynamodb
 type FbData struct {
 	Key       string `dynamodbav:"key"`
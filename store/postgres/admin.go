@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+const apiKeyColumns = "name, scopes, expired_at, last_used_at, created_at"
+
+func scanAPIKey(row pgx.Row) (*store.APIKey, error) {
+	var key store.APIKey
+	if err := row.Scan(&key.Name,
+		&key.Scopes,
+		&key.ExpireAt,
+		&key.LastUsedAt,
+		&key.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (p *PGStore) AddAPIKey(ctx context.Context, name, keyHash string, scopes []string, expireAt *time.Time) (*store.APIKey, error) {
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	q := psql.Insert("fbm.api_key").
+		SetMap(map[string]interface{}{
+			"name":       name,
+			"key_hash":   keyHash,
+			"scopes":     scopes,
+			"expired_at": expireAt,
+		}).
+		Suffix("RETURNING " + apiKeyColumns)
+
+	st, val, _ := q.ToSql()
+
+	return scanAPIKey(p.pool.QueryRow(ctx, st, val...))
+}
+
+func (p *PGStore) UseAPIKey(ctx context.Context, keyHash string) (*store.APIKey, error) {
+	q := psql.Update("fbm.api_key").
+		Set("last_used_at", time.Now()).
+		Where(sq.Eq{"key_hash": keyHash}).
+		Suffix("RETURNING " + apiKeyColumns)
+
+	st, val, _ := q.ToSql()
+
+	key, err := scanAPIKey(p.pool.QueryRow(ctx, st, val...))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	return key, err
+}
+
+func (p *PGStore) GetAPIKeys(ctx context.Context) ([]store.APIKey, error) {
+	st, val, _ := psql.Select(apiKeyColumns).
+		From("fbm.api_key").
+		OrderBy("name").
+		ToSql()
+
+	rows, err := p.pool.Query(ctx, st, val...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]store.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (p *PGStore) DeleteAPIKey(ctx context.Context, name string) error {
+	st, val, _ := psql.Delete("fbm.api_key").
+		Where(sq.Eq{"name": name}).
+		ToSql()
+
+	_, err := p.pool.Exec(ctx, st, val...)
+	return err
+}
+
+func (p *PGStore) AddAuditLog(ctx context.Context, log *store.AuditLog) error {
+	params := log.Params
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	q := psql.Insert("fbm.audit_log").
+		Columns("actor", "action", "params", "status", "remote_addr").
+		Values(log.Actor, log.Action, params, log.Status, log.RemoteAddr).
+		Suffix("RETURNING id, created_at")
+
+	st, val, _ := q.ToSql()
+
+	return p.pool.QueryRow(ctx, st, val...).Scan(&log.ID, &log.CreatedAt)
+}
+
+func (p *PGStore) GetAuditLogs(ctx context.Context, params *store.AuditLogQueryParam) ([]store.AuditLog, error) {
+	q := psql.Select("id, actor, action, params, status, remote_addr, created_at").
+		From("fbm.audit_log").
+		OrderBy("id DESC")
+
+	if params.Actor != nil {
+		q = q.Where(sq.Eq{"actor": *params.Actor})
+	}
+
+	if params.Before != nil {
+		q = q.Where(sq.Lt{"id": *params.Before})
+	}
+
+	if params.Limit > 0 {
+		q = q.Limit(params.Limit)
+	}
+
+	st, val, _ := q.ToSql()
+
+	rows, err := p.pool.Query(ctx, st, val...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := make([]store.AuditLog, 0)
+	for rows.Next() {
+		var log store.AuditLog
+		if err := rows.Scan(&log.ID,
+			&log.Actor,
+			&log.Action,
+			&log.Params,
+			&log.Status,
+			&log.RemoteAddr,
+			&log.CreatedAt); err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, rows.Err()
+}
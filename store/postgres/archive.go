@@ -19,7 +19,7 @@ func (p *PGStore) AddFBArchive(ctx context.Context, accountNumber string, starti
 		Insert("fbm.fbarchive").
 		Columns("account_number", "file_key", "starting_time", "ending_time").
 		Values(accountNumber, "", starting, ending).
-		Suffix("RETURNING id, account_number, file_key, starting_time, ending_time, analyzed_task_id, content_hash, processing_status, created_at, updated_at")
+		Suffix("RETURNING id, account_number, file_key, starting_time, ending_time, analyzed_task_id, content_hash, workflow_id, bitmark_id, processing_status, created_at, updated_at")
 
 	st, val, _ := q.ToSql()
 
@@ -32,6 +32,8 @@ func (p *PGStore) AddFBArchive(ctx context.Context, accountNumber string, starti
 			&fbArchive.EndingTime,
 			&fbArchive.AnalyzedTaskID,
 			&fbArchive.ContentHash,
+			&fbArchive.WorkflowID,
+			&fbArchive.BitmarkID,
 			&fbArchive.ProcessingStatus,
 			&fbArchive.CreatedAt,
 			&fbArchive.UpdatedAt); err != nil {
@@ -49,7 +51,7 @@ func (p *PGStore) AddFBArchive(ctx context.Context, accountNumber string, starti
 func (p *PGStore) UpdateFBArchiveStatus(ctx context.Context, params *store.FBArchiveQueryParam, values *store.FBArchiveQueryParam) ([]store.FBArchive, error) {
 	q := psql.Update("fbm.fbarchive").
 		Set("updated_at", time.Now()).
-		Suffix("RETURNING id, account_number, file_key, starting_time, ending_time, analyzed_task_id, content_hash, processing_status, created_at, updated_at")
+		Suffix("RETURNING id, account_number, file_key, starting_time, ending_time, analyzed_task_id, content_hash, workflow_id, bitmark_id, processing_status, created_at, updated_at")
 
 	if params.ID != nil {
 		q = q.Where(sq.Eq{"id": *params.ID})
@@ -79,6 +81,14 @@ func (p *PGStore) UpdateFBArchiveStatus(ctx context.Context, params *store.FBArc
 		q = q.Set("content_hash", *values.ContentHash)
 	}
 
+	if values.WorkflowID != nil {
+		q = q.Set("workflow_id", *values.WorkflowID)
+	}
+
+	if values.BitmarkID != nil {
+		q = q.Set("bitmark_id", *values.BitmarkID)
+	}
+
 	st, val, _ := q.ToSql()
 
 	rows, err := p.pool.Query(ctx, st, val...)
@@ -98,6 +108,8 @@ func (p *PGStore) UpdateFBArchiveStatus(ctx context.Context, params *store.FBArc
 			&fbArchive.EndingTime,
 			&fbArchive.AnalyzedTaskID,
 			&fbArchive.ContentHash,
+			&fbArchive.WorkflowID,
+			&fbArchive.BitmarkID,
 			&fbArchive.ProcessingStatus,
 			&fbArchive.CreatedAt,
 			&fbArchive.UpdatedAt); err != nil {
@@ -112,7 +124,7 @@ func (p *PGStore) UpdateFBArchiveStatus(ctx context.Context, params *store.FBArc
 
 func (p *PGStore) GetFBArchives(ctx context.Context, params *store.FBArchiveQueryParam) ([]store.FBArchive, error) {
 	q := psql.Select(`id, account_number, file_key, starting_time, ending_time, analyzed_task_id,
-					  content_hash, processing_status, processing_error, created_at, updated_at`).
+					  content_hash, workflow_id, bitmark_id, processing_status, processing_error, merge_summary, created_at, updated_at`).
 		From("fbm.fbarchive")
 
 	if params.ID != nil {
@@ -142,6 +154,7 @@ func (p *PGStore) GetFBArchives(ctx context.Context, params *store.FBArchiveQuer
 
 	for rows.Next() {
 		var fbArchive store.FBArchive
+		var mergeSummary []byte
 
 		if rows.Scan(&fbArchive.ID,
 			&fbArchive.AccountNumber,
@@ -150,13 +163,22 @@ func (p *PGStore) GetFBArchives(ctx context.Context, params *store.FBArchiveQuer
 			&fbArchive.EndingTime,
 			&fbArchive.AnalyzedTaskID,
 			&fbArchive.ContentHash,
+			&fbArchive.WorkflowID,
+			&fbArchive.BitmarkID,
 			&fbArchive.ProcessingStatus,
 			&fbArchive.ProcessingError,
+			&mergeSummary,
 			&fbArchive.CreatedAt,
 			&fbArchive.UpdatedAt); err != nil {
 			return nil, err
 		}
 
+		if len(mergeSummary) > 0 {
+			if err := json.Unmarshal(mergeSummary, &fbArchive.MergeSummary); err != nil {
+				return nil, err
+			}
+		}
+
 		fbarchives = append(fbarchives, fbArchive)
 	}
 
@@ -215,30 +237,72 @@ func (p *PGStore) DeleteFBArchives(ctx context.Context, params *store.FBArchiveQ
 	return err
 }
 
-// AddFBStat to add a FB stat
-func (p *PGStore) AddFBStat(ctx context.Context, key string, value interface{}) error {
-	q := psql.Insert("fbm.fbdata").Values(key, value)
+// StartFBArchiveStage records the start of a stage of processing an archive
+func (p *PGStore) StartFBArchiveStage(ctx context.Context, archiveID int64, name string) error {
+	q := psql.Insert("fbm.fbarchive_stage").
+		Columns("archive_id", "name", "status", "started_at").
+		Values(archiveID, name, store.FBArchiveStageStatusRunning, time.Now()).
+		Suffix(`ON CONFLICT (archive_id, name) DO UPDATE SET status = EXCLUDED.status,
+				item_count = 0, error = '', started_at = EXCLUDED.started_at, finished_at = NULL`)
+
 	st, val, _ := q.ToSql()
+
 	_, err := p.pool.Exec(ctx, st, val...)
 	return err
 }
 
-// GetFBStat to get a FB stat
-func (p *PGStore) GetFBStat(ctx context.Context, key string) (interface{}, error) {
-	q := psql.Select("fbm.fbdata").Where(sq.Eq{"data_name": key})
+// FinishFBArchiveStage records the end of a stage of processing an archive
+func (p *PGStore) FinishFBArchiveStage(ctx context.Context, archiveID int64, name string, itemCount int64, stageError string) error {
+	status := store.FBArchiveStageStatusFinished
+	if stageError != "" {
+		status = store.FBArchiveStageStatusFailed
+	}
+
+	q := psql.Update("fbm.fbarchive_stage").
+		Set("status", status).
+		Set("item_count", itemCount).
+		Set("error", stageError).
+		Set("finished_at", time.Now()).
+		Where(sq.Eq{"archive_id": archiveID, "name": name})
+
 	st, val, _ := q.ToSql()
 
-	var data interface{}
+	_, err := p.pool.Exec(ctx, st, val...)
+	return err
+}
 
-	if err := p.pool.
-		QueryRow(ctx, st, val...).
-		Scan(&data); err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil
-		}
+// GetFBArchiveStages returns the stages of archives ordered by their archives and their starting time
+func (p *PGStore) GetFBArchiveStages(ctx context.Context, archiveIDs []int64) ([]store.FBArchiveStage, error) {
+	q := psql.Select("archive_id, name, status, item_count, error, started_at, finished_at").
+		From("fbm.fbarchive_stage").
+		Where(sq.Eq{"archive_id": archiveIDs}).
+		OrderBy("archive_id", "started_at")
 
+	st, val, _ := q.ToSql()
+
+	rows, err := p.pool.Query(ctx, st, val...)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	stages := make([]store.FBArchiveStage, 0)
+
+	for rows.Next() {
+		var stage store.FBArchiveStage
+
+		if err := rows.Scan(&stage.ArchiveID,
+			&stage.Name,
+			&stage.Status,
+			&stage.ItemCount,
+			&stage.Error,
+			&stage.StartedAt,
+			&stage.FinishedAt); err != nil {
+			return nil, err
+		}
+
+		stages = append(stages, stage)
+	}
 
-	return data, nil
+	return stages, rows.Err()
 }
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/bitmark-inc/spring-app-api/store"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddFBStat to add a FB stat
+func (p *PGStore) AddFBStat(ctx context.Context, key string, timestamp int64, value []byte) error {
+	return p.AddFBStats(ctx, []store.FbData{
+		{
+			Key:       key,
+			Timestamp: timestamp,
+			Data:      value,
+		},
+	})
+}
+
+// AddFBStats will upsert all of the statistic records in data in one statement
+func (p *PGStore) AddFBStats(ctx context.Context, data []store.FbData) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	// postgres refuses to update the same row twice in one upsert,
+	// so only the last record of a duplicated key is kept
+	type statKey struct {
+		key       string
+		timestamp int64
+	}
+	positions := make(map[statKey]int)
+	records := make([]store.FbData, 0, len(data))
+	for _, d := range data {
+		k := statKey{d.Key, d.Timestamp}
+		if i, ok := positions[k]; ok {
+			records[i] = d
+			continue
+		}
+		positions[k] = len(records)
+		records = append(records, d)
+	}
+
+	q := psql.Insert("fbm.fbstat").
+		Columns("key", "timestamp", "data")
+
+	for _, r := range records {
+		q = q.Values(r.Key, r.Timestamp, r.Data)
+	}
+
+	q = q.Suffix("ON CONFLICT (key, timestamp) DO UPDATE SET data = EXCLUDED.data")
+
+	st, val, _ := q.ToSql()
+
+	_, err := p.pool.Exec(ctx, st, val...)
+	return err
+}
+
+// GetFBStat returns the stats of a key between from and to, newest first.
+// A zero limit returns all of them.
+func (p *PGStore) GetFBStat(ctx context.Context, key string, from, to, limit int64) ([][]byte, error) {
+	q := psql.Select("data").
+		From("fbm.fbstat").
+		Where(sq.Eq{"key": key}).
+		Where(sq.GtOrEq{"timestamp": from}).
+		Where(sq.LtOrEq{"timestamp": to}).
+		OrderBy("timestamp DESC")
+
+	if limit > 0 {
+		q = q.Limit(uint64(limit))
+	}
+
+	st, val, _ := q.ToSql()
+
+	rows, err := p.pool.Query(ctx, st, val...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data [][]byte
+	for rows.Next() {
+		var d []byte
+		if err := rows.Scan(&d); err != nil {
+			return nil, err
+		}
+
+		data = append(data, d)
+	}
+
+	return data, rows.Err()
+}
+
+// GetFBStatPage returns a page of stats of a key older than the cursor, newest first
+func (p *PGStore) GetFBStatPage(ctx context.Context, key string, from, to, limit int64, cursor *store.Cursor) ([][]byte, *store.Cursor, error) {
+	q := psql.Select("timestamp", "data").
+		From("fbm.fbstat").
+		Where(sq.Eq{"key": key}).
+		Where(sq.GtOrEq{"timestamp": from}).
+		Where(sq.LtOrEq{"timestamp": to}).
+		OrderBy("timestamp DESC").
+		Limit(uint64(limit) + 1) // one more to know whether there is a next page
+
+	if cursor != nil {
+		q = q.Where(sq.Lt{"timestamp": cursor.Timestamp})
+	}
+
+	st, val, _ := q.ToSql()
+
+	rows, err := p.pool.Query(ctx, st, val...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var data [][]byte
+	var timestamps []int64
+	for rows.Next() {
+		var ts int64
+		var d []byte
+		if err := rows.Scan(&ts, &d); err != nil {
+			return nil, nil, err
+		}
+
+		data = append(data, d)
+		timestamps = append(timestamps, ts)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if int64(len(data)) <= limit {
+		return data, nil, nil
+	}
+
+	return data[:limit], &store.Cursor{Timestamp: timestamps[limit-1]}, nil
+}
+
+// GetExactFBStat returns the stat of a key at the exact timestamp
+func (p *PGStore) GetExactFBStat(ctx context.Context, key string, in int64) ([]byte, error) {
+	q := psql.Select("data").
+		From("fbm.fbstat").
+		Where(sq.Eq{"key": key, "timestamp": in})
+
+	st, val, _ := q.ToSql()
+
+	var data []byte
+	if err := p.pool.
+		QueryRow(ctx, st, val...).
+		Scan(&data); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// RemoveFBStat removes all stats of a key
+func (p *PGStore) RemoveFBStat(ctx context.Context, key string) error {
+	q := psql.Delete("fbm.fbstat").Where(sq.Eq{"key": key})
+
+	st, val, _ := q.ToSql()
+
+	_, err := p.pool.Exec(ctx, st, val...)
+	return err
+}
+
+// RemoveExactFBStat removes the stat of a key at the exact timestamp
+func (p *PGStore) RemoveExactFBStat(ctx context.Context, key string, in int64) error {
+	q := psql.Delete("fbm.fbstat").Where(sq.Eq{"key": key, "timestamp": in})
+
+	st, val, _ := q.ToSql()
+
+	_, err := p.pool.Exec(ctx, st, val...)
+	return err
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FBStat(t *testing.T) {
+	loadTestConfig()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s, err := NewPGStore(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+
+	key := testAccountNumber1 + "/post"
+	assert.NoError(t, s.RemoveFBStat(ctx, key))
+
+	// Add stats
+	assert.NoError(t, s.AddFBStat(ctx, key, 100, []byte("100")))
+	assert.NoError(t, s.AddFBStats(ctx, []store.FbData{
+		{Key: key, Timestamp: 200, Data: []byte("200")},
+		{Key: key, Timestamp: 300, Data: []byte("old")},
+		{Key: key, Timestamp: 300, Data: []byte("300")},
+	}))
+
+	// Update an existing stat
+	assert.NoError(t, s.AddFBStat(ctx, key, 100, []byte("new")))
+
+	// Query stats
+	data, err := s.GetFBStat(ctx, key, 0, 1000, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("300"), []byte("200"), []byte("new")}, data)
+
+	data, err = s.GetFBStat(ctx, key, 100, 200, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("200"), []byte("new")}, data)
+
+	data, err = s.GetFBStat(ctx, key, 0, 1000, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("300")}, data)
+
+	data, err = s.GetFBStat(ctx, key, 400, 1000, 0)
+	assert.NoError(t, err)
+	assert.Len(t, data, 0)
+
+	d, err := s.GetExactFBStat(ctx, key, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("200"), d)
+
+	d, err = s.GetExactFBStat(ctx, key, 250)
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+
+	// Remove stats
+	assert.NoError(t, s.RemoveFBStat(ctx, key))
+
+	data, err = s.GetFBStat(ctx, key, 0, 1000, 0)
+	assert.NoError(t, err)
+	assert.Len(t, data, 0)
+}
@@ -35,7 +35,37 @@ CREATE TABLE fbm.token (
     expired_at TIMESTAMP WITH TIME ZONE DEFAULT now()
 );
 
-CREATE TYPE archive_status AS ENUM ('created', 'submitted', 'stored', 'processing', 'processed', 'invalid');
+-- access tokens denied until they expire, an empty id denies all tokens of an account issued before revoked_at
+CREATE TABLE fbm.revoked_token (
+    account_number TEXT NOT NULL,
+    id TEXT NOT NULL DEFAULT '',
+    revoked_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    expired_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (account_number, id)
+);
+
+-- api keys of the admin apis, only the sha256 hashes of the keys are stored
+CREATE TABLE fbm.api_key (
+    name TEXT NOT NULL PRIMARY KEY,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expired_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+-- admin actions, which are kept after their api keys are deleted
+CREATE TABLE fbm.audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    status INTEGER NOT NULL DEFAULT 0,
+    remote_addr TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE TYPE archive_status AS ENUM ('created', 'submitted', 'stored', 'processing', 'processed', 'invalid', 'removed');
 CREATE TABLE fbm.fbarchive (
     id SERIAL PRIMARY KEY,
     account_number TEXT NOT NULL REFERENCES fbm.account(account_number) ON DELETE CASCADE,
@@ -44,19 +74,37 @@ CREATE TABLE fbm.fbarchive (
     ending_time TIMESTAMP WITH TIME ZONE DEFAULT now(),
     analyzed_task_id TEXT DEFAULT '',
     content_hash TEXT DEFAULT '',
+    workflow_id TEXT DEFAULT '',
+    bitmark_id TEXT DEFAULT '',
     processing_status archive_status DEFAULT 'created',
     processing_error JSONB DEFAULT '{}',
+    merge_summary JSONB,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
 );
 
-CREATE TABLE fbm.fbdata (
-    data_name TEXT PRIMARY KEY,
-    data_value JSONB
+CREATE TABLE fbm.fbarchive_stage (
+    archive_id INTEGER NOT NULL REFERENCES fbm.fbarchive(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'running',
+    item_count BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (archive_id, name)
+);
+
+CREATE TABLE fbm.fbstat (
+    key TEXT NOT NULL,
+    timestamp BIGINT NOT NULL,
+    data BYTEA NOT NULL,
+    PRIMARY KEY (key, timestamp)
 );
 
 CREATE INDEX fbarchive_filekey ON fbm.fbarchive (file_key);
 CREATE INDEX fbarchive_account_number ON fbm.fbarchive (account_number);
+CREATE INDEX token_account_number ON fbm.token (account_number);
+CREATE INDEX audit_log_actor ON fbm.audit_log (actor);
 
 -- finished
 SET search_path TO DEFAULT;
@@ -9,6 +9,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/bitmark-inc/spring-app-api/store/storetest"
 )
 
 func loadTestConfig() {
@@ -32,3 +34,19 @@ func Test_PostgresEngine(t *testing.T) {
 	err = s.Close(ctx)
 	assert.NoError(t, err)
 }
+
+func Test_Conformance(t *testing.T) {
+	loadTestConfig()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s, err := NewPGStore(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Close(ctx)
+
+	storetest.TestStore(t, s)
+	storetest.TestFBDataStore(t, s)
+}
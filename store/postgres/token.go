@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+// accountRevocationID is the id of a revocation of all tokens of an account
+const accountRevocationID = ""
+
+func (p *PGStore) AddToken(ctx context.Context, tokenHash, accountNumber string, info map[string]interface{}, expireAt time.Time) (*store.Token, error) {
+	values := map[string]interface{}{
+		"id":             tokenHash,
+		"account_number": accountNumber,
+		"expired_at":     expireAt,
+	}
+	if info != nil {
+		values["info"] = info
+	}
+
+	q := psql.
+		Insert("fbm.token").
+		SetMap(values).
+		Suffix("RETURNING id, account_number, info, created_at, expired_at")
+
+	st, val, _ := q.ToSql()
+
+	var t store.Token
+	if err := p.pool.
+		QueryRow(ctx, st, val...).
+		Scan(&t.Token,
+			&t.AccountNumber,
+			&t.Info,
+			&t.CreatedAt,
+			&t.ExpireAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (p *PGStore) DeleteTokens(ctx context.Context, params *store.TokenQueryParam) ([]store.Token, error) {
+	if params.Token == nil && params.AccountNumber == nil {
+		return nil, errors.New("no condition to delete tokens")
+	}
+
+	q := psql.Delete("fbm.token").
+		Suffix("RETURNING id, account_number, info, created_at, expired_at")
+
+	if params.Token != nil {
+		q = q.Where(sq.Eq{"id": *params.Token})
+	}
+
+	if params.AccountNumber != nil {
+		q = q.Where(sq.Eq{"account_number": *params.AccountNumber})
+	}
+
+	st, val, _ := q.ToSql()
+
+	rows, err := p.pool.Query(ctx, st, val...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]store.Token, 0)
+	for rows.Next() {
+		var t store.Token
+		if err := rows.Scan(&t.Token,
+			&t.AccountNumber,
+			&t.Info,
+			&t.CreatedAt,
+			&t.ExpireAt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (p *PGStore) RevokeToken(ctx context.Context, accountNumber, tokenID string, expireAt time.Time) error {
+	return p.revoke(ctx, accountNumber, tokenID, time.Now(), expireAt)
+}
+
+func (p *PGStore) RevokeAccountTokens(ctx context.Context, accountNumber string, revokedAt, expireAt time.Time) error {
+	return p.revoke(ctx, accountNumber, accountRevocationID, revokedAt.Truncate(time.Second), expireAt)
+}
+
+// revoke upserts a revocation, so revoking again moves its time and its expiry
+func (p *PGStore) revoke(ctx context.Context, accountNumber, id string, revokedAt, expireAt time.Time) error {
+	q := psql.Insert("fbm.revoked_token").
+		Columns("account_number", "id", "revoked_at", "expired_at").
+		Values(accountNumber, id, revokedAt, expireAt).
+		Suffix(`ON CONFLICT (account_number, id) DO UPDATE SET revoked_at = EXCLUDED.revoked_at,
+				expired_at = EXCLUDED.expired_at`)
+
+	st, val, _ := q.ToSql()
+
+	_, err := p.pool.Exec(ctx, st, val...)
+	return err
+}
+
+func (p *PGStore) IsTokenRevoked(ctx context.Context, accountNumber, tokenID string, issuedAt time.Time) (bool, error) {
+	q := psql.Select("COUNT(*)").From("fbm.revoked_token").
+		Where(sq.Eq{"account_number": accountNumber}).
+		Where(sq.Or{
+			sq.Eq{"id": tokenID},
+			sq.And{
+				sq.Eq{"id": accountRevocationID},
+				sq.GtOrEq{"revoked_at": issuedAt},
+			},
+		}).
+		Where(sq.Gt{"expired_at": time.Now()})
+
+	st, val, _ := q.ToSql()
+
+	var count int64
+	if err := p.pool.QueryRow(ctx, st, val...).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (p *PGStore) DeleteExpiredTokens(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for _, table := range []string{"fbm.token", "fbm.revoked_token"} {
+		st, val, _ := psql.Delete(table).
+			Where(sq.Lt{"expired_at": before}).
+			ToSql()
+
+		t, err := p.pool.Exec(ctx, st, val...)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += t.RowsAffected()
+	}
+
+	return deleted, nil
+}
@@ -46,6 +46,62 @@ type Store interface {
 	// DeleteFBArchives to delete fbarchives with conditions
 	DeleteFBArchives(ctx context.Context, params *FBArchiveQueryParam) error
 
+	// StartFBArchiveStage to record the start of a stage of processing an archive.
+	// Starting a stage again resets it.
+	StartFBArchiveStage(ctx context.Context, archiveID int64, name string) error
+
+	// FinishFBArchiveStage to record the end of a stage with the number of processed items,
+	// and the error of the stage if it fails
+	FinishFBArchiveStage(ctx context.Context, archiveID int64, name string, itemCount int64, stageError string) error
+
+	// GetFBArchiveStages to fetch the stages of archives ordered by their archives and their starting time
+	GetFBArchiveStages(ctx context.Context, archiveIDs []int64) ([]FBArchiveStage, error)
+
+	// Token
+
+	// AddToken to add the hash of a refresh token of an account which expires at expireAt
+	AddToken(ctx context.Context, tokenHash, accountNumber string, info map[string]interface{}, expireAt time.Time) (*Token, error)
+
+	// DeleteTokens to delete refresh tokens with conditions and returns the deleted tokens.
+	// It is used to consume a refresh token, so a token is never used twice.
+	DeleteTokens(ctx context.Context, params *TokenQueryParam) ([]Token, error)
+
+	// RevokeToken to deny an access token of an account by its id until it expires at expireAt
+	RevokeToken(ctx context.Context, accountNumber, tokenID string, expireAt time.Time) error
+
+	// RevokeAccountTokens to deny all access tokens of an account issued before revokedAt.
+	// Tokens are issued at a precision of seconds, so revokedAt is truncated to the second
+	// and tokens issued in the same second are denied as well.
+	// The revocation is kept until expireAt, when all of the tokens are expired.
+	RevokeAccountTokens(ctx context.Context, accountNumber string, revokedAt, expireAt time.Time) error
+
+	// IsTokenRevoked to check whether an access token of an account issued at issuedAt is revoked
+	IsTokenRevoked(ctx context.Context, accountNumber, tokenID string, issuedAt time.Time) (bool, error)
+
+	// DeleteExpiredTokens to delete refresh tokens and revocations expired before a time
+	DeleteExpiredTokens(ctx context.Context, before time.Time) (int64, error)
+
+	// Admin
+
+	// AddAPIKey to add an api key with the hash of the key
+	AddAPIKey(ctx context.Context, name, keyHash string, scopes []string, expireAt *time.Time) (*APIKey, error)
+
+	// UseAPIKey to query an api key by the hash of the key and record the time it is used.
+	// Returns nil if there is no such key.
+	UseAPIKey(ctx context.Context, keyHash string) (*APIKey, error)
+
+	// GetAPIKeys to fetch all api keys ordered by their names
+	GetAPIKeys(ctx context.Context) ([]APIKey, error)
+
+	// DeleteAPIKey to delete an api key by its name
+	DeleteAPIKey(ctx context.Context, name string) error
+
+	// AddAuditLog to record an admin action
+	AddAuditLog(ctx context.Context, log *AuditLog) error
+
+	// GetAuditLogs to fetch audit logs with conditions, newest first
+	GetAuditLogs(ctx context.Context, params *AuditLogQueryParam) ([]AuditLog, error)
+
 	// Metrics
 
 	// CountAccountCreation to count account creation for a specific time range
@@ -57,6 +113,22 @@ type AccountQueryParam struct {
 	AccountNumber *string
 }
 
+// TokenQueryParam params for querying refresh tokens
+type TokenQueryParam struct {
+	// Token is the hash of a refresh token
+	Token         *string
+	AccountNumber *string
+}
+
+// AuditLogQueryParam params for querying audit logs
+type AuditLogQueryParam struct {
+	Actor *string
+
+	// Before is the id which the logs are older than
+	Before *int64
+	Limit  uint64
+}
+
 // FBArchiveQueryParam params for querying a fb archive
 type FBArchiveQueryParam struct {
 	ID            *int64
@@ -66,6 +138,8 @@ type FBArchiveQueryParam struct {
 	Error         interface{}
 	AnalyzedID    *string
 	ContentHash   *string
+	WorkflowID    *string
+	BitmarkID     *string
 }
 
 func ArchiveMessage(message string) *string {
@@ -95,8 +169,36 @@ var (
 	// FBArchiveStatusInvalid when an archive is either failed to download
 	// or there are errors while processing
 	FBArchiveStatusInvalid = "invalid"
+
+	// FBArchiveStatusRemoved when the data imported from an archive is removed
+	FBArchiveStatusRemoved = "removed"
+)
+
+const (
+	// Stages of processing an archive:
+
+	FBArchiveStageDownload          = "download"
+	FBArchiveStageHash              = "hash"
+	FBArchiveStageParse             = "parse"
+	FBArchiveStageAnalyzePosts      = "analyze_posts"
+	FBArchiveStageAnalyzeReactions  = "analyze_reactions"
+	FBArchiveStageAnalyzeComments   = "analyze_comments"
+	FBArchiveStageAnalyzeSentiments = "analyze_sentiments"
+	FBArchiveStageTimeMetadata      = "time_metadata"
+	FBArchiveStageNotify            = "notify"
+
+	// Statuses of a stage:
+
+	FBArchiveStageStatusRunning  = "running"
+	FBArchiveStageStatusFinished = "finished"
+	FBArchiveStageStatusFailed   = "failed"
 )
 
+// FBArchiveParseStage returns the stage of parsing the files of a pattern of an archive
+func FBArchiveParseStage(pattern string) string {
+	return FBArchiveStageParse + ":" + pattern
+}
+
 // FBDataStore an interface for storing fb archive data
 type FBDataStore interface {
 	// AddFBStat to add a FB stat
@@ -108,9 +210,16 @@ type FBDataStore interface {
 	// GetFBStat to get a FB stat
 	GetFBStat(ctx context.Context, key string, from, to, limit int64) ([][]byte, error)
 
+	// GetFBStatPage to get a page of FB stats older than the cursor, newest first.
+	// The returned cursor is nil when there are no more stats in the range.
+	GetFBStatPage(ctx context.Context, key string, from, to, limit int64, cursor *Cursor) ([][]byte, *Cursor, error)
+
 	// GetExactFBStat to get a FB stat exactly in timestamp
 	GetExactFBStat(ctx context.Context, key string, in int64) ([]byte, error)
 
 	// RemoveFBStat to delete fb stat with specific key and with any timestamp >= 0
 	RemoveFBStat(ctx context.Context, key string) error
+
+	// RemoveExactFBStat to delete fb stat with specific key exactly in timestamp
+	RemoveExactFBStat(ctx context.Context, key string, in int64) error
 }
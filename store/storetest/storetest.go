@@ -0,0 +1,857 @@
+// Package storetest provides conformance tests that any implementation of
+// store.Store and store.FBDataStore is expected to pass.
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bitmark-inc/spring-app-api/store"
+)
+
+var (
+	testAccountNumber1 = "storetest_account_1"
+	testAccountNumber2 = "storetest_account_2"
+)
+
+// TestStore runs the conformance tests of store.Store against s
+func TestStore(t *testing.T, s store.Store) {
+	t.Run("Account", func(t *testing.T) { testAccount(t, s) })
+	t.Run("FBArchive", func(t *testing.T) { testFBArchive(t, s) })
+	t.Run("InvalidFBArchive", func(t *testing.T) { testInvalidFBArchive(t, s) })
+	t.Run("FBArchiveStage", func(t *testing.T) { testFBArchiveStage(t, s) })
+	t.Run("CountAccountCreation", func(t *testing.T) { testCountAccountCreation(t, s) })
+	t.Run("Token", func(t *testing.T) { testToken(t, s) })
+	t.Run("TokenRevocation", func(t *testing.T) { testTokenRevocation(t, s) })
+	t.Run("APIKey", func(t *testing.T) { testAPIKey(t, s) })
+	t.Run("AuditLog", func(t *testing.T) { testAuditLog(t, s) })
+}
+
+// TestFBDataStore runs the conformance tests of store.FBDataStore against s
+func TestFBDataStore(t *testing.T, s store.FBDataStore) {
+	t.Run("FBStat", func(t *testing.T) { testFBStat(t, s) })
+	t.Run("FBStatLimit", func(t *testing.T) { testFBStatLimit(t, s) })
+	t.Run("FBStatPage", func(t *testing.T) { testFBStatPage(t, s) })
+}
+
+func resetAccounts(ctx context.Context, t *testing.T, s store.Store) {
+	assert.NoError(t, s.DeleteAccount(ctx, testAccountNumber1))
+	assert.NoError(t, s.DeleteAccount(ctx, testAccountNumber2))
+}
+
+func testAccount(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resetAccounts(ctx, t, s)
+	defer resetAccounts(ctx, t, s)
+
+	// Insert account without metadata
+	account, err := s.InsertAccount(ctx, testAccountNumber1, nil, nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, account) {
+		assert.Equal(t, testAccountNumber1, account.AccountNumber)
+		assert.Empty(t, account.Metadata)
+		assert.False(t, account.Deleting)
+	}
+
+	// Insert the same account again
+	_, err = s.InsertAccount(ctx, testAccountNumber1, nil, nil)
+	assert.Error(t, err)
+
+	// Insert with metadata
+	account, err = s.InsertAccount(ctx, testAccountNumber2, nil, map[string]interface{}{
+		"platform": "ios",
+		"version":  1,
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, account)
+
+	account, err = s.QueryAccount(ctx, &store.AccountQueryParam{
+		AccountNumber: &testAccountNumber2,
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, account) {
+		assert.Equal(t, testAccountNumber2, account.AccountNumber)
+		assert.Equal(t, map[string]interface{}{
+			"platform": "ios",
+			"version":  float64(1),
+		}, account.Metadata)
+	}
+
+	// Update metadata merges into the existing one
+	account, err = s.UpdateAccountMetadata(ctx, &store.AccountQueryParam{
+		AccountNumber: &testAccountNumber2,
+	}, map[string]interface{}{
+		"platform": "android",
+		"test":     "test",
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, account) {
+		assert.Equal(t, map[string]interface{}{
+			"platform": "android",
+			"version":  float64(1),
+			"test":     "test",
+		}, account.Metadata)
+	}
+
+	account, err = s.QueryAccount(ctx, &store.AccountQueryParam{
+		AccountNumber: &testAccountNumber2,
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, account) {
+		assert.Equal(t, "android", account.Metadata["platform"])
+	}
+
+	// Unknown accounts
+	unknown := "storetest_unknown_account"
+	account, err = s.QueryAccount(ctx, &store.AccountQueryParam{
+		AccountNumber: &unknown,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, account)
+
+	account, err = s.UpdateAccountMetadata(ctx, &store.AccountQueryParam{
+		AccountNumber: &unknown,
+	}, map[string]interface{}{"test": "test"})
+	assert.NoError(t, err)
+	assert.Nil(t, account)
+
+	// Delete account
+	assert.NoError(t, s.DeleteAccount(ctx, testAccountNumber1))
+	account, err = s.QueryAccount(ctx, &store.AccountQueryParam{
+		AccountNumber: &testAccountNumber1,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, account)
+}
+
+func testFBArchive(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resetAccounts(ctx, t, s)
+	defer resetAccounts(ctx, t, s)
+
+	_, err := s.InsertAccount(ctx, testAccountNumber1, nil, nil)
+	assert.NoError(t, err)
+
+	// Archives can not be added to unknown accounts
+	_, err = s.AddFBArchive(ctx, "storetest_unknown_account", time.Now(), time.Now())
+	assert.Error(t, err)
+
+	archive, err := s.AddFBArchive(ctx, testAccountNumber1, time.Now(), time.Now())
+	assert.NoError(t, err)
+	if !assert.NotNil(t, archive) {
+		return
+	}
+	assert.Equal(t, testAccountNumber1, archive.AccountNumber)
+	assert.Equal(t, store.FBArchiveStatusCreated, archive.ProcessingStatus)
+
+	archives, err := s.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		ID: &archive.ID,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, archives, 1) {
+		assert.JSONEq(t, `{}`, string(archives[0].ProcessingError))
+	}
+
+	// Walk through all of the statuses an archive goes through
+	s3Key := fmt.Sprintf("%s/facebook/archives/%d/archive.zip", testAccountNumber1, archive.ID)
+	archives, err = s.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+		ID: &archive.ID,
+	}, &store.FBArchiveQueryParam{
+		S3Key:  &s3Key,
+		Status: &store.FBArchiveStatusSubmitted,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, archives, 1) {
+		assert.Equal(t, s3Key, archives[0].S3Key)
+		assert.Equal(t, store.FBArchiveStatusSubmitted, archives[0].ProcessingStatus)
+	}
+
+	for _, status := range []string{
+		store.FBArchiveStatusStored,
+		store.FBArchiveStatusProcessing,
+		store.FBArchiveStatusProcessed,
+	} {
+		status := status
+		archives, err = s.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+			S3Key: &s3Key,
+		}, &store.FBArchiveQueryParam{
+			Status: &status,
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, archives, 1) {
+			assert.Equal(t, status, archives[0].ProcessingStatus)
+		}
+	}
+
+	// Unknown statuses are not accepted
+	wrongStatus := "wrong_status"
+	archives, _ = s.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+		ID: &archive.ID,
+	}, &store.FBArchiveQueryParam{
+		Status: &wrongStatus,
+	})
+	assert.Len(t, archives, 0)
+
+	// Hash, task id, workflow id and bitmark id are updated along with the status
+	contentHash := "hash"
+	taskID := "task_id"
+	workflowID := "workflow_id"
+	bitmarkID := "bitmark_id"
+	archives, err = s.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+		ID:    &archive.ID,
+		S3Key: &s3Key,
+	}, &store.FBArchiveQueryParam{
+		ContentHash: &contentHash,
+		AnalyzedID:  &taskID,
+		WorkflowID:  &workflowID,
+		BitmarkID:   &bitmarkID,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, archives, 1) {
+		assert.Equal(t, store.FBArchiveStatusProcessed, archives[0].ProcessingStatus)
+		assert.Equal(t, contentHash, archives[0].ContentHash)
+		assert.Equal(t, taskID, archives[0].AnalyzedTaskID)
+		assert.Equal(t, workflowID, archives[0].WorkflowID)
+		assert.Equal(t, bitmarkID, archives[0].BitmarkID)
+	}
+
+	// Updating with a not matching condition changes nothing
+	wrongS3Key := "wrong_s3_key"
+	archives, err = s.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+		ID:    &archive.ID,
+		S3Key: &wrongS3Key,
+	}, &store.FBArchiveQueryParam{
+		Status: &store.FBArchiveStatusInvalid,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, archives, 0)
+
+	// Query archives
+	archives, err = s.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		AccountNumber: &testAccountNumber1,
+		Status:        &store.FBArchiveStatusProcessed,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, archives, 1) {
+		assert.Equal(t, archive.ID, archives[0].ID)
+	}
+
+	archives, err = s.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		AccountNumber: &testAccountNumber1,
+		Status:        &store.FBArchiveStatusCreated,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, archives, 0)
+
+	// Delete archives
+	assert.NoError(t, s.DeleteFBArchives(ctx, &store.FBArchiveQueryParam{
+		ID: &archive.ID,
+	}))
+	archives, err = s.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		ID: &archive.ID,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, archives, 0)
+
+	// Archives are removed along with their account
+	archive, err = s.AddFBArchive(ctx, testAccountNumber1, time.Now(), time.Now())
+	assert.NoError(t, err)
+	assert.NotNil(t, archive)
+
+	assert.NoError(t, s.DeleteAccount(ctx, testAccountNumber1))
+	archives, err = s.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		AccountNumber: &testAccountNumber1,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, archives, 0)
+}
+
+func testInvalidFBArchive(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resetAccounts(ctx, t, s)
+	defer resetAccounts(ctx, t, s)
+
+	_, err := s.InsertAccount(ctx, testAccountNumber1, nil, nil)
+	assert.NoError(t, err)
+
+	archive, err := s.AddFBArchive(ctx, testAccountNumber1, time.Now(), time.Now())
+	assert.NoError(t, err)
+	if !assert.NotNil(t, archive) {
+		return
+	}
+
+	// Both id and error are required
+	assert.Error(t, s.InvalidFBArchive(ctx, &store.FBArchiveQueryParam{
+		Error: map[string]string{"code": "FILE_DOWNLOAD_FAILED"},
+	}))
+	assert.Error(t, s.InvalidFBArchive(ctx, &store.FBArchiveQueryParam{
+		ID: &archive.ID,
+	}))
+
+	assert.NoError(t, s.InvalidFBArchive(ctx, &store.FBArchiveQueryParam{
+		ID: &archive.ID,
+		Error: map[string]string{
+			"code":    "FILE_DOWNLOAD_FAILED",
+			"message": "fail to download the archive",
+		},
+	}))
+
+	archives, err := s.GetFBArchives(ctx, &store.FBArchiveQueryParam{
+		ID: &archive.ID,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, archives, 1) {
+		assert.Equal(t, store.FBArchiveStatusInvalid, archives[0].ProcessingStatus)
+		assert.JSONEq(t,
+			`{"code": "FILE_DOWNLOAD_FAILED", "message": "fail to download the archive"}`,
+			string(archives[0].ProcessingError))
+	}
+}
+
+func testFBArchiveStage(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resetAccounts(ctx, t, s)
+	defer resetAccounts(ctx, t, s)
+
+	_, err := s.InsertAccount(ctx, testAccountNumber1, nil, nil)
+	assert.NoError(t, err)
+
+	archive, err := s.AddFBArchive(ctx, testAccountNumber1, time.Now(), time.Now())
+	assert.NoError(t, err)
+	if !assert.NotNil(t, archive) {
+		return
+	}
+	otherArchive, err := s.AddFBArchive(ctx, testAccountNumber1, time.Now(), time.Now())
+	assert.NoError(t, err)
+	if !assert.NotNil(t, otherArchive) {
+		return
+	}
+
+	parseStage := store.FBArchiveParseStage("posts")
+	assert.NoError(t, s.StartFBArchiveStage(ctx, archive.ID, store.FBArchiveStageDownload))
+	assert.NoError(t, s.FinishFBArchiveStage(ctx, archive.ID, store.FBArchiveStageDownload, 1, ""))
+	assert.NoError(t, s.StartFBArchiveStage(ctx, archive.ID, parseStage))
+	assert.NoError(t, s.StartFBArchiveStage(ctx, otherArchive.ID, store.FBArchiveStageDownload))
+
+	stages, err := s.GetFBArchiveStages(ctx, []int64{archive.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, stages, 2) {
+		assert.Equal(t, archive.ID, stages[0].ArchiveID)
+		assert.Equal(t, store.FBArchiveStageDownload, stages[0].Name)
+		assert.Equal(t, store.FBArchiveStageStatusFinished, stages[0].Status)
+		assert.Equal(t, int64(1), stages[0].ItemCount)
+		assert.NotNil(t, stages[0].FinishedAt)
+
+		assert.Equal(t, parseStage, stages[1].Name)
+		assert.Equal(t, store.FBArchiveStageStatusRunning, stages[1].Status)
+		assert.Nil(t, stages[1].FinishedAt)
+	}
+
+	// A failed stage keeps its error until it starts again
+	assert.NoError(t, s.FinishFBArchiveStage(ctx, archive.ID, parseStage, 10, "invalid file"))
+	stages, err = s.GetFBArchiveStages(ctx, []int64{archive.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, stages, 2) {
+		assert.Equal(t, store.FBArchiveStageStatusFailed, stages[1].Status)
+		assert.Equal(t, int64(10), stages[1].ItemCount)
+		assert.Equal(t, "invalid file", stages[1].Error)
+	}
+
+	assert.NoError(t, s.StartFBArchiveStage(ctx, archive.ID, parseStage))
+	stages, err = s.GetFBArchiveStages(ctx, []int64{archive.ID, otherArchive.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, stages, 3) {
+		assert.Equal(t, parseStage, stages[1].Name)
+		assert.Equal(t, store.FBArchiveStageStatusRunning, stages[1].Status)
+		assert.Equal(t, int64(0), stages[1].ItemCount)
+		assert.Empty(t, stages[1].Error)
+		assert.Nil(t, stages[1].FinishedAt)
+
+		assert.Equal(t, otherArchive.ID, stages[2].ArchiveID)
+	}
+
+	// Stages are removed along with their archives
+	assert.NoError(t, s.DeleteFBArchives(ctx, &store.FBArchiveQueryParam{ID: &archive.ID}))
+	stages, err = s.GetFBArchiveStages(ctx, []int64{archive.ID})
+	assert.NoError(t, err)
+	assert.Len(t, stages, 0)
+}
+
+func testToken(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resetAccounts(ctx, t, s)
+	defer resetAccounts(ctx, t, s)
+
+	_, err := s.InsertAccount(ctx, testAccountNumber1, nil, nil)
+	assert.NoError(t, err)
+	_, err = s.InsertAccount(ctx, testAccountNumber2, nil, nil)
+	assert.NoError(t, err)
+
+	// Tokens can be added to registered accounts only
+	expireAt := time.Now().Add(time.Hour)
+	token, err := s.AddToken(ctx, "storetest_token_1", testAccountNumber1, map[string]interface{}{"client_type": "ios"}, expireAt)
+	assert.NoError(t, err)
+	if assert.NotNil(t, token) {
+		assert.Equal(t, "storetest_token_1", token.Token)
+		assert.Equal(t, testAccountNumber1, token.AccountNumber)
+		assert.Equal(t, "ios", token.Info["client_type"])
+		assert.WithinDuration(t, expireAt, token.ExpireAt, time.Millisecond)
+	}
+	_, err = s.AddToken(ctx, "storetest_token_1", testAccountNumber1, nil, expireAt)
+	assert.Error(t, err)
+	_, err = s.AddToken(ctx, "storetest_token_0", "storetest_account_unknown", nil, expireAt)
+	assert.Error(t, err)
+
+	_, err = s.AddToken(ctx, "storetest_token_2", testAccountNumber1, nil, expireAt)
+	assert.NoError(t, err)
+	_, err = s.AddToken(ctx, "storetest_token_3", testAccountNumber2, nil, expireAt)
+	assert.NoError(t, err)
+	_, err = s.AddToken(ctx, "storetest_token_4", testAccountNumber2, nil, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+
+	_, err = s.DeleteTokens(ctx, &store.TokenQueryParam{})
+	assert.Error(t, err)
+
+	// A token is deleted once
+	token1 := "storetest_token_1"
+	tokens, err := s.DeleteTokens(ctx, &store.TokenQueryParam{Token: &token1})
+	assert.NoError(t, err)
+	if assert.Len(t, tokens, 1) {
+		assert.Equal(t, testAccountNumber1, tokens[0].AccountNumber)
+	}
+	tokens, err = s.DeleteTokens(ctx, &store.TokenQueryParam{Token: &token1})
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 0)
+
+	// A token is not deleted with another account
+	token2 := "storetest_token_2"
+	tokens, err = s.DeleteTokens(ctx, &store.TokenQueryParam{Token: &token2, AccountNumber: &testAccountNumber2})
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 0)
+
+	// Expired tokens are deleted
+	deleted, err := s.DeleteExpiredTokens(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, deleted >= 1)
+
+	tokens, err = s.DeleteTokens(ctx, &store.TokenQueryParam{AccountNumber: &testAccountNumber2})
+	assert.NoError(t, err)
+	if assert.Len(t, tokens, 1) {
+		assert.Equal(t, "storetest_token_3", tokens[0].Token)
+	}
+
+	// Tokens are removed along with their accounts
+	assert.NoError(t, s.DeleteAccount(ctx, testAccountNumber1))
+	tokens, err = s.DeleteTokens(ctx, &store.TokenQueryParam{Token: &token2})
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 0)
+}
+
+func testTokenRevocation(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// revocations are kept after their accounts are deleted, so every run uses new token ids
+	// and revokes everything with expired revocations at the end
+	tokenID1 := fmt.Sprintf("storetest_token_%d", time.Now().UnixNano())
+	tokenID2 := tokenID1 + "_2"
+	defer func() {
+		past := time.Now().Add(-time.Minute)
+		assert.NoError(t, s.RevokeToken(ctx, testAccountNumber1, tokenID1, past))
+		assert.NoError(t, s.RevokeAccountTokens(ctx, testAccountNumber2, past, past))
+		_, err := s.DeleteExpiredTokens(ctx, time.Now())
+		assert.NoError(t, err)
+	}()
+
+	issuedAt := time.Now().Add(-time.Minute)
+
+	revoked, err := s.IsTokenRevoked(ctx, testAccountNumber1, tokenID1, issuedAt)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	assert.NoError(t, s.RevokeToken(ctx, testAccountNumber1, tokenID1, time.Now().Add(time.Hour)))
+	revoked, err = s.IsTokenRevoked(ctx, testAccountNumber1, tokenID1, issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	// Other tokens are not revoked
+	revoked, err = s.IsTokenRevoked(ctx, testAccountNumber1, tokenID2, issuedAt)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	revoked, err = s.IsTokenRevoked(ctx, testAccountNumber2, tokenID1, issuedAt)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Tokens of an account issued before the revocation are revoked
+	revokedAt := time.Now()
+	assert.NoError(t, s.RevokeAccountTokens(ctx, testAccountNumber2, revokedAt, time.Now().Add(time.Hour)))
+	revoked, err = s.IsTokenRevoked(ctx, testAccountNumber2, tokenID2, issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = s.IsTokenRevoked(ctx, testAccountNumber2, tokenID2, revokedAt.Add(time.Second))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Tokens issued in the same second as the revocation are revoked, even right after it
+	revoked, err = s.IsTokenRevoked(ctx, testAccountNumber2, tokenID2, time.Unix(revokedAt.Unix(), 0))
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	// Expired revocations are ignored
+	assert.NoError(t, s.RevokeToken(ctx, testAccountNumber1, tokenID1, time.Now().Add(-time.Second)))
+	revoked, err = s.IsTokenRevoked(ctx, testAccountNumber1, tokenID1, issuedAt)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func testAPIKey(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reset := func() {
+		assert.NoError(t, s.DeleteAPIKey(ctx, "storetest_key_1"))
+		assert.NoError(t, s.DeleteAPIKey(ctx, "storetest_key_2"))
+	}
+	reset()
+	defer reset()
+
+	expireAt := time.Now().Add(time.Hour)
+	key, err := s.AddAPIKey(ctx, "storetest_key_1", "storetest_hash_1", []string{"accounts:delete", "metrics:read"}, &expireAt)
+	assert.NoError(t, err)
+	if assert.NotNil(t, key) {
+		assert.Equal(t, "storetest_key_1", key.Name)
+		assert.Equal(t, []string{"accounts:delete", "metrics:read"}, key.Scopes)
+		assert.True(t, key.HasScope("metrics:read"))
+		assert.False(t, key.HasScope("archives:reparse"))
+		if assert.NotNil(t, key.ExpireAt) {
+			assert.WithinDuration(t, expireAt, *key.ExpireAt, time.Millisecond)
+		}
+		assert.Nil(t, key.LastUsedAt)
+	}
+
+	// Names and hashes are unique
+	_, err = s.AddAPIKey(ctx, "storetest_key_1", "storetest_hash_2", nil, nil)
+	assert.Error(t, err)
+	_, err = s.AddAPIKey(ctx, "storetest_key_2", "storetest_hash_1", nil, nil)
+	assert.Error(t, err)
+
+	key, err = s.AddAPIKey(ctx, "storetest_key_2", "storetest_hash_2", nil, nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, key) {
+		assert.Empty(t, key.Scopes)
+		assert.Nil(t, key.ExpireAt)
+	}
+
+	// Keys are found by their hashes and their uses are recorded
+	key, err = s.UseAPIKey(ctx, "storetest_hash_1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, key) {
+		assert.Equal(t, "storetest_key_1", key.Name)
+		assert.NotNil(t, key.LastUsedAt)
+	}
+	key, err = s.UseAPIKey(ctx, "storetest_hash_unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	keys, err := s.GetAPIKeys(ctx)
+	assert.NoError(t, err)
+	names := make([]string, 0)
+	for _, k := range keys {
+		if k.Name == "storetest_key_1" {
+			assert.NotNil(t, k.LastUsedAt)
+		}
+		if k.Name == "storetest_key_1" || k.Name == "storetest_key_2" {
+			names = append(names, k.Name)
+		}
+	}
+	assert.Equal(t, []string{"storetest_key_1", "storetest_key_2"}, names)
+
+	assert.NoError(t, s.DeleteAPIKey(ctx, "storetest_key_1"))
+	key, err = s.UseAPIKey(ctx, "storetest_hash_1")
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+}
+
+func testAuditLog(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// audit logs are never deleted, so every run uses new actors
+	actor := fmt.Sprintf("storetest_actor_%d", time.Now().UnixNano())
+	otherActor := actor + "_2"
+
+	for i := 0; i < 3; i++ {
+		log := &store.AuditLog{
+			Actor:      actor,
+			Action:     "POST /secret/delete-accounts",
+			Params:     map[string]interface{}{"body": map[string]interface{}{"account_numbers": []string{"a"}}},
+			Status:     200 + i,
+			RemoteAddr: "127.0.0.1",
+		}
+		assert.NoError(t, s.AddAuditLog(ctx, log))
+		assert.NotZero(t, log.ID)
+		assert.False(t, log.CreatedAt.IsZero())
+	}
+	assert.NoError(t, s.AddAuditLog(ctx, &store.AuditLog{Actor: otherActor, Action: "GET /secret/dead-letter-jobs"}))
+
+	logs, err := s.GetAuditLogs(ctx, &store.AuditLogQueryParam{Actor: &actor, Limit: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, logs, 2) {
+		assert.Equal(t, 202, logs[0].Status)
+		assert.Equal(t, 201, logs[1].Status)
+		assert.Equal(t, "POST /secret/delete-accounts", logs[0].Action)
+		assert.Equal(t, "127.0.0.1", logs[0].RemoteAddr)
+		assert.Equal(t, map[string]interface{}{"account_numbers": []interface{}{"a"}}, logs[0].Params["body"])
+
+		logs, err = s.GetAuditLogs(ctx, &store.AuditLogQueryParam{Actor: &actor, Before: &logs[1].ID})
+		assert.NoError(t, err)
+		if assert.Len(t, logs, 1) {
+			assert.Equal(t, 200, logs[0].Status)
+		}
+	}
+
+	logs, err = s.GetAuditLogs(ctx, &store.AuditLogQueryParam{Actor: &otherActor})
+	assert.NoError(t, err)
+	if assert.Len(t, logs, 1) {
+		assert.Empty(t, logs[0].Params)
+	}
+}
+
+func testCountAccountCreation(t *testing.T, s store.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resetAccounts(ctx, t, s)
+	defer resetAccounts(ctx, t, s)
+
+	account, err := s.InsertAccount(ctx, testAccountNumber1, nil, map[string]interface{}{
+		"platform": "ios",
+	})
+	assert.NoError(t, err)
+	if !assert.NotNil(t, account) {
+		return
+	}
+
+	// only the account created in this range are counted
+	from := account.CreatedAt
+	to := account.CreatedAt
+
+	// Accounts without a processed archive are not counted
+	archive, err := s.AddFBArchive(ctx, testAccountNumber1, time.Now(), time.Now())
+	assert.NoError(t, err)
+	if !assert.NotNil(t, archive) {
+		return
+	}
+
+	result, err := s.CountAccountCreation(ctx, from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result["ios"])
+
+	// Accounts with multiple processed archives are counted once
+	for i := 0; i < 2; i++ {
+		_, err = s.UpdateFBArchiveStatus(ctx, &store.FBArchiveQueryParam{
+			ID: &archive.ID,
+		}, &store.FBArchiveQueryParam{
+			Status: &store.FBArchiveStatusProcessed,
+		})
+		assert.NoError(t, err)
+
+		archive, err = s.AddFBArchive(ctx, testAccountNumber1, time.Now(), time.Now())
+		assert.NoError(t, err)
+	}
+
+	result, err = s.CountAccountCreation(ctx, from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result["ios"])
+	assert.Equal(t, 0, result["android"])
+
+	// Accounts created out of the range are not counted
+	result, err = s.CountAccountCreation(ctx, from.Add(time.Second), time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result["ios"])
+}
+
+func testFBStat(t *testing.T, s store.FBDataStore) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := testAccountNumber1 + "/post"
+	otherKey := testAccountNumber1 + "/reaction"
+	assert.NoError(t, s.RemoveFBStat(ctx, key))
+	assert.NoError(t, s.RemoveFBStat(ctx, otherKey))
+	defer s.RemoveFBStat(ctx, key)
+	defer s.RemoveFBStat(ctx, otherKey)
+
+	assert.NoError(t, s.AddFBStat(ctx, key, 100, []byte("100")))
+	assert.NoError(t, s.AddFBStats(ctx, []store.FbData{
+		{Key: key, Timestamp: 300, Data: []byte("300")},
+		{Key: key, Timestamp: 200, Data: []byte("200")},
+		{Key: otherKey, Timestamp: 200, Data: []byte("other")},
+	}))
+
+	// Adding to an existing timestamp replaces the stat
+	assert.NoError(t, s.AddFBStat(ctx, key, 100, []byte("new")))
+
+	// Stats are returned newest first
+	data, err := s.GetFBStat(ctx, key, 0, 1000, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("300"), []byte("200"), []byte("new")}, data)
+
+	// Both ends of the range are inclusive
+	data, err = s.GetFBStat(ctx, key, 100, 200, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("200"), []byte("new")}, data)
+
+	data, err = s.GetFBStat(ctx, key, 400, 1000, 0)
+	assert.NoError(t, err)
+	assert.Len(t, data, 0)
+
+	// Exact queries
+	d, err := s.GetExactFBStat(ctx, key, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("200"), d)
+
+	d, err = s.GetExactFBStat(ctx, key, 250)
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+
+	// Removing an exact stat leaves the other timestamps untouched
+	assert.NoError(t, s.RemoveExactFBStat(ctx, key, 300))
+	assert.NoError(t, s.RemoveExactFBStat(ctx, key, 250))
+
+	data, err = s.GetFBStat(ctx, key, 0, 1000, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("200"), []byte("new")}, data)
+
+	// Removing a key leaves the others untouched
+	assert.NoError(t, s.RemoveFBStat(ctx, key))
+
+	data, err = s.GetFBStat(ctx, key, 0, 1000, 0)
+	assert.NoError(t, err)
+	assert.Len(t, data, 0)
+
+	data, err = s.GetFBStat(ctx, otherKey, 0, 1000, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("other")}, data)
+}
+
+func testFBStatLimit(t *testing.T, s store.FBDataStore) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := testAccountNumber1 + "/post-week-stat"
+	assert.NoError(t, s.RemoveFBStat(ctx, key))
+	defer s.RemoveFBStat(ctx, key)
+
+	// push 30 stats in batches of 25, which is the most dynamodb takes at once
+	stats := make([]store.FbData, 0)
+	for i := int64(1); i <= 30; i++ {
+		stats = append(stats, store.FbData{
+			Key:       key,
+			Timestamp: i,
+			Data:      []byte(fmt.Sprint(i)),
+		})
+	}
+	assert.NoError(t, s.AddFBStats(ctx, stats[:25]))
+	assert.NoError(t, s.AddFBStats(ctx, stats[25:]))
+
+	// A zero limit returns everything
+	data, err := s.GetFBStat(ctx, key, 0, 100, 0)
+	assert.NoError(t, err)
+	assert.Len(t, data, 30)
+
+	// The newest stats are kept when there are more than the limit
+	data, err = s.GetFBStat(ctx, key, 0, 100, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, data, 10) {
+		assert.Equal(t, []byte("30"), data[0])
+		assert.Equal(t, []byte("21"), data[9])
+	}
+
+	// The limit is applied after the range
+	data, err = s.GetFBStat(ctx, key, 0, 15, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, data, 10) {
+		assert.Equal(t, []byte("15"), data[0])
+		assert.Equal(t, []byte("6"), data[9])
+	}
+
+	// A limit larger than the result returns everything in range
+	data, err = s.GetFBStat(ctx, key, 0, 5, 10)
+	assert.NoError(t, err)
+	assert.Len(t, data, 5)
+
+	// A limit equal to the result
+	data, err = s.GetFBStat(ctx, key, 0, 100, 30)
+	assert.NoError(t, err)
+	assert.Len(t, data, 30)
+}
+
+func testFBStatPage(t *testing.T, s store.FBDataStore) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := testAccountNumber1 + "/reaction"
+	assert.NoError(t, s.RemoveFBStat(ctx, key))
+	defer s.RemoveFBStat(ctx, key)
+
+	for i := int64(0); i < 25; i++ {
+		assert.NoError(t, s.AddFBStat(ctx, key, 100+i, []byte(fmt.Sprint(100+i))))
+	}
+
+	// Walk through the range [105, 200] page by page
+	var cursor *store.Cursor
+	var all [][]byte
+	pages := 0
+	for {
+		data, next, err := s.GetFBStatPage(ctx, key, 105, 200, 8, cursor)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, len(data) <= 8)
+		all = append(all, data...)
+		pages++
+
+		if next == nil {
+			break
+		}
+
+		// the cursor survives being handed to a client and back
+		cursor, err = store.ParseCursor(next.String())
+		assert.NoError(t, err)
+		assert.Equal(t, next, cursor)
+
+		if !assert.True(t, pages < 10, "too many pages") {
+			return
+		}
+	}
+
+	// an implementation may only find out the range is exhausted with an extra empty page
+	assert.True(t, pages == 3 || pages == 4)
+	if assert.Len(t, all, 20) {
+		assert.Equal(t, []byte("124"), all[0])
+		assert.Equal(t, []byte("117"), all[7])
+		assert.Equal(t, []byte("116"), all[8])
+		assert.Equal(t, []byte("105"), all[19])
+	}
+
+	// An empty range gives no cursor
+	data, next, err := s.GetFBStatPage(ctx, key, 300, 400, 8, nil)
+	assert.NoError(t, err)
+	assert.Len(t, data, 0)
+	assert.Nil(t, next)
+}